@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// envPrefix is prepended to the upper-cased configuration keys to build their environment variable override
+const envPrefix = "VKE_"
+
+// VKECloudProviderConfig is the configuration of the cloud provider. It extends the API
+// credentials Config with tuning settings. Zero values disable the related limits.
+type VKECloudProviderConfig struct {
+	Config `json:",inline"`
+
+	// MaxBatchDeleteNodes is the maximum number of nodes removed in a single API call.
+	MaxBatchDeleteNodes int `json:"max_batch_delete_nodes"`
+
+	// NodeGroupCacheTTL is the duration node pools are cached by the API client before being fetched again.
+	NodeGroupCacheTTL metav1.Duration `json:"node_group_cache_ttl"`
+
+	// APIBudgetPerCycle is the maximum number of API requests sent per autoscaling loop.
+	APIBudgetPerCycle int `json:"api_budget_per_cycle"`
+
+	// WorkerPoolSize is the number of API calls running at the same time.
+	WorkerPoolSize int `json:"worker_pool_size"`
+
+	// ExpanderPreferSpot makes the vke-priority expander prefer spot node pools over priorities.
+	ExpanderPreferSpot bool `json:"expander_prefer_spot"`
+
+	// MaxNodeProvisionTime is the time given to new nodes to be provisioned, unless overridden by the node pool.
+	MaxNodeProvisionTime metav1.Duration `json:"max_node_provision_time"`
+
+	// LabelPrefix is prepended to the names of the labels and annotations managed by the autoscaler.
+	LabelPrefix string `json:"label_prefix"`
+
+	// NodeNamePattern is the regular expression matching the node names, with the `cluster`,
+	// `pool` and `suffix` named groups. DefaultNodeNamePattern is used when empty.
+	NodeNamePattern string `json:"node_name_pattern"`
+
+	// DryRunBeforeScale validates scale ups with a dry-run call before performing them.
+	DryRunBeforeScale bool `json:"dry_run_before_scale"`
+
+	// SoftDeleteMode makes scale-downs only cordon and mark the nodes, which are deleted once confirmed.
+	SoftDeleteMode bool `json:"soft_delete_mode"`
+
+	// QuotaReservation is the part of the project quota left to other workloads, scale-ups not fitting
+	// in the rest of the quota being refused. No quota is reserved when unset.
+	QuotaReservation *QuotaReservation `json:"quota_reservation"`
+}
+
+// LoadVKECloudProviderConfig reads the YAML (or JSON) configuration file, then applies
+// the `VKE_<FIELD>` environment variables overrides, e.g. VKE_CLUSTER_ID.
+func LoadVKECloudProviderConfig(configPath string) (*VKECloudProviderConfig, error) {
+	configFile, err := os.Open(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer configFile.Close()
+
+	return readConfig(configFile)
+}
+
+// readConfig read cloud provider configuration file into a struct, applying environment variables overrides
+func readConfig(configFile io.Reader) (*VKECloudProviderConfig, error) {
+	cfg := &VKECloudProviderConfig{
+		LabelPrefix: sdk.DefaultLabelPrefix,
+	}
+	if configFile != nil {
+		body, err := io.ReadAll(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read content: %w", err)
+		}
+
+		err = yaml.Unmarshal(body, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+		}
+	}
+
+	if err := cfg.applyEnvOverrides(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the configuration is complete and its settings are in range
+func (cfg *VKECloudProviderConfig) Validate() error {
+	if err := validatePayload(&cfg.Config); err != nil {
+		return err
+	}
+
+	if cfg.MaxBatchDeleteNodes < 0 {
+		return fmt.Errorf("`max_batch_delete_nodes` should not be negative")
+	}
+
+	if cfg.NodeGroupCacheTTL.Duration < 0 {
+		return fmt.Errorf("`node_group_cache_ttl` should not be negative")
+	}
+
+	if cfg.MaxNodeProvisionTime.Duration < 0 {
+		return fmt.Errorf("`max_node_provision_time` should not be negative")
+	}
+
+	if cfg.APIBudgetPerCycle < 0 {
+		return fmt.Errorf("`api_budget_per_cycle` should not be negative")
+	}
+
+	if cfg.WorkerPoolSize < 0 {
+		return fmt.Errorf("`worker_pool_size` should not be negative")
+	}
+
+	if r := cfg.QuotaReservation; r != nil && (r.ReservedCPUs < 0 || r.ReservedMemoryGB < 0 || r.ReservedNodes < 0) {
+		return fmt.Errorf("`quota_reservation` amounts should not be negative")
+	}
+
+	if errs := validation.IsQualifiedName(cfg.AnnotationKey("name")); len(errs) > 0 {
+		return fmt.Errorf("`label_prefix` %q is not a valid label prefix: %s", cfg.LabelPrefix, strings.Join(errs, ", "))
+	}
+
+	if _, err := cfg.nodeNameRegexp(); err != nil {
+		return fmt.Errorf("`node_name_pattern` is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// applyEnvOverrides replaces the configuration values with the environment variables set
+func (cfg *VKECloudProviderConfig) applyEnvOverrides() error {
+	stringFields := map[string]*string{
+		"project_id":               &cfg.ProjectID,
+		"cluster_id":               &cfg.ClusterID,
+		"authentication_type":      &cfg.AuthenticationType,
+		"openstack_auth_url":       &cfg.OpenStackAuthUrl,
+		"openstack_username":       &cfg.OpenStackUsername,
+		"openstack_password":       &cfg.OpenStackPassword,
+		"openstack_domain":         &cfg.OpenStackDomain,
+		"application_endpoint":     &cfg.ApplicationEndpoint,
+		"application_key":          &cfg.ApplicationKey,
+		"application_secret":       &cfg.ApplicationSecret,
+		"application_consumer_key": &cfg.ApplicationConsumerKey,
+		"label_prefix":             &cfg.LabelPrefix,
+		"node_name_pattern":        &cfg.NodeNamePattern,
+	}
+	for key, field := range stringFields {
+		if value, ok := lookupEnv(key); ok {
+			*field = value
+		}
+	}
+
+	intFields := map[string]*int{
+		"max_batch_delete_nodes": &cfg.MaxBatchDeleteNodes,
+		"api_budget_per_cycle":   &cfg.APIBudgetPerCycle,
+		"worker_pool_size":       &cfg.WorkerPoolSize,
+	}
+	for key, field := range intFields {
+		if value, ok := lookupEnv(key); ok {
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", envName(key), err)
+			}
+			*field = i
+		}
+	}
+
+	boolFields := map[string]*bool{
+		"expander_prefer_spot": &cfg.ExpanderPreferSpot,
+		"dry_run_before_scale": &cfg.DryRunBeforeScale,
+		"soft_delete_mode":     &cfg.SoftDeleteMode,
+	}
+	for key, field := range boolFields {
+		if value, ok := lookupEnv(key); ok {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", envName(key), err)
+			}
+			*field = b
+		}
+	}
+
+	durationFields := map[string]*time.Duration{
+		"node_group_cache_ttl":    &cfg.NodeGroupCacheTTL.Duration,
+		"max_node_provision_time": &cfg.MaxNodeProvisionTime.Duration,
+	}
+	for key, field := range durationFields {
+		if value, ok := lookupEnv(key); ok {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", envName(key), err)
+			}
+			*field = d
+		}
+	}
+
+	return nil
+}
+
+// AnnotationKey builds the key of a label or annotation managed by the autoscaler from its name
+func (cfg *VKECloudProviderConfig) AnnotationKey(name string) string {
+	return sdk.AnnotationKey(cfg.LabelPrefix, name)
+}
+
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(key)
+}
+
+func lookupEnv(key string) (string, bool) {
+	return os.LookupEnv(envName(key))
+}
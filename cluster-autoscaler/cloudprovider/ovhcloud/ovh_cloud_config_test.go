@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testYAMLConfig = `
+project_id: projectID
+cluster_id: clusterID
+authentication_type: consumer
+application_endpoint: ovh-eu
+application_key: key
+application_secret: secret
+application_consumer_key: consumer_key
+max_batch_delete_nodes: 5
+node_group_cache_ttl: 30s
+api_budget_per_cycle: 100
+worker_pool_size: 4
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "cloud-config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadVKECloudProviderConfig(t *testing.T) {
+	t.Run("check YAML parsing", func(t *testing.T) {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "clusterID", cfg.ClusterID)
+		assert.Equal(t, "ovh-eu", cfg.ApplicationEndpoint)
+		assert.Equal(t, "key", cfg.ApplicationKey)
+		assert.Equal(t, 5, cfg.MaxBatchDeleteNodes)
+		assert.Equal(t, 30*time.Second, cfg.NodeGroupCacheTTL.Duration)
+		assert.Equal(t, 100, cfg.APIBudgetPerCycle)
+		assert.Equal(t, 4, cfg.WorkerPoolSize)
+		assert.Equal(t, "vke.autoscaler/", cfg.LabelPrefix)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("check JSON configuration is still supported", func(t *testing.T) {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, `{"project_id": "projectID", "cluster_id": "clusterID"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "clusterID", cfg.ClusterID)
+	})
+
+	t.Run("check environment variables override file values", func(t *testing.T) {
+		t.Setenv("VKE_CLUSTER_ID", "otherClusterID")
+		t.Setenv("VKE_MAX_BATCH_DELETE_NODES", "8")
+		t.Setenv("VKE_NODE_GROUP_CACHE_TTL", "1m")
+		t.Setenv("VKE_WORKER_POOL_SIZE", "8")
+		t.Setenv("VKE_MAX_NODE_PROVISION_TIME", "20m")
+
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "otherClusterID", cfg.ClusterID)
+		assert.Equal(t, 8, cfg.MaxBatchDeleteNodes)
+		assert.Equal(t, time.Minute, cfg.NodeGroupCacheTTL.Duration)
+		assert.Equal(t, 20*time.Minute, cfg.MaxNodeProvisionTime.Duration)
+		assert.Equal(t, "key", cfg.ApplicationKey)
+		assert.Equal(t, 8, cfg.WorkerPoolSize)
+		assert.Equal(t, 100, cfg.APIBudgetPerCycle)
+	})
+
+	t.Run("check label prefix", func(t *testing.T) {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+		assert.Equal(t, "vke.autoscaler/locked", cfg.AnnotationKey("locked"))
+
+		cfg, err = LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig+"label_prefix: example.com/\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com/locked", cfg.AnnotationKey("locked"))
+	})
+
+	t.Run("check malformed environment variable", func(t *testing.T) {
+		t.Setenv("VKE_MAX_BATCH_DELETE_NODES", "many")
+
+		_, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.Error(t, err)
+	})
+
+	t.Run("check missing file", func(t *testing.T) {
+		_, err := LoadVKECloudProviderConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestVKECloudProviderConfig_Validate(t *testing.T) {
+	newConfig := func(t *testing.T) *VKECloudProviderConfig {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+
+		return cfg
+	}
+
+	t.Run("check missing cluster ID", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.ClusterID = ""
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("check negative settings", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.MaxBatchDeleteNodes = -1
+		assert.Error(t, cfg.Validate())
+
+		cfg = newConfig(t)
+		cfg.NodeGroupCacheTTL.Duration = -time.Second
+		assert.Error(t, cfg.Validate())
+
+		cfg = newConfig(t)
+		cfg.APIBudgetPerCycle = -1
+		assert.Error(t, cfg.Validate())
+
+		cfg = newConfig(t)
+		cfg.WorkerPoolSize = -2
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("check soft delete mode", func(t *testing.T) {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+		assert.False(t, cfg.SoftDeleteMode)
+
+		t.Setenv("VKE_SOFT_DELETE_MODE", "true")
+
+		cfg, err = LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig))
+		assert.NoError(t, err)
+		assert.True(t, cfg.SoftDeleteMode)
+	})
+
+	t.Run("check quota reservation", func(t *testing.T) {
+		cfg, err := LoadVKECloudProviderConfig(writeTestConfig(t, testYAMLConfig+"quota_reservation:\n  reserved_cpus: 8\n  reserved_nodes: 2\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, &QuotaReservation{ReservedCPUs: 8, ReservedNodes: 2}, cfg.QuotaReservation)
+		assert.NoError(t, cfg.Validate())
+
+		cfg.QuotaReservation.ReservedNodes = -1
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("check invalid label prefix", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.LabelPrefix = "not a prefix/"
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("check invalid node name pattern", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.NodeNamePattern = "^(?P<cluster>.+)-(?P<pool>.+)$"
+		assert.Error(t, cfg.Validate())
+
+		cfg = newConfig(t)
+		cfg.NodeNamePattern = "(unclosed"
+		assert.Error(t, cfg.Validate())
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/apiserver/pkg/server/mux"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+const (
+	// ScaleHistoryDebugPath is the HTTP path exposing the scale operations history of a node group.
+	ScaleHistoryDebugPath = "/debug/vke/scale-history"
+
+	// NodeGroupDebugPath is the HTTP path exposing the diagnostic snapshot of a node group.
+	NodeGroupDebugPath = "/debug/vke/nodegroup"
+
+	// defaultScaleHistoryCount is the number of operations returned when not specified.
+	defaultScaleHistoryCount = 10
+)
+
+// NodeGroupDebugInfo is a diagnostic snapshot of the state of a node group.
+type NodeGroupDebugInfo struct {
+	NodeGroupID string    `json:"nodeGroupId"`
+	CollectedAt time.Time `json:"collectedAt"`
+
+	NodePool    *sdk.NodePool            `json:"nodePool"`
+	Nodes       []sdk.Node               `json:"nodes"`
+	Autoscaling *sdk.NodePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	TargetSize int    `json:"targetSize"`
+	Locked     bool   `json:"locked"`
+	LockReason string `json:"lockReason,omitempty"`
+
+	ScaleHistory []ScaleOperation `json:"scaleHistory"`
+}
+
+// RegisterDebugHandlers registers the cloud provider debugging endpoints on the autoscaler multiplexer,
+// served alongside the `/metrics` and `/snapshotz` endpoints.
+func (provider *OVHCloudProvider) RegisterDebugHandlers(mux *mux.PathRecorderMux) {
+	mux.HandleFunc(ScaleHistoryDebugPath, provider.ScaleHistoryHandler)
+	mux.HandleFunc(NodeGroupDebugPath, provider.NodeGroupDebugHandler)
+}
+
+// ScaleHistoryHandler returns as JSON the last scale operations of the node group given by the
+// `pool` query parameter. The number of operations can be set with the `n` query parameter.
+func (provider *OVHCloudProvider) ScaleHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	pool := r.URL.Query().Get("pool")
+	if pool == "" {
+		http.Error(w, "missing `pool` query parameter", http.StatusBadRequest)
+		return
+	}
+
+	count := defaultScaleHistoryCount
+	if n := r.URL.Query().Get("n"); n != "" {
+		var err error
+
+		count, err = strconv.Atoi(n)
+		if err != nil || count < 0 {
+			http.Error(w, "`n` query parameter should be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	operations := provider.manager.lastScaleOperations(pool, count)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(operations); err != nil {
+		klog.Errorf("failed to write scale history of node group %s: %v", pool, err)
+	}
+}
+
+// NodeGroupDebugHandler returns as JSON the diagnostic snapshot of the node group given by the `id` query parameter.
+func (provider *OVHCloudProvider) NodeGroupDebugHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "missing `id` query parameter", http.StatusBadRequest)
+		return
+	}
+
+	var ng *NodeGroup
+	for _, group := range provider.NodeGroups() {
+		if group.Id() == id {
+			ng = group.(*NodeGroup)
+			break
+		}
+	}
+
+	if ng == nil {
+		http.Error(w, fmt.Sprintf("node group %s not found", id), http.StatusNotFound)
+		return
+	}
+
+	info, err := ng.DebugInfo(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	body, err := MarshalDebugInfoJSON(info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		klog.Errorf("failed to write debug info of node group %s: %v", id, err)
+	}
+}
+
+// DebugInfo collects a diagnostic snapshot of the node group, fetching its current state from the API.
+func (ng *NodeGroup) DebugInfo(ctx context.Context) (*NodeGroupDebugInfo, error) {
+	pool, err := ng.Manager.Client.GetNodePool(ctx, ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", ng.ID, err)
+	}
+
+	nodes, err := ng.Manager.Client.ListNodePoolNodes(ctx, ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of node pool %s: %w", ng.ID, err)
+	}
+
+	size, err := ng.TargetSize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node group target size: %w", err)
+	}
+
+	locked, reason, err := sdk.IsNodePoolLocked(pool, ng.Manager.ProviderConfig.LabelPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check node pool lock: %w", err)
+	}
+
+	return &NodeGroupDebugInfo{
+		NodeGroupID:  ng.Id(),
+		CollectedAt:  time.Now(),
+		NodePool:     pool,
+		Nodes:        nodes,
+		Autoscaling:  pool.Autoscaling,
+		TargetSize:   size,
+		Locked:       locked,
+		LockReason:   reason,
+		ScaleHistory: ng.Manager.lastScaleOperations(ng.Id(), scaleHistorySize),
+	}, nil
+}
+
+// MarshalDebugInfoJSON encodes a diagnostic snapshot as indented JSON.
+func MarshalDebugInfoJSON(info *NodeGroupDebugInfo) ([]byte, error) {
+	body, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal debug info: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apiserver/pkg/server/mux"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func TestOVHCloudProvider_NodeGroupDebugHandler(t *testing.T) {
+	provider := newTestProvider(t)
+	client := provider.manager.Client.(*sdk.ClientMock)
+	ctx := context.Background()
+
+	pool := provider.manager.NodePools[0]
+	client.On("GetNodePool", ctx, "projectID", "clusterID", "1").Return(&pool, nil)
+	client.On("ListNodePoolNodes", ctx, "projectID", "clusterID", "1").Return(
+		[]sdk.Node{
+			{ID: "id-1", Name: "node-1", Status: "READY", InstanceID: "instance-1"},
+			{ID: "id-2", Name: "node-2", Status: "READY", InstanceID: "instance-2"},
+		}, nil,
+	)
+	provider.manager.getScaleHistory("pool-1").Add(ScaleOperation{Action: ScaleUpDirection, FromSize: 1, ToSize: 2})
+
+	t.Run("check snapshot is returned as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.NodeGroupDebugHandler(recorder, httptest.NewRequest("GET", NodeGroupDebugPath+"?id=pool-1", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.True(t, json.Valid(recorder.Body.Bytes()))
+
+		info := &NodeGroupDebugInfo{}
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), info))
+		assert.Equal(t, "pool-1", info.NodeGroupID)
+		assert.Equal(t, "1", info.NodePool.ID)
+		assert.Len(t, info.Nodes, 2)
+		assert.Equal(t, 2, info.TargetSize)
+		assert.False(t, info.Locked)
+		assert.Len(t, info.ScaleHistory, 1)
+
+		client.AssertCalled(t, "GetNodePool", ctx, "projectID", "clusterID", "1")
+		client.AssertCalled(t, "ListNodePoolNodes", ctx, "projectID", "clusterID", "1")
+	})
+
+	t.Run("check unknown node group", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.NodeGroupDebugHandler(recorder, httptest.NewRequest("GET", NodeGroupDebugPath+"?id=unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("check snapshot is served by the autoscaler multiplexer", func(t *testing.T) {
+		pathRecorderMux := mux.NewPathRecorderMux("test")
+		provider.RegisterDebugHandlers(pathRecorderMux)
+
+		recorder := httptest.NewRecorder()
+		pathRecorderMux.ServeHTTP(recorder, httptest.NewRequest("GET", NodeGroupDebugPath+"?id=unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "node group unknown not found")
+	})
+
+	t.Run("check missing id is rejected", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.NodeGroupDebugHandler(recorder, httptest.NewRequest("GET", NodeGroupDebugPath, nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
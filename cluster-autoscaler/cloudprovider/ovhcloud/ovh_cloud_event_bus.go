@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Scale directions of a ScaleEvent.
+const (
+	// ScaleUpDirection is used when nodes are added to a node group.
+	ScaleUpDirection = "up"
+
+	// ScaleDownDirection is used when nodes are removed from a node group.
+	ScaleDownDirection = "down"
+)
+
+// ScaleEvent describes a scaling action performed on a node group.
+type ScaleEvent struct {
+	NodeGroupID string
+	Direction   string
+	OldSize     int
+	NewSize     int
+	Timestamp   time.Time
+}
+
+// EventHandler is called with every scale event published on the bus.
+type EventHandler func(ScaleEvent)
+
+type subscription struct {
+	id      uint64
+	handler EventHandler
+}
+
+// EventBus fans out scale events to multiple subscribers (metrics, webhooks, audit log...).
+type EventBus struct {
+	mutex         sync.RWMutex
+	subscriptions []subscription
+	nextID        uint64
+}
+
+// NewEventBus creates an event bus without any subscriber.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscriptions: make([]subscription, 0),
+	}
+}
+
+// Subscribe registers a handler and returns the function removing it from the bus.
+func (b *EventBus) Subscribe(handler EventHandler) func() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscriptions = append(b.subscriptions, subscription{id: id, handler: handler})
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		for i, s := range b.subscriptions {
+			if s.id == id {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish calls every subscribed handler serially, in subscription order.
+func (b *EventBus) Publish(event ScaleEvent) {
+	for _, handler := range b.handlers() {
+		callHandler(handler, event)
+	}
+}
+
+// PublishAsync calls every subscribed handler in its own goroutine, without waiting for them.
+func (b *EventBus) PublishAsync(event ScaleEvent) {
+	for _, handler := range b.handlers() {
+		go callHandler(handler, event)
+	}
+}
+
+// handlers returns a snapshot of the subscribed handlers so they are called without holding the lock
+func (b *EventBus) handlers() []EventHandler {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	handlers := make([]EventHandler, 0, len(b.subscriptions))
+	for _, s := range b.subscriptions {
+		handlers = append(handlers, s.handler)
+	}
+
+	return handlers
+}
+
+// callHandler runs a handler and recovers from its panic so a bad subscriber does not affect the others
+func callHandler(handler EventHandler, event ScaleEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			klog.Errorf("scale event handler panicked on event %+v: %v", event, r)
+		}
+	}()
+
+	handler(event)
+}
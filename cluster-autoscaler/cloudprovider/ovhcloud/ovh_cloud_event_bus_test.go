@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus_Publish(t *testing.T) {
+	event := ScaleEvent{NodeGroupID: "pool-b2-7", Direction: ScaleUpDirection, OldSize: 3, NewSize: 4}
+
+	t.Run("check every subscriber receives the event", func(t *testing.T) {
+		bus := NewEventBus()
+
+		received := make([]ScaleEvent, 0)
+		for i := 0; i < 3; i++ {
+			bus.Subscribe(func(e ScaleEvent) {
+				received = append(received, e)
+			})
+		}
+
+		bus.Publish(event)
+
+		assert.Equal(t, []ScaleEvent{event, event, event}, received)
+	})
+
+	t.Run("check unsubscribed handler is not called", func(t *testing.T) {
+		bus := NewEventBus()
+
+		calls := 0
+		unsubscribe := bus.Subscribe(func(e ScaleEvent) {
+			calls++
+		})
+		bus.Subscribe(func(e ScaleEvent) {})
+
+		unsubscribe()
+		bus.Publish(event)
+
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("check panicking handler does not prevent other handlers", func(t *testing.T) {
+		bus := NewEventBus()
+
+		calls := 0
+		bus.Subscribe(func(e ScaleEvent) {
+			panic("bad handler")
+		})
+		bus.Subscribe(func(e ScaleEvent) {
+			calls++
+		})
+
+		assert.NotPanics(t, func() { bus.Publish(event) })
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestEventBus_PublishAsync(t *testing.T) {
+	event := ScaleEvent{NodeGroupID: "pool-b2-7", Direction: ScaleDownDirection, OldSize: 3, NewSize: 2}
+
+	t.Run("check every subscriber receives the event despite a panicking handler", func(t *testing.T) {
+		bus := NewEventBus()
+
+		wg := sync.WaitGroup{}
+		wg.Add(3)
+
+		mutex := sync.Mutex{}
+		received := 0
+		for i := 0; i < 3; i++ {
+			bus.Subscribe(func(e ScaleEvent) {
+				defer wg.Done()
+
+				mutex.Lock()
+				received++
+				mutex.Unlock()
+			})
+		}
+		bus.Subscribe(func(e ScaleEvent) {
+			panic("bad handler")
+		})
+
+		bus.PublishAsync(event)
+		wg.Wait()
+
+		assert.Equal(t, 3, received)
+	})
+}
+
+func TestOVHCloudNodeGroup_PublishScaleEvents(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+
+	events := make(chan ScaleEvent, 1)
+	ng.Manager.EventBus.Subscribe(func(e ScaleEvent) {
+		events <- e
+	})
+
+	t.Run("check increase size publishes a scale-up event", func(t *testing.T) {
+		ng.mockCallUpdateNodePool(4, nil)
+
+		err := ng.IncreaseSize(1)
+		assert.NoError(t, err)
+
+		select {
+		case e := <-events:
+			assert.Equal(t, ng.Id(), e.NodeGroupID)
+			assert.Equal(t, ScaleUpDirection, e.Direction)
+			assert.Equal(t, 3, e.OldSize)
+			assert.Equal(t, 4, e.NewSize)
+		case <-time.After(time.Second):
+			assert.Fail(t, "no scale event published")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// ScaleRequest asks to resize a node pool of one of the federated clusters.
+type ScaleRequest struct {
+	ClusterID    string
+	PoolID       string
+	DesiredNodes int
+}
+
+// ScaleResult is the outcome of a ScaleRequest.
+type ScaleResult struct {
+	ClusterID string
+	PoolID    string
+	Success   bool
+	Error     error
+}
+
+// FederatedScaler resizes node pools of several clusters of a same project concurrently.
+type FederatedScaler struct {
+	ProjectID string
+
+	clients map[string]ClientInterface
+	mutex   sync.RWMutex
+}
+
+// NewFederatedScaler creates a scaler without any cluster.
+func NewFederatedScaler(projectID string) *FederatedScaler {
+	return &FederatedScaler{
+		ProjectID: projectID,
+		clients:   make(map[string]ClientInterface),
+	}
+}
+
+// AddCluster registers the client used to scale the node pools of a cluster, replacing any previous one.
+func (fs *FederatedScaler) AddCluster(clusterID string, c ClientInterface) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	fs.clients[clusterID] = c
+}
+
+// ScaleAll applies all the requests concurrently. Results are returned in the requests order,
+// whatever the failures. The returned error aggregates the errors of the failed requests.
+func (fs *FederatedScaler) ScaleAll(ctx context.Context, requests []ScaleRequest) ([]ScaleResult, error) {
+	results := make([]ScaleResult, len(requests))
+
+	group := errgroup.Group{}
+	for i, request := range requests {
+		i, request := i, request
+
+		group.Go(func() error {
+			err := fs.scale(ctx, request)
+
+			results[i] = ScaleResult{
+				ClusterID: request.ClusterID,
+				PoolID:    request.PoolID,
+				Success:   err == nil,
+				Error:     err,
+			}
+
+			// Failures are reported in the results so they do not interrupt the other requests
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	errs := make([]error, 0)
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to scale node pool %s of cluster %s: %w", result.PoolID, result.ClusterID, result.Error))
+		}
+	}
+
+	return results, utilerrors.NewAggregate(errs)
+}
+
+// scale applies a single request.
+func (fs *FederatedScaler) scale(ctx context.Context, request ScaleRequest) error {
+	if request.DesiredNodes < 0 {
+		return fmt.Errorf("desired nodes should not be negative, got %d", request.DesiredNodes)
+	}
+
+	fs.mutex.RLock()
+	client, ok := fs.clients[request.ClusterID]
+	fs.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("cluster %s is not registered", request.ClusterID)
+	}
+
+	desired := uint32(request.DesiredNodes)
+	_, err := client.UpdateNodePool(ctx, fs.ProjectID, request.ClusterID, request.PoolID, &sdk.UpdateNodePoolOpts{
+		DesiredNodes: &desired,
+	})
+
+	return err
+}
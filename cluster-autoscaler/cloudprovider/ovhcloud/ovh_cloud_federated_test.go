@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func TestFederatedScaler_ScaleAll(t *testing.T) {
+	ctx := context.Background()
+
+	clientA := &sdk.ClientMock{}
+	clientA.On("UpdateNodePool", ctx, "projectID", "clusterA", "pool-a", mock.Anything).Return(&sdk.NodePool{}, nil)
+
+	clientB := &sdk.ClientMock{}
+	clientB.On("UpdateNodePool", ctx, "projectID", "clusterB", "pool-b", mock.Anything).Return(&sdk.NodePool{}, errors.New("quota exceeded"))
+
+	scaler := NewFederatedScaler("projectID")
+	scaler.AddCluster("clusterA", clientA)
+	scaler.AddCluster("clusterB", clientB)
+
+	t.Run("check all results are returned on partial failure", func(t *testing.T) {
+		results, err := scaler.ScaleAll(ctx, []ScaleRequest{
+			{ClusterID: "clusterA", PoolID: "pool-a", DesiredNodes: 3},
+			{ClusterID: "clusterB", PoolID: "pool-b", DesiredNodes: 5},
+		})
+		assert.ErrorContains(t, err, "quota exceeded")
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, ScaleResult{ClusterID: "clusterA", PoolID: "pool-a", Success: true}, results[0])
+		assert.Equal(t, "clusterB", results[1].ClusterID)
+		assert.False(t, results[1].Success)
+		assert.EqualError(t, results[1].Error, "quota exceeded")
+
+		clientA.AssertCalled(t, "UpdateNodePool", ctx, "projectID", "clusterA", "pool-a", &sdk.UpdateNodePoolOpts{DesiredNodes: ptrUint32(3)})
+	})
+
+	t.Run("check unknown cluster and invalid size are reported", func(t *testing.T) {
+		results, err := scaler.ScaleAll(ctx, []ScaleRequest{
+			{ClusterID: "clusterC", PoolID: "pool-c", DesiredNodes: 1},
+			{ClusterID: "clusterA", PoolID: "pool-a", DesiredNodes: -1},
+		})
+		assert.Error(t, err)
+		assert.False(t, results[0].Success)
+		assert.False(t, results[1].Success)
+	})
+
+	t.Run("check no requests", func(t *testing.T) {
+		results, err := scaler.ScaleAll(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
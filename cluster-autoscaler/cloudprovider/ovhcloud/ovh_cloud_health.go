@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+)
+
+const (
+	// HealthFactorReadyNodes is the fraction of the node pool nodes Ready in Kubernetes
+	HealthFactorReadyNodes = "ready-nodes"
+
+	// HealthFactorNodesNotInError is the fraction of the node pool nodes not in error on the VKE side
+	HealthFactorNodesNotInError = "nodes-not-in-error"
+
+	// HealthFactorNoBackoff is 1 when the last scale operation of the node pool succeeded
+	HealthFactorNoBackoff = "no-backoff"
+
+	// HealthFactorAPIReachable is 1 when the OVHcloud API answered
+	HealthFactorAPIReachable = "api-reachable"
+)
+
+// nodeErrorStatus is the status of the VKE nodes which failed
+const nodeErrorStatus = "ERROR"
+
+// HealthScore is the health of a node pool, from 0 to 1 (perfect health), computed as the weighted sum of its factors.
+type HealthScore struct {
+	Score   float64
+	Factors []HealthFactor
+}
+
+// HealthFactor is a signal contributing to a node pool health score. Value ranges from 0 to 1.
+type HealthFactor struct {
+	Name   string
+	Weight float64
+	Value  float64
+}
+
+// ComputeNodePoolHealthScore computes the health score of a node pool from its nodes state on both the
+// VKE and the Kubernetes sides, its last scale operation and the availability of the OVHcloud API.
+// An unreachable API lowers the score rather than failing; Kubernetes API errors are returned.
+func (m *OvhCloudManager) ComputeNodePoolHealthScore(ctx context.Context, pool *sdk.NodePool, k8sClient kubernetes.Interface) (*HealthScore, error) {
+	apiReachable, readyNodes, nodesNotInError := 1.0, 0.0, 0.0
+
+	poolNodes, err := m.Client.ListNodePoolNodes(ctx, m.ProjectID, m.ClusterID, pool.ID)
+	if err != nil {
+		klog.Warningf("Failed to list nodes of node pool %s: %v", pool.Name, err)
+		apiReachable = 0
+	} else if len(poolNodes) == 0 {
+		readyNodes, nodesNotInError = 1, 1
+	} else {
+		var ready, notInError int
+		for _, poolNode := range poolNodes {
+			if poolNode.Status != nodeErrorStatus {
+				notInError++
+			}
+
+			node, err := k8sClient.CoreV1().Nodes().Get(ctx, poolNode.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get node %s: %w", poolNode.Name, err)
+			}
+
+			if isReady, _, err := kube_util.GetReadinessState(node); err == nil && isReady {
+				ready++
+			}
+		}
+
+		readyNodes = float64(ready) / float64(len(poolNodes))
+		nodesNotInError = float64(notInError) / float64(len(poolNodes))
+	}
+
+	noBackoff := 1.0
+	if last := m.lastScaleOperations(pool.Name, 1); len(last) == 1 && last[0].Error != "" {
+		noBackoff = 0
+	}
+
+	return newHealthScore([]HealthFactor{
+		{Name: HealthFactorReadyNodes, Weight: 0.4, Value: readyNodes},
+		{Name: HealthFactorNodesNotInError, Weight: 0.3, Value: nodesNotInError},
+		{Name: HealthFactorNoBackoff, Weight: 0.15, Value: noBackoff},
+		{Name: HealthFactorAPIReachable, Weight: 0.15, Value: apiReachable},
+	}), nil
+}
+
+// newHealthScore computes the weighted sum of the factors
+func newHealthScore(factors []HealthFactor) *HealthScore {
+	var score, weights float64
+	for _, factor := range factors {
+		score += factor.Weight * factor.Value
+		weights += factor.Weight
+	}
+
+	if weights > 0 {
+		score /= weights
+	}
+
+	return &HealthScore{
+		Score:   score,
+		Factors: factors,
+	}
+}
+
+// IsPoolHealthy tells whether a health score reaches the given threshold.
+func IsPoolHealthy(score *HealthScore, threshold float64) bool {
+	return score != nil && score.Score >= threshold
+}
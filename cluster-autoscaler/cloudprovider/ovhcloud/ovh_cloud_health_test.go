@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func newTestHealthNode(name string, ready apiv1.ConditionStatus) *apiv1.Node {
+	return &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: apiv1.NodeStatus{
+			Conditions: []apiv1.NodeCondition{
+				{Type: apiv1.NodeReady, Status: ready},
+			},
+		},
+	}
+}
+
+func TestOvhCloudManager_ComputeNodePoolHealthScore(t *testing.T) {
+	provider := newTestProvider(t)
+	manager := provider.manager
+	client := manager.Client.(*sdk.ClientMock)
+	ctx := context.Background()
+
+	client.On("ListNodePoolNodes", ctx, "projectID", "clusterID", "1").Return(
+		[]sdk.Node{
+			{Name: "node-1", Status: "READY"},
+			{Name: "node-2", Status: "READY"},
+			{Name: "node-3", Status: "ERROR"},
+			{Name: "node-4", Status: "ERROR"},
+		}, nil,
+	)
+	client.On("ListNodePoolNodes", ctx, "projectID", "clusterID", "2").Return([]sdk.Node(nil), errors.New("unavailable"))
+
+	k8sClient := fake.NewSimpleClientset(
+		newTestHealthNode("node-1", apiv1.ConditionTrue),
+		newTestHealthNode("node-2", apiv1.ConditionTrue),
+		newTestHealthNode("node-3", apiv1.ConditionFalse),
+	)
+
+	pool := &sdk.NodePool{ID: "1", Name: "pool-1"}
+
+	t.Run("check score of a half-degraded pool", func(t *testing.T) {
+		score, err := manager.ComputeNodePoolHealthScore(ctx, pool, k8sClient)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []HealthFactor{
+			{Name: HealthFactorReadyNodes, Weight: 0.4, Value: 0.5},
+			{Name: HealthFactorNodesNotInError, Weight: 0.3, Value: 0.5},
+			{Name: HealthFactorNoBackoff, Weight: 0.15, Value: 1},
+			{Name: HealthFactorAPIReachable, Weight: 0.15, Value: 1},
+		}, score.Factors)
+		assert.InDelta(t, 0.65, score.Score, 1e-9)
+
+		assert.True(t, IsPoolHealthy(score, 0.6))
+		assert.False(t, IsPoolHealthy(score, 0.7))
+	})
+
+	t.Run("check failed scale operation lowers the score", func(t *testing.T) {
+		manager.getScaleHistory("pool-1").Add(newScaleOperation(ScaleUpDirection, 4, 5, time.Now(), errors.New("quota exceeded")))
+
+		score, err := manager.ComputeNodePoolHealthScore(ctx, pool, k8sClient)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.5, score.Score, 1e-9)
+	})
+
+	t.Run("check unreachable API", func(t *testing.T) {
+		score, err := manager.ComputeNodePoolHealthScore(ctx, &sdk.NodePool{ID: "2", Name: "pool-2"}, k8sClient)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.15, score.Score, 1e-9)
+		assert.False(t, IsPoolHealthy(score, 0.5))
+	})
+
+	t.Run("check nil score is unhealthy", func(t *testing.T) {
+		assert.False(t, IsPoolHealthy(nil, 0))
+	})
+}
@@ -18,14 +18,16 @@ package ovhcloud
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"slices"
 	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
 	"k8s.io/klog/v2"
 )
@@ -37,6 +39,9 @@ type ClientInterface interface {
 	// ListNodePools lists all the node pools found in a Kubernetes cluster.
 	ListNodePools(ctx context.Context, projectID string, clusterID string) ([]sdk.NodePool, error)
 
+	// GetNodePool gets the details of a node pool.
+	GetNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*sdk.NodePool, error)
+
 	// ListNodePoolNodes lists all the nodes contained in a node pool.
 	ListNodePoolNodes(ctx context.Context, projectID string, clusterID string, poolID string) ([]sdk.Node, error)
 
@@ -46,11 +51,41 @@ type ClientInterface interface {
 	// UpdateNodePool updates the details of an existing node pool.
 	UpdateNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *sdk.UpdateNodePoolOpts) (*sdk.NodePool, error)
 
+	// DryRunUpdateNodePool validates an update of a specific pool without executing it.
+	DryRunUpdateNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *sdk.UpdateNodePoolOpts) (*sdk.DryRunResult, error)
+
+	// ListSpotInterruptions lists the pending interruption notices of the spot nodes of a specific pool.
+	ListSpotInterruptions(ctx context.Context, projectID string, clusterID string, poolID string) ([]sdk.SpotInterruption, error)
+
 	// DeleteNodePool deletes a specific pool.
 	DeleteNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*sdk.NodePool, error)
 
 	// ListClusterFlavors list all available flavors usable in a Kubernetes cluster.
 	ListClusterFlavors(ctx context.Context, projectID string, clusterID string) ([]sdk.Flavor, error)
+
+	// GetFlavorPricing gets the price of a flavor usable in a Kubernetes cluster.
+	GetFlavorPricing(ctx context.Context, projectID string, clusterID string, flavorName string) (*sdk.FlavorPricing, error)
+
+	// GetCluster gets the details of the Kubernetes cluster.
+	GetCluster(ctx context.Context, projectID string, clusterID string) (*sdk.Cluster, error)
+
+	// ListQuotas lists the quotas of the project in every region.
+	ListQuotas(ctx context.Context, projectID string) ([]sdk.Quota, error)
+
+	// InitialiseNodePool applies the standard labels and annotations expected by the autoscaler to a pool.
+	InitialiseNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *sdk.InitialiseOpts) error
+
+	// CheckNodePoolQuota checks that adding nodes to a pool does not exceed its own quota.
+	CheckNodePoolQuota(ctx context.Context, projectID string, clusterID string, poolID string, requested int) error
+
+	// ListCapacityReservations lists the capacity reservations of a pool.
+	ListCapacityReservations(ctx context.Context, projectID string, clusterID string, poolID string) ([]sdk.CapacityReservation, error)
+
+	// GetScalingEvents lists the scale actions of a pool which occurred since the given time.
+	GetScalingEvents(ctx context.Context, projectID string, clusterID string, poolID string, since time.Time) ([]sdk.ScalingEvent, error)
+
+	// Shutdown stops making new calls and waits for the in-flight ones to complete.
+	Shutdown(ctx context.Context) error
 }
 
 // OvhCloudManager defines current application context manager to interact
@@ -68,6 +103,46 @@ type OvhCloudManager struct {
 
 	FlavorsCache               map[string]sdk.Flavor
 	FlavorsCacheExpirationTime time.Time
+
+	// EventBus notifies subscribers of every scale action performed on node groups
+	EventBus *EventBus
+
+	// ScaleHistoryPerNodeGroup keeps the last scale operations of each node group, served by the debug handlers
+	ScaleHistoryPerNodeGroup     map[string]*ScaleOperationRingBuffer
+	ScaleHistoryPerNodeGroupLock sync.Mutex
+
+	// PoolOperations serialises the updates of a same node pool
+	PoolOperations *PoolOperationQueue
+
+	// CallBudget limits the API requests sent per autoscaling loop, when set
+	CallBudget *sdk.CallBudget
+
+	TargetSizeHistoryPerNodePool     map[string][]TargetSizeRecord
+	TargetSizeHistoryPerNodePoolLock sync.Mutex
+
+	// SpotInterruptionHandlers are the running spot interruption handlers, stopped on cleanup
+	SpotInterruptionHandlers     []*SpotInterruptionHandler
+	SpotInterruptionHandlersLock sync.Mutex
+
+	// QuotaReservation is the part of the project quota the autoscaler must leave untouched (optional)
+	QuotaReservation *QuotaReservation
+
+	// ProviderConfig holds the tuning settings read from the configuration file
+	ProviderConfig *VKECloudProviderConfig
+
+	// PendingPodsLister lists the pods a scale-up should help to schedule (optional)
+	PendingPodsLister PendingPodsLister
+
+	// SoftDeleteMode makes scale-downs only mark the nodes for deletion, using KubeClient
+	SoftDeleteMode bool
+
+	// KubeClient is used to mark the nodes in soft delete mode, and to drain the interrupted spot nodes (optional)
+	KubeClient kubernetes.Interface
+
+	// PendingDeletionsPerNodePool holds the names of the nodes of each node pool waiting for their deletion to be confirmed,
+	// which are still counted in the node pool desired nodes but not in its target size
+	PendingDeletionsPerNodePool     map[string]map[string]struct{}
+	PendingDeletionsPerNodePoolLock sync.Mutex
 }
 
 // Config is the configuration file content of OVHcloud provider
@@ -107,7 +182,6 @@ const (
 
 // NewManager initializes an API client given a cloud provider configuration file
 func NewManager(configFile io.Reader) (*OvhCloudManager, error) {
-	var client ClientInterface
 	var openStackProvider *sdk.OpenStackProvider
 
 	// First, read configuration file to properly boot API client
@@ -117,12 +191,27 @@ func NewManager(configFile io.Reader) (*OvhCloudManager, error) {
 	}
 
 	// Then, validate payload
-	err = validatePayload(cfg)
+	err = cfg.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("config content validation failed: %w", err)
 	}
 
+	options := []sdk.ClientOption{sdk.WithLabelPrefix(cfg.LabelPrefix)}
+	if cfg.NodeGroupCacheTTL.Duration > 0 {
+		options = append(options, sdk.WithNodePoolCacheTTL(cfg.NodeGroupCacheTTL.Duration))
+	}
+
+	var callBudget *sdk.CallBudget
+	if cfg.APIBudgetPerCycle > 0 {
+		callBudget = sdk.NewCallBudget(cfg.APIBudgetPerCycle)
+		options = append(options, sdk.WithCallBudget(callBudget))
+	}
+	if cfg.WorkerPoolSize > 0 {
+		options = append(options, sdk.WithMaxConcurrentCalls(cfg.WorkerPoolSize))
+	}
+
 	// Eventually, create API client given its authentication method
+	var configs []sdk.ClientConfig
 	switch cfg.AuthenticationType {
 	case OpenStackAuthenticationType:
 		openStackProvider, err = sdk.NewOpenStackProvider(cfg.OpenStackAuthUrl, cfg.OpenStackUsername, cfg.OpenStackPassword, cfg.OpenStackDomain, cfg.ProjectID)
@@ -130,23 +219,54 @@ func NewManager(configFile io.Reader) (*OvhCloudManager, error) {
 			return nil, fmt.Errorf("failed to create OpenStack provider: %w", err)
 		}
 
-		client, err = sdk.NewDefaultClientWithToken(openStackProvider.AuthUrl, openStackProvider.Token)
+		primary := sdk.ClientConfig{
+			Endpoint:          sdk.EndpointFromAuthUrl(openStackProvider.AuthUrl),
+			ApplicationKey:    "none",
+			ApplicationSecret: "none",
+			ConsumerKey:       "none",
+			Options:           append(slices.Clip(options), sdk.WithTokenProvider(openStackProvider, sdk.DefaultTokenRenewBefore)),
+		}
+		configs = []sdk.ClientConfig{primary}
+
+		// The recent canadian tenants may not be synchronized yet on the european endpoint, let's fail over to
+		// the canadian one with the same token, renewed by the first client
+		if primary.Endpoint == sdk.OvhEU {
+			fallback := primary
+			fallback.Endpoint = sdk.OvhCA
+			fallback.Options = append(slices.Clip(options), sdk.WithTokenSource(sdk.TokenProviderSource(openStackProvider)))
+			configs = append(configs, fallback)
+		}
 	case ApplicationConsumerAuthenticationType:
-		client, err = sdk.NewClient(cfg.ApplicationEndpoint, cfg.ApplicationKey, cfg.ApplicationSecret, cfg.ApplicationConsumerKey)
+		configs = []sdk.ClientConfig{{
+			Endpoint:          cfg.ApplicationEndpoint,
+			ApplicationKey:    cfg.ApplicationKey,
+			ApplicationSecret: cfg.ApplicationSecret,
+			ConsumerKey:       cfg.ApplicationConsumerKey,
+			Options:           options,
+		}}
 	default:
-		err = errors.New("authentication method unknown")
+		return nil, errors.New("failed to create API client: authentication method unknown")
 	}
 
+	regions, err := sdk.NewMultiRegionClient(configs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
+	manager := newManagerWithClient(regions.Primary(), cfg.ProjectID, cfg.ClusterID)
+	manager.OpenStackProvider = openStackProvider
+	manager.ProviderConfig = cfg
+	manager.CallBudget = callBudget
 
+	return manager, nil
+}
+
+// newManagerWithClient initializes a manager given an already built API client
+func newManagerWithClient(client ClientInterface, projectID string, clusterID string) *OvhCloudManager {
 	return &OvhCloudManager{
-		Client:            client,
-		OpenStackProvider: openStackProvider,
+		Client: client,
 
-		ProjectID: cfg.ProjectID,
-		ClusterID: cfg.ClusterID,
+		ProjectID: projectID,
+		ClusterID: clusterID,
 
 		NodePools:                  make([]sdk.NodePool, 0),
 		NodeGroupPerProviderID:     make(map[string]*NodeGroup),
@@ -154,7 +274,22 @@ func NewManager(configFile io.Reader) (*OvhCloudManager, error) {
 
 		FlavorsCache:               make(map[string]sdk.Flavor),
 		FlavorsCacheExpirationTime: time.Time{},
-	}, nil
+
+		EventBus: NewEventBus(),
+
+		ScaleHistoryPerNodeGroup:     make(map[string]*ScaleOperationRingBuffer),
+		ScaleHistoryPerNodeGroupLock: sync.Mutex{},
+
+		PoolOperations: NewPoolOperationQueue(),
+
+		TargetSizeHistoryPerNodePool:     make(map[string][]TargetSizeRecord),
+		TargetSizeHistoryPerNodePoolLock: sync.Mutex{},
+
+		ProviderConfig: &VKECloudProviderConfig{},
+
+		PendingDeletionsPerNodePool:     make(map[string]map[string]struct{}),
+		PendingDeletionsPerNodePoolLock: sync.Mutex{},
+	}
 }
 
 // getFlavorsByName lists available flavors from cache or from OVHCloud APIs if the cache is outdated
@@ -195,6 +330,79 @@ func (m *OvhCloudManager) getFlavorByName(flavorName string) (sdk.Flavor, error)
 	return sdk.Flavor{}, fmt.Errorf("flavor %s not found in available flavors", flavorName)
 }
 
+// forgetNodePool removes from cache the nodes associated to the node group of a deleted node pool.
+// The node pool itself is dropped by the next refresh, NodePools being only replaced by refresh.
+func (m *OvhCloudManager) forgetNodePool(poolID string) {
+	m.NodeGroupPerProviderIDLock.Lock()
+	defer m.NodeGroupPerProviderIDLock.Unlock()
+
+	for providerID, nodeGroup := range m.NodeGroupPerProviderID {
+		if nodeGroup.ID == poolID {
+			delete(m.NodeGroupPerProviderID, providerID)
+		}
+	}
+}
+
+// pruneNodeGroupPerProviderID removes from cache the nodes associated to a node group whose pool is not in the given ones
+func (m *OvhCloudManager) pruneNodeGroupPerProviderID(pools []sdk.NodePool) {
+	existing := make(map[string]bool, len(pools))
+	for _, pool := range pools {
+		existing[pool.ID] = true
+	}
+
+	m.NodeGroupPerProviderIDLock.Lock()
+	defer m.NodeGroupPerProviderIDLock.Unlock()
+
+	for providerID, nodeGroup := range m.NodeGroupPerProviderID {
+		if !existing[nodeGroup.ID] {
+			delete(m.NodeGroupPerProviderID, providerID)
+		}
+	}
+}
+
+// annotateNodesWithPoolID annotates the Kubernetes nodes whose node group is known with their node pool ID, for it
+// to be found from the annotation afterwards. The nodes added by scale-ups are annotated once their node group is
+// found by listing the node pools nodes. Nothing is annotated without a Kubernetes client.
+func (m *OvhCloudManager) annotateNodesWithPoolID(ctx context.Context) {
+	if m.KubeClient == nil {
+		return
+	}
+
+	nodes, err := m.KubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Warningf("failed to list nodes to annotate them with their node pool: %v", err)
+		return
+	}
+
+	for _, node := range nodes.Items {
+		nodeGroup := m.getNodeGroupPerProviderID(node.Spec.ProviderID)
+		if nodeGroup == nil {
+			continue
+		}
+
+		if node.Annotations[sdk.NodeGroupIDAnnotation] == nodeGroup.ID && node.Annotations[sdk.ClusterIDAnnotation] == m.ClusterID {
+			continue
+		}
+
+		if err := sdk.AnnotateNodeWithPoolID(ctx, m.KubeClient, node.Name, m.ClusterID, nodeGroup.ID); err != nil {
+			klog.Warningf("failed to annotate node %s with its node pool: %v", node.Name, err)
+		}
+	}
+}
+
+// setFlavorResources sets the resources of the nodes of every pool from their flavor, leaving them unset when unknown
+func (m *OvhCloudManager) setFlavorResources(pools []sdk.NodePool) {
+	for i := range pools {
+		flavor, err := m.getFlavorByName(pools[i].Flavor)
+		if err != nil {
+			klog.Warningf("failed to get resources of node pool %s: %v", pools[i].Name, err)
+			continue
+		}
+
+		pools[i].SetFlavorResources(flavor)
+	}
+}
+
 // setNodeGroupPerProviderID stores the association provider ID => node group in cache for future reference
 func (m *OvhCloudManager) setNodeGroupPerProviderID(providerID string, nodeGroup *NodeGroup) {
 	m.NodeGroupPerProviderIDLock.Lock()
@@ -211,45 +419,49 @@ func (m *OvhCloudManager) getNodeGroupPerProviderID(providerID string) *NodeGrou
 	return m.NodeGroupPerProviderID[providerID]
 }
 
+// getScaleHistory returns the scale operations history of a node group, creating it if needed
+func (m *OvhCloudManager) getScaleHistory(nodeGroupID string) *ScaleOperationRingBuffer {
+	m.ScaleHistoryPerNodeGroupLock.Lock()
+	defer m.ScaleHistoryPerNodeGroupLock.Unlock()
+
+	history, ok := m.ScaleHistoryPerNodeGroup[nodeGroupID]
+	if !ok {
+		history = NewScaleOperationRingBuffer(scaleHistorySize)
+		m.ScaleHistoryPerNodeGroup[nodeGroupID] = history
+	}
+
+	return history
+}
+
+// lastScaleOperations returns the n last scale operations of a node group, without creating its history
+func (m *OvhCloudManager) lastScaleOperations(nodeGroupID string, n int) []ScaleOperation {
+	m.ScaleHistoryPerNodeGroupLock.Lock()
+	history, ok := m.ScaleHistoryPerNodeGroup[nodeGroupID]
+	m.ScaleHistoryPerNodeGroupLock.Unlock()
+
+	if !ok {
+		return make([]ScaleOperation, 0)
+	}
+
+	return history.Last(n)
+}
+
 // ReAuthenticate allows OpenStack keystone token to be revoked and re-created to call API
 func (m *OvhCloudManager) ReAuthenticate() error {
 	if m.OpenStackProvider != nil {
+		// The token is renewed in the background, this only catches up after failed renewals.
+		// The client reads the token from the provider, so it does not need to be re-created.
 		if m.OpenStackProvider.IsTokenExpired() {
 			err := m.OpenStackProvider.ReauthenticateToken()
 			if err != nil {
 				return fmt.Errorf("failed to re-authenticate OpenStack token: %w", err)
 			}
-
-			client, err := sdk.NewDefaultClientWithToken(m.OpenStackProvider.AuthUrl, m.OpenStackProvider.Token)
-			if err != nil {
-				return fmt.Errorf("failed to re-create client: %w", err)
-			}
-
-			m.Client = client
 		}
 	}
 
 	return nil
 }
 
-// readConfig read cloud provider configuration file into a struct
-func readConfig(configFile io.Reader) (*Config, error) {
-	cfg := &Config{}
-	if configFile != nil {
-		body, err := ioutil.ReadAll(configFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read content: %w", err)
-		}
-
-		err = json.Unmarshal(body, cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal body: %w", err)
-		}
-	}
-
-	return cfg, nil
-}
-
 // validatePayload check that cloud provider configuration file is correctly formatted
 func validatePayload(cfg *Config) error {
 	if cfg.ClusterID == "" {
@@ -23,6 +23,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
 )
 
@@ -290,3 +294,45 @@ func TestOvhCloudManager_cacheConcurrency(t *testing.T) {
 		manager.getNodeGroupPerProviderID("")
 	})
 }
+
+func TestOvhCloudManager_annotateNodesWithPoolID(t *testing.T) {
+	manager := newTestManager(t)
+	ctx := context.Background()
+
+	newNode := func(name string, providerID string, annotations map[string]string) *apiv1.Node {
+		return &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+			Spec:       apiv1.NodeSpec{ProviderID: providerID},
+		}
+	}
+
+	k8sClient := fake.NewSimpleClientset(
+		newNode("new", providerIDPrefix+"0", nil),
+		newNode("annotated", providerIDPrefix+"1", map[string]string{sdk.NodeGroupIDAnnotation: "1", sdk.ClusterIDAnnotation: "clusterID"}),
+		newNode("unknown", providerIDPrefix+"2", nil),
+	)
+	manager.KubeClient = k8sClient
+	manager.NodeGroupPerProviderID = map[string]*NodeGroup{
+		providerIDPrefix + "0": {NodePool: sdk.NodePool{ID: "1"}},
+		providerIDPrefix + "1": {NodePool: sdk.NodePool{ID: "1"}},
+	}
+
+	manager.annotateNodesWithPoolID(ctx)
+
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, "new", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", node.Annotations[sdk.NodeGroupIDAnnotation])
+	assert.Equal(t, "clusterID", node.Annotations[sdk.ClusterIDAnnotation])
+
+	node, err = k8sClient.CoreV1().Nodes().Get(ctx, "unknown", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, node.Annotations)
+
+	patches := 0
+	for _, action := range k8sClient.Actions() {
+		if action.GetVerb() == "patch" {
+			patches++
+		}
+	}
+	assert.Equal(t, 1, patches)
+}
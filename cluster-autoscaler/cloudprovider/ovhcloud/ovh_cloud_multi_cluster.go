@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// ClusterClient holds the manager used to interact with one of the clusters handled by a MultiClusterManager.
+type ClusterClient struct {
+	Manager *OvhCloudManager
+
+	mutex sync.RWMutex
+}
+
+// Refresh fetches the node pools of the cluster.
+func (cc *ClusterClient) Refresh(ctx context.Context) error {
+	pools, err := cc.Manager.Client.ListNodePools(ctx, cc.Manager.ProjectID, cc.Manager.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh node pool list of cluster %s: %w", cc.Manager.ClusterID, err)
+	}
+
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	cc.Manager.NodePools = pools
+
+	return nil
+}
+
+// findNodeGroup returns the node group with the given ID if it belongs to the cluster, nil otherwise.
+func (cc *ClusterClient) findNodeGroup(nodeGroupID string) *NodeGroup {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+
+	for _, pool := range cc.Manager.NodePools {
+		if pool.Name == nodeGroupID {
+			return &NodeGroup{
+				NodePool:    pool,
+				Manager:     cc.Manager,
+				CurrentSize: -1,
+			}
+		}
+	}
+
+	return nil
+}
+
+// MultiClusterManager shards node groups across several clusters of a same project,
+// allowing a single autoscaler to manage the node pools of all of them.
+type MultiClusterManager struct {
+	ProjectID string
+
+	clusters map[string]*ClusterClient
+	mutex    sync.RWMutex
+}
+
+// NewMultiClusterManager creates a manager without any registered cluster.
+func NewMultiClusterManager(projectID string) *MultiClusterManager {
+	return &MultiClusterManager{
+		ProjectID: projectID,
+		clusters:  make(map[string]*ClusterClient),
+	}
+}
+
+// RegisterCluster adds a cluster to the manager, calling the API endpoint with the given OpenStack token.
+func (m *MultiClusterManager) RegisterCluster(clusterID, endpoint, token string) error {
+	if clusterID == "" {
+		return fmt.Errorf("cluster ID should not be empty")
+	}
+
+	client, err := sdk.NewEndpointClientWithToken(endpoint, token)
+	if err != nil {
+		return fmt.Errorf("failed to create API client for cluster %s: %w", clusterID, err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.clusters[clusterID] = &ClusterClient{
+		Manager: newManagerWithClient(client, m.ProjectID, clusterID),
+	}
+
+	return nil
+}
+
+// UnregisterCluster removes a cluster from the manager.
+func (m *MultiClusterManager) UnregisterCluster(clusterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.clusters, clusterID)
+}
+
+// GetNodeGroup searches all registered clusters for the node group with the given ID.
+func (m *MultiClusterManager) GetNodeGroup(nodeGroupID string) (cloudprovider.NodeGroup, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, cc := range m.clusters {
+		if ng := cc.findNodeGroup(nodeGroupID); ng != nil {
+			return ng, nil
+		}
+	}
+
+	return nil, fmt.Errorf("node group %s not found in any registered cluster", nodeGroupID)
+}
+
+// RefreshAll concurrently refreshes the node pools of all registered clusters.
+func (m *MultiClusterManager) RefreshAll(ctx context.Context) error {
+	m.mutex.RLock()
+	clusters := make([]*ClusterClient, 0, len(m.clusters))
+	for _, cc := range m.clusters {
+		clusters = append(clusters, cc)
+	}
+	m.mutex.RUnlock()
+
+	errs := make([]error, len(clusters))
+	wg := sync.WaitGroup{}
+	for i, cc := range clusters {
+		wg.Add(1)
+		go func(i int, cc *ClusterClient) {
+			defer wg.Done()
+
+			errs[i] = cc.Refresh(ctx)
+			if errs[i] != nil {
+				klog.Warningf("failed to refresh cluster %s: %v", cc.Manager.ClusterID, errs[i])
+			}
+		}(i, cc)
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func newTestMultiClusterManager(t *testing.T) *MultiClusterManager {
+	manager := NewMultiClusterManager("projectID")
+
+	for _, clusterID := range []string{"clusterA", "clusterB"} {
+		err := manager.RegisterCluster(clusterID, "ovh-eu", "token")
+		assert.NoError(t, err)
+
+		client := &sdk.ClientMock{}
+		client.On("ListNodePools", context.Background(), "projectID", clusterID).Return(
+			[]sdk.NodePool{
+				{
+					ID:           fmt.Sprintf("%s-1", clusterID),
+					Name:         fmt.Sprintf("%s-pool", clusterID),
+					Flavor:       "b2-7",
+					DesiredNodes: 2,
+					MinNodes:     1,
+					MaxNodes:     5,
+					Autoscale:    true,
+				},
+			}, nil,
+		)
+		manager.clusters[clusterID].Manager.Client = client
+	}
+
+	return manager
+}
+
+func TestMultiClusterManager_GetNodeGroup(t *testing.T) {
+	manager := newTestMultiClusterManager(t)
+	assert.NoError(t, manager.RefreshAll(context.Background()))
+
+	t.Run("check node group of cluster B is found", func(t *testing.T) {
+		ng, err := manager.GetNodeGroup("clusterB-pool")
+		assert.NoError(t, err)
+
+		assert.Equal(t, "clusterB-pool", ng.Id())
+		assert.Equal(t, "clusterB", ng.(*NodeGroup).Manager.ClusterID)
+	})
+
+	t.Run("check unknown node group is not found", func(t *testing.T) {
+		_, err := manager.GetNodeGroup("unknown-pool")
+		assert.Error(t, err)
+	})
+
+	t.Run("check unregistered cluster node groups are not found", func(t *testing.T) {
+		manager.UnregisterCluster("clusterA")
+
+		_, err := manager.GetNodeGroup("clusterA-pool")
+		assert.Error(t, err)
+	})
+}
+
+func TestMultiClusterManager_RefreshAll(t *testing.T) {
+	manager := newTestMultiClusterManager(t)
+
+	failing := &sdk.ClientMock{}
+	failing.On("ListNodePools", context.Background(), "projectID", "clusterA").Return([]sdk.NodePool{}, fmt.Errorf("API is down"))
+	manager.clusters["clusterA"].Manager.Client = failing
+
+	err := manager.RefreshAll(context.Background())
+	assert.Error(t, err)
+
+	// The other cluster has been refreshed anyway
+	_, err = manager.GetNodeGroup("clusterB-pool")
+	assert.NoError(t, err)
+}
+
+func TestMultiClusterManager_RegisterCluster(t *testing.T) {
+	manager := NewMultiClusterManager("projectID")
+
+	assert.Error(t, manager.RegisterCluster("", "ovh-eu", "token"))
+	assert.Error(t, manager.RegisterCluster("clusterA", "unknown", "token"))
+}
@@ -39,6 +39,19 @@ import (
 
 const providerIDPrefix = "openstack:///"
 
+// DefaultMaxNodeProvisionTime is the time given to new nodes to be provisioned when not configured
+const DefaultMaxNodeProvisionTime = 15 * time.Minute
+
+// ParseProviderID extracts the OpenStack instance ID from a node provider ID.
+// An empty instance ID is returned for nodes whose instance is not created yet.
+func ParseProviderID(providerID string) (string, error) {
+	if !strings.HasPrefix(providerID, providerIDPrefix) {
+		return "", fmt.Errorf("provider ID %q does not start with %s", providerID, providerIDPrefix)
+	}
+
+	return strings.TrimPrefix(providerID, providerIDPrefix), nil
+}
+
 // NodeGroup implements cloudprovider.NodeGroup interface.
 type NodeGroup struct {
 	sdk.NodePool
@@ -61,14 +74,25 @@ func (ng *NodeGroup) MinSize() int {
 // TargetSize returns the current TARGET size of the node pool. It is possible that the
 // number is different from the number of nodes registered in Kubernetes.
 func (ng *NodeGroup) TargetSize() (int, error) {
-	// By default, fetch the API desired nodes before using target size from autoscaler
+	// By default, fetch the API desired nodes before using target size from autoscaler.
 	if ng.CurrentSize == -1 {
-		return int(ng.DesiredNodes), nil
+		return ng.Manager.nodePoolTargetSize(&ng.NodePool), nil
 	}
 
 	return ng.CurrentSize, nil
 }
 
+// nodePoolTargetSize returns the desired nodes of a node pool, less its nodes pending deletion. The API leaves
+// the desired nodes unset (zero) on some pools, the running nodes are used then.
+func (m *OvhCloudManager) nodePoolTargetSize(pool *sdk.NodePool) int {
+	desired := int(pool.DesiredNodes)
+	if desired == 0 {
+		desired = int(pool.CurrentNodes)
+	}
+
+	return desired - m.pendingDeletions(pool.ID)
+}
+
 // IncreaseSize increases node pool size.
 func (ng *NodeGroup) IncreaseSize(delta int) error {
 	// Do not use node group which does not support autoscaling
@@ -76,6 +100,14 @@ func (ng *NodeGroup) IncreaseSize(delta int) error {
 		return nil
 	}
 
+	if err := ng.checkNotLocked(); err != nil {
+		return err
+	}
+
+	if err := ng.checkNotInMaintenance(); err != nil {
+		return err
+	}
+
 	klog.V(4).Infof("Increasing NodeGroup size by %d node(s)", delta)
 
 	// First, verify the NodeGroup can be increased
@@ -92,21 +124,81 @@ func (ng *NodeGroup) IncreaseSize(delta int) error {
 		return fmt.Errorf("node group size would be above minimum size - desired: %d, max: %d", size+delta, ng.MaxSize())
 	}
 
-	// Then, forge current size and parameters
-	ng.CurrentSize = size + delta
+	// Some node pools are limited by their own quota
+	if err := ng.Manager.Client.CheckNodePoolQuota(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, delta); err != nil {
+		return fmt.Errorf("failed to increase node pool desired size: %w", err)
+	}
 
-	desired := uint32(ng.CurrentSize)
-	opts := sdk.UpdateNodePoolOpts{
-		DesiredNodes: &desired,
+	// Keep the reserved part of the project quota available for other workloads
+	if ng.Manager.QuotaReservation != nil {
+		feasibility, err := ng.Manager.CheckScaleUpFeasibility(context.Background(), ng.Flavor, delta)
+		if err != nil {
+			return fmt.Errorf("failed to check scale up feasibility: %w", err)
+		}
+
+		if !feasibility.Feasible {
+			return fmt.Errorf("scale up would exceed reserved quota: %s", feasibility.Reason)
+		}
+	}
+
+	// Scale-ups are not always driven by pending pods (minimum size, balancing), so this is only a hint
+	if ng.Manager.PendingPodsLister != nil {
+		if err := ng.checkPendingPodsFit(); err != nil {
+			klog.Warningf("Scaling up node group %s anyway: %v", ng.Id(), err)
+		}
 	}
-	klog.V(4).Infof("Upscaling node pool %s to %d desired nodes", ng.ID, desired)
 
-	// Call API to increase desired nodes number, automatically creating new nodes
-	resp, err := ng.Manager.Client.UpdateNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &opts)
+	ctx, cancel := context.WithTimeout(context.Background(), ng.provisionTimeout())
+	defer cancel()
+
+	// The size is read again once the pending resizes of the node pool are done, for them not to be overwritten
+	var resp *sdk.NodePool
+	err = ng.Manager.PoolOperations.SubmitResize(ctx, ng.ID, size, func(ctx context.Context, current int) (int, error) {
+		size = current
+
+		// Then, forge parameters and current size, the nodes pending deletion being still in the node pool
+		desired := ng.Manager.nodePoolDesiredNodes(ng.ID, size+delta)
+		if int(desired) > ng.MaxSize() {
+			return size, fmt.Errorf("node group size would be above minimum size - desired: %d, max: %d", desired, ng.MaxSize())
+		}
+
+		opts := sdk.UpdateNodePoolOpts{
+			DesiredNodes: &desired,
+		}
+
+		if ng.Manager.ProviderConfig.DryRunBeforeScale {
+			if err := ng.dryRunUpdate(&opts); err != nil {
+				return size, err
+			}
+		}
+
+		klog.V(4).Infof("Upscaling node pool %s to %d desired nodes", ng.ID, desired)
+
+		// Call API to increase desired nodes number, automatically creating new nodes
+		start := time.Now()
+		var err error
+		resp, err = ng.Manager.Client.UpdateNodePool(ctx, ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &opts)
+		ng.Manager.getScaleHistory(ng.Id()).Add(newScaleOperation(ScaleUpDirection, size, size+delta, start, err))
+		if err != nil {
+			return size, fmt.Errorf("failed to increase node pool desired size: %w", err)
+		}
+
+		return size + delta, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to increase node pool desired size: %w", err)
+		return err
 	}
+
 	ng.Status = resp.Status
+	ng.CurrentSize = size + delta
+
+	ng.Manager.EventBus.PublishAsync(ScaleEvent{
+		NodeGroupID: ng.Id(),
+		Direction:   ScaleUpDirection,
+		OldSize:     size,
+		NewSize:     ng.CurrentSize,
+		Timestamp:   time.Now(),
+	})
 
 	return nil
 }
@@ -124,6 +216,14 @@ func (ng *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
 		return nil
 	}
 
+	if err := ng.checkNotLocked(); err != nil {
+		return err
+	}
+
+	if err := ng.checkNotInMaintenance(); err != nil {
+		return err
+	}
+
 	klog.V(4).Infof("Deleting %d node(s)", len(nodes))
 
 	// First, verify the NodeGroup can be decreased
@@ -136,27 +236,128 @@ func (ng *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
 		return fmt.Errorf("node group size would be below minimum size - desired: %d, max: %d", size-len(nodes), ng.MinSize())
 	}
 
+	// Nodes are only marked, they are removed from the pool once their deletion is confirmed
+	if ng.Manager.SoftDeleteMode {
+		return ng.softDeleteNodes(nodes, size)
+	}
+
 	nodeProviderIds := make([]string, 0)
 	for _, node := range nodes {
 		nodeProviderIds = append(nodeProviderIds, node.Spec.ProviderID)
 	}
 
-	desired := uint32(size - len(nodes))
-	opts := sdk.UpdateNodePoolOpts{
-		DesiredNodes:  &desired,
-		NodesToRemove: nodeProviderIds,
-	}
-	klog.V(4).Infof("Downscaling node pool %s to %d desired nodes by deleting the following nodes: %s", ng.ID, desired, nodeProviderIds)
+	// The size is read again once the pending resizes of the node pool are done, for them not to be overwritten
+	var resp *sdk.NodePool
+	reached := size
+	err = ng.Manager.PoolOperations.SubmitResize(context.Background(), ng.ID, size, func(ctx context.Context, current int) (int, error) {
+		size = current
+		reached = size
+		if size-len(nodes) < ng.MinSize() {
+			return size, fmt.Errorf("node group size would be below minimum size - desired: %d, max: %d", size-len(nodes), ng.MinSize())
+		}
+
+		// Nodes are removed by batches of at most `max_batch_delete_nodes` nodes per API call
+		batchSize := len(nodeProviderIds)
+		if maxBatch := ng.Manager.ProviderConfig.MaxBatchDeleteNodes; maxBatch > 0 && maxBatch < batchSize {
+			batchSize = maxBatch
+		}
+
+		for i := 0; ; i += batchSize {
+			batch := nodeProviderIds[i:min(i+batchSize, len(nodeProviderIds))]
+
+			desired := ng.Manager.nodePoolDesiredNodes(ng.ID, reached-len(batch))
+			opts := sdk.UpdateNodePoolOpts{
+				DesiredNodes:  &desired,
+				NodesToRemove: batch,
+			}
+			klog.V(4).Infof("Downscaling node pool %s to %d desired nodes by deleting the following nodes: %s", ng.ID, desired, batch)
+
+			// Call API to remove nodes from a NodeGroup
+			start := time.Now()
+			var err error
+			resp, err = ng.Manager.Client.UpdateNodePool(ctx, ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &opts)
+			ng.Manager.getScaleHistory(ng.Id()).Add(newScaleOperation(ScaleDownDirection, reached, reached-len(batch), start, err))
+			if err != nil {
+				// The nodes of the previous batches are removed, the node pool keeps their removal
+				return reached, fmt.Errorf("failed to delete node pool nodes: %w", err)
+			}
+
+			reached -= len(batch)
+			if i+batchSize >= len(nodeProviderIds) {
+				break
+			}
+		}
 
-	// Call API to remove nodes from a NodeGroup
-	resp, err := ng.Manager.Client.UpdateNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &opts)
+		return reached, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete node pool nodes: %w", err)
+		if reached != size {
+			ng.CurrentSize = reached
+		}
+		return err
 	}
 
 	// Update the node group
 	ng.Status = resp.Status
-	ng.CurrentSize = size - len(nodes)
+	ng.CurrentSize = reached
+
+	ng.Manager.EventBus.PublishAsync(ScaleEvent{
+		NodeGroupID: ng.Id(),
+		Direction:   ScaleDownDirection,
+		OldSize:     size,
+		NewSize:     ng.CurrentSize,
+		Timestamp:   time.Now(),
+	})
+
+	return nil
+}
+
+// softDeleteNodes marks the nodes pending deletion, leaving them out of the node group target size while
+// the node pool desired nodes are left untouched until their deletion is confirmed.
+func (ng *NodeGroup) softDeleteNodes(nodes []*apiv1.Node, size int) error {
+	// The size is read again once the pending resizes of the node pool are done, for them not to be overwritten
+	reached := size
+	err := ng.Manager.PoolOperations.SubmitResize(context.Background(), ng.ID, size, func(ctx context.Context, current int) (int, error) {
+		size = current
+		reached = size
+		if size-len(nodes) < ng.MinSize() {
+			return size, fmt.Errorf("node group size would be below minimum size - desired: %d, max: %d", size-len(nodes), ng.MinSize())
+		}
+
+		start := time.Now()
+		var err error
+		for _, node := range nodes {
+			var marked bool
+			marked, err = ng.Manager.SoftDeleteNode(ctx, ng.Manager.ClusterID, ng.ID, node.Name, ng.Manager.KubeClient)
+			if err != nil {
+				break
+			}
+
+			if marked {
+				reached--
+			}
+		}
+		ng.Manager.getScaleHistory(ng.Id()).Add(newScaleOperation(ScaleDownDirection, size, reached, start, err))
+
+		// The nodes marked before a failure stay pending deletion
+		return reached, err
+	})
+	if err != nil {
+		if reached != size {
+			ng.CurrentSize = reached
+		}
+		return err
+	}
+
+	ng.CurrentSize = reached
+
+	ng.Manager.EventBus.PublishAsync(ScaleEvent{
+		NodeGroupID: ng.Id(),
+		Direction:   ScaleDownDirection,
+		OldSize:     size,
+		NewSize:     ng.CurrentSize,
+		Timestamp:   time.Now(),
+	})
 
 	return nil
 }
@@ -186,6 +387,11 @@ func (ng *NodeGroup) Debug() string {
 func (ng *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 	// Fetch all nodes contained in the node group
 	nodes, err := ng.Manager.Client.ListNodePoolNodes(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID)
+	if sdk.IsNodeGroupNotFound(err) {
+		// Forget the node pool deleted since the last refresh
+		klog.Warningf("node pool %s does not exist anymore, forgetting its nodes: %v", ng.ID, err)
+		ng.Manager.forgetNodePool(ng.ID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list node pool nodes: %w", err)
 	}
@@ -243,6 +449,9 @@ func (ng *NodeGroup) TemplateNodeInfo() (*schedulerframework.NodeInfo, error) {
 	node.Status.Capacity[apiv1.ResourceCPU] = *resource.NewQuantity(int64(flavor.VCPUs), resource.DecimalSI)
 	node.Status.Capacity[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(int64(flavor.GPUs), resource.DecimalSI)
 	node.Status.Capacity[apiv1.ResourceMemory] = *resource.NewQuantity(int64(flavor.RAM)*int64(math.Pow(1024, 3)), resource.DecimalSI)
+	if flavor.Disk > 0 {
+		node.Status.Capacity[apiv1.ResourceEphemeralStorage] = *resource.NewQuantity(int64(flavor.Disk)*int64(math.Pow(1024, 3)), resource.DecimalSI)
+	}
 
 	node.Status.Allocatable = node.Status.Capacity
 
@@ -284,6 +493,15 @@ func (ng *NodeGroup) Create() (cloudprovider.NodeGroup, error) {
 		return nil, fmt.Errorf("failed to create node pool: %w", err)
 	}
 
+	// Apply the standard metadata, the node pool is usable anyway if it fails
+	err = ng.Manager.Client.InitialiseNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, np.ID, &sdk.InitialiseOpts{
+		AutoscalerEnabled: true,
+		ScaleDownEnabled:  true,
+	})
+	if err != nil {
+		klog.Warningf("failed to initialise node pool %s: %v", np.Name, err)
+	}
+
 	// Forge a node group interface given the API response
 	return &NodeGroup{
 		NodePool:    *np,
@@ -299,6 +517,14 @@ func (ng *NodeGroup) Delete() error {
 
 	// Call API to delete the node pool given its project and cluster
 	_, err := ng.Manager.Client.DeleteNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID)
+
+	// The node pool is already gone, it only has to be forgotten
+	if sdk.IsNotFound(err) {
+		klog.V(2).Infof("Node pool %s does not exist anymore, removing its nodes from cache", ng.ID)
+		ng.Manager.forgetNodePool(ng.ID)
+		return nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to delete node pool: %w", err)
 	}
@@ -338,6 +564,94 @@ func (ng *NodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*c
 	return cfg, nil
 }
 
+// checkPendingPodsFit returns an error if none of the pending pods would fit on a new node of the node group
+func (ng *NodeGroup) checkPendingPodsFit() error {
+	pods, err := ng.Manager.PendingPodsLister()
+	if err != nil {
+		return fmt.Errorf("failed to list pending pods: %w", err)
+	}
+
+	if len(pods) == 0 {
+		return nil
+	}
+
+	template, err := ng.TemplateNodeInfo()
+	if err != nil {
+		return fmt.Errorf("failed to build template node: %w", err)
+	}
+
+	schedulable, _, err := SimulatePodFit(template, pods)
+	if err != nil {
+		return fmt.Errorf("failed to simulate pending pods scheduling: %w", err)
+	}
+
+	if len(schedulable) == 0 {
+		return fmt.Errorf("none of the %d pending pod(s) would fit on a new node of node group %s", len(pods), ng.Id())
+	}
+
+	return nil
+}
+
+// provisionTimeout returns the time given to the nodes of the node group to be provisioned,
+// read from the node pool annotation or the cloud provider configuration
+func (ng *NodeGroup) provisionTimeout() time.Duration {
+	fallback := ng.Manager.ProviderConfig.MaxNodeProvisionTime.Duration
+	if fallback == 0 {
+		fallback = DefaultMaxNodeProvisionTime
+	}
+
+	return sdk.ParseProvisionTimeAnnotation(&ng.NodePool, ng.Manager.ProviderConfig.LabelPrefix, fallback)
+}
+
+// checkNotInMaintenance returns sdk.ErrPoolInMaintenanceMode if the node group is in maintenance
+func (ng *NodeGroup) checkNotInMaintenance() error {
+	if sdk.GetMaintenanceModeStatus(&ng.NodePool, ng.Manager.ProviderConfig.LabelPrefix).Active {
+		return sdk.ErrPoolInMaintenanceMode
+	}
+
+	return nil
+}
+
+// dryRunUpdate validates a node pool update with a dry-run call, within the current node pool bounds
+func (ng *NodeGroup) dryRunUpdate(opts *sdk.UpdateNodePoolOpts) error {
+	dryRunOpts := *opts
+	if dryRunOpts.MinNodes == nil {
+		dryRunOpts.MinNodes = &ng.MinNodes
+	}
+	if dryRunOpts.MaxNodes == nil {
+		dryRunOpts.MaxNodes = &ng.MaxNodes
+	}
+
+	result, err := ng.Manager.Client.DryRunUpdateNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &dryRunOpts)
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("node pool %s update rejected by dry-run: %s", ng.ID, strings.Join(result.Warnings, "; "))
+	}
+
+	for _, warning := range result.Warnings {
+		klog.Warningf("Dry-run of node pool %s update: %s", ng.ID, warning)
+	}
+
+	return nil
+}
+
+// checkNotLocked returns sdk.ErrNodePoolLocked if an operator locked the node group
+func (ng *NodeGroup) checkNotLocked() error {
+	locked, reason, err := sdk.IsNodePoolLocked(&ng.NodePool, ng.Manager.ProviderConfig.LabelPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to check node pool lock: %w", err)
+	}
+
+	if locked {
+		return fmt.Errorf("%w: %s", sdk.ErrNodePoolLocked, reason)
+	}
+
+	return nil
+}
+
 // isGpu checks if a node group is using GPU machines
 func (ng *NodeGroup) isGpu() bool {
 	flavor, err := ng.Manager.getFlavorByName(ng.Flavor)
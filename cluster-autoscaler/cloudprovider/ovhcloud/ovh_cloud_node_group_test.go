@@ -19,11 +19,15 @@ package ovhcloud
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -70,6 +74,7 @@ func newTestNodeGroup(t *testing.T, flavor string) *NodeGroup {
 				VCPUs:    8,
 				GPUs:     1,
 				RAM:      45,
+				Disk:     400,
 			},
 			{
 				Name:     "unknown",
@@ -81,6 +86,7 @@ func newTestNodeGroup(t *testing.T, flavor string) *NodeGroup {
 			},
 		}, nil,
 	)
+	client.On("CheckNodePoolQuota", ctx, "projectID", "clusterID", "id", mock.Anything).Return(nil)
 	manager.Client = client
 
 	ng := &NodeGroup{
@@ -109,7 +115,7 @@ func newTestNodeGroup(t *testing.T, flavor string) *NodeGroup {
 func (ng *NodeGroup) mockCallUpdateNodePool(newDesiredNodes uint32, nodesToRemove []string) {
 	ng.Manager.Client.(*sdk.ClientMock).On(
 		"UpdateNodePool",
-		context.Background(),
+		mock.Anything,
 		ng.Manager.ProjectID,
 		ng.Manager.ClusterID,
 		ng.ID,
@@ -187,6 +193,17 @@ func (ng *NodeGroup) mockCallCreateNodePool() {
 			MaxNodes:     ng.MaxNodes,
 		}, nil,
 	)
+	ng.Manager.Client.(*sdk.ClientMock).On(
+		"InitialiseNodePool",
+		context.Background(),
+		ng.Manager.ProjectID,
+		ng.Manager.ClusterID,
+		ng.ID,
+		&sdk.InitialiseOpts{
+			AutoscalerEnabled: true,
+			ScaleDownEnabled:  true,
+		},
+	).Return(nil)
 }
 
 func (ng *NodeGroup) mockCallDeleteNodePool() {
@@ -222,6 +239,18 @@ func TestOVHCloudNodeGroup_TargetSize(t *testing.T) {
 
 		assert.Equal(t, 3, targetSize)
 	})
+
+	t.Run("check target size falls back to current nodes when desired nodes are not set", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.CurrentSize = -1
+		ng.DesiredNodes = 0
+		ng.CurrentNodes = 2
+
+		targetSize, err := ng.TargetSize()
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, targetSize)
+	})
 }
 
 func TestOVHCloudNodeGroup_IncreaseSize(t *testing.T) {
@@ -248,6 +277,132 @@ func TestOVHCloudNodeGroup_IncreaseSize(t *testing.T) {
 		err := ng.IncreaseSize(-1)
 		assert.Error(t, err)
 	})
+
+	t.Run("check increase size above node pool quota", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		client := &sdk.ClientMock{}
+		client.On("CheckNodePoolQuota", context.Background(), "projectID", "clusterID", "id", 1).Return(sdk.ErrQuotaExceeded)
+		ng.Manager.Client = client
+
+		err := ng.IncreaseSize(1)
+		assert.ErrorIs(t, err, sdk.ErrQuotaExceeded)
+	})
+
+	t.Run("check increase size of locked node group", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.LockedAnnotation): "true"}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		err := ng.IncreaseSize(1)
+		assert.ErrorIs(t, err, sdk.ErrNodePoolLocked)
+	})
+
+	t.Run("check increase size of node group in maintenance", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.MaintenanceModeAnnotation): sdk.MaintenanceModeActive}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		err := ng.IncreaseSize(1)
+		assert.ErrorIs(t, err, sdk.ErrPoolInMaintenanceMode)
+	})
+
+	t.Run("check increase size validated by dry-run", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.Manager.ProviderConfig.DryRunBeforeScale = true
+		ng.mockCallUpdateNodePool(4, nil)
+
+		client := ng.Manager.Client.(*sdk.ClientMock)
+		client.On("DryRunUpdateNodePool", context.Background(), "projectID", "clusterID", "id", mock.Anything).Return(&sdk.DryRunResult{Valid: true}, nil)
+
+		err := ng.IncreaseSize(1)
+		assert.NoError(t, err)
+
+		desired, min, max := uint32(4), uint32(1), uint32(5)
+		client.AssertCalled(t, "DryRunUpdateNodePool", context.Background(), "projectID", "clusterID", "id", &sdk.UpdateNodePoolOpts{DesiredNodes: &desired, MinNodes: &min, MaxNodes: &max})
+		client.AssertNumberOfCalls(t, "UpdateNodePool", 1)
+	})
+
+	t.Run("check increase size rejected by dry-run", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.Manager.ProviderConfig.DryRunBeforeScale = true
+
+		client := ng.Manager.Client.(*sdk.ClientMock)
+		client.On("DryRunUpdateNodePool", context.Background(), "projectID", "clusterID", "id", mock.Anything).Return(&sdk.DryRunResult{Valid: false, Warnings: []string{"not enough capacity"}}, nil)
+
+		err := ng.IncreaseSize(1)
+		assert.ErrorContains(t, err, "not enough capacity")
+		client.AssertNotCalled(t, "UpdateNodePool", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		targetSize, err := ng.TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, targetSize)
+	})
+}
+
+func TestOVHCloudNodeGroup_provisionTimeout(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+
+	t.Run("check default provision timeout", func(t *testing.T) {
+		assert.Equal(t, DefaultMaxNodeProvisionTime, ng.provisionTimeout())
+	})
+
+	t.Run("check configured provision timeout", func(t *testing.T) {
+		ng.Manager.ProviderConfig.MaxNodeProvisionTime.Duration = 20 * time.Minute
+		defer func() { ng.Manager.ProviderConfig.MaxNodeProvisionTime.Duration = 0 }()
+
+		assert.Equal(t, 20*time.Minute, ng.provisionTimeout())
+	})
+
+	t.Run("check node pool provision timeout", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.ProvisionTimeAnnotation): "2700"}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		assert.Equal(t, 45*time.Minute, ng.provisionTimeout())
+	})
+
+	t.Run("check malformed node pool provision timeout", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.ProvisionTimeAnnotation): "forever"}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		assert.Equal(t, DefaultMaxNodeProvisionTime, ng.provisionTimeout())
+	})
+}
+
+func TestOVHCloudNodeGroup_ConcurrentResizes(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+
+	// Another node group of the same node pool, built from the same listing
+	other := &NodeGroup{NodePool: ng.NodePool, Manager: ng.Manager, CurrentSize: ng.CurrentSize}
+
+	var sizes []uint32
+	var mutex sync.Mutex
+	ng.Manager.Client.(*sdk.ClientMock).On(
+		"UpdateNodePool",
+		mock.Anything,
+		ng.Manager.ProjectID,
+		ng.Manager.ClusterID,
+		ng.ID,
+		mock.Anything,
+	).Run(func(args mock.Arguments) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		sizes = append(sizes, *args.Get(4).(*sdk.UpdateNodePoolOpts).DesiredNodes)
+	}).Return(&sdk.NodePool{ID: ng.ID}, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, ng.IncreaseSize(1))
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, other.DeleteNodes([]*v1.Node{{Spec: v1.NodeSpec{ProviderID: "openstack:///instance-1"}}}))
+	}()
+	wg.Wait()
+
+	// Whatever the order, the second resize starts from the size set by the first one
+	assert.Len(t, sizes, 2)
+	assert.Contains(t, [][]uint32{{4, 3}, {2, 3}}, sizes)
 }
 
 func TestOVHCloudNodeGroup_DeleteNodes(t *testing.T) {
@@ -289,6 +444,85 @@ func TestOVHCloudNodeGroup_DeleteNodes(t *testing.T) {
 		assert.Equal(t, 2, targetSize)
 	})
 
+	t.Run("check nodes are deleted by batches", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.CurrentSize = 5
+		ng.Manager.ProviderConfig.MaxBatchDeleteNodes = 2
+
+		ng.mockCallUpdateNodePool(3, []string{"openstack:///instance-1", "openstack:///instance-2"})
+		ng.mockCallUpdateNodePool(2, []string{"openstack:///instance-3"})
+
+		nodes := make([]*v1.Node, 0)
+		for i := 1; i <= 3; i++ {
+			nodes = append(nodes, &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)},
+				Spec:       v1.NodeSpec{ProviderID: fmt.Sprintf("openstack:///instance-%d", i)},
+			})
+		}
+
+		err := ng.DeleteNodes(nodes)
+		assert.NoError(t, err)
+
+		targetSize, err := ng.TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, targetSize)
+
+		ng.Manager.Client.(*sdk.ClientMock).AssertNumberOfCalls(t, "UpdateNodePool", 2)
+	})
+
+	t.Run("check nodes of the batches done are kept removed when a batch fails", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.CurrentSize = 5
+		ng.Manager.ProviderConfig.MaxBatchDeleteNodes = 2
+
+		ng.mockCallUpdateNodePool(3, []string{"openstack:///instance-1", "openstack:///instance-2"})
+		failed := uint32(2)
+		ng.Manager.Client.(*sdk.ClientMock).On(
+			"UpdateNodePool",
+			mock.Anything,
+			ng.Manager.ProjectID,
+			ng.Manager.ClusterID,
+			ng.ID,
+			&sdk.UpdateNodePoolOpts{DesiredNodes: &failed, NodesToRemove: []string{"openstack:///instance-3"}},
+		).Return(&sdk.NodePool{}, errors.New("unavailable"))
+
+		nodes := make([]*v1.Node, 0)
+		for i := 1; i <= 3; i++ {
+			nodes = append(nodes, &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)},
+				Spec:       v1.NodeSpec{ProviderID: fmt.Sprintf("openstack:///instance-%d", i)},
+			})
+		}
+
+		err := ng.DeleteNodes(nodes)
+		assert.Error(t, err)
+
+		targetSize, err := ng.TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, targetSize)
+
+		// The next resize starts from the size reached
+		ng.mockCallUpdateNodePool(4, nil)
+		ng.CurrentSize = -1
+		assert.NoError(t, ng.IncreaseSize(1))
+	})
+
+	t.Run("check delete nodes of locked node group", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.LockedAnnotation): "true"}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		err := ng.DeleteNodes(nil)
+		assert.ErrorIs(t, err, sdk.ErrNodePoolLocked)
+	})
+
+	t.Run("check delete nodes of node group in maintenance", func(t *testing.T) {
+		ng.Template.Metadata.Annotations = map[string]string{ng.Manager.ProviderConfig.AnnotationKey(sdk.MaintenanceModeAnnotation): sdk.MaintenanceModeActive}
+		defer func() { ng.Template.Metadata.Annotations = nil }()
+
+		err := ng.DeleteNodes(nil)
+		assert.ErrorIs(t, err, sdk.ErrPoolInMaintenanceMode)
+	})
+
 	t.Run("check delete nodes below min size", func(t *testing.T) {
 		err := ng.DeleteNodes([]*v1.Node{
 			{
@@ -346,6 +580,19 @@ func TestOVHCloudNodeGroup_Debug(t *testing.T) {
 	})
 }
 
+func TestParseProviderID(t *testing.T) {
+	instanceID, err := ParseProviderID(providerIDPrefix + "0123")
+	assert.NoError(t, err)
+	assert.Equal(t, "0123", instanceID)
+
+	instanceID, err = ParseProviderID(providerIDPrefix)
+	assert.NoError(t, err)
+	assert.Empty(t, instanceID)
+
+	_, err = ParseProviderID("aws:///eu-west-3a/i-0123")
+	assert.Error(t, err)
+}
+
 func TestOVHCloudNodeGroup_Nodes(t *testing.T) {
 	ng := newTestNodeGroup(t, "b2-7")
 
@@ -388,6 +635,7 @@ func TestOVHCloudNodeGroup_TemplateNodeInfo(t *testing.T) {
 		assert.Equal(t, *resource.NewQuantity(2, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceCPU])
 		assert.Equal(t, *resource.NewQuantity(0, resource.DecimalSI), node.Status.Capacity[gpu.ResourceNvidiaGPU])
 		assert.Equal(t, *resource.NewQuantity(7516192768, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceMemory])
+		assert.NotContains(t, node.Status.Capacity, apiv1.ResourceEphemeralStorage)
 	})
 
 	t.Run("template for t1-45 flavor", func(t *testing.T) {
@@ -408,6 +656,7 @@ func TestOVHCloudNodeGroup_TemplateNodeInfo(t *testing.T) {
 		assert.Equal(t, *resource.NewQuantity(8, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceCPU])
 		assert.Equal(t, *resource.NewQuantity(1, resource.DecimalSI), node.Status.Capacity[gpu.ResourceNvidiaGPU])
 		assert.Equal(t, *resource.NewQuantity(48318382080, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceMemory])
+		assert.Equal(t, *resource.NewQuantity(429496729600, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceEphemeralStorage])
 	})
 
 	t.Run("template for b2-7 flavor with node pool templates", func(t *testing.T) {
@@ -476,6 +725,11 @@ func TestOVHCloudNodeGroup_Create(t *testing.T) {
 		assert.Equal(t, 3, targetSize)
 		assert.Equal(t, 1, newGroup.MinSize())
 		assert.Equal(t, 5, newGroup.MaxSize())
+
+		ng.Manager.Client.(*sdk.ClientMock).AssertCalled(t, "InitialiseNodePool", context.Background(), "projectID", "clusterID", ng.ID, &sdk.InitialiseOpts{
+			AutoscalerEnabled: true,
+			ScaleDownEnabled:  true,
+		})
 	})
 }
 
@@ -488,6 +742,19 @@ func TestOVHCloudNodeGroup_Delete(t *testing.T) {
 		err := ng.Delete()
 		assert.NoError(t, err)
 	})
+
+	t.Run("check deleted node group is removed from cache", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		other := &NodeGroup{NodePool: sdk.NodePool{ID: "other"}}
+		ng.Manager.NodeGroupPerProviderID = map[string]*NodeGroup{"openstack:///1": ng, "openstack:///2": other}
+		ng.Manager.Client.(*sdk.ClientMock).On("DeleteNodePool", context.Background(), "projectID", "clusterID", "id").Return(
+			&sdk.NodePool{}, &sdk.APIError{Code: http.StatusNotFound, ErrorCode: sdk.ErrorCodeNodeGroupNotFound},
+		)
+
+		err := ng.Delete()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]*NodeGroup{"openstack:///2": other}, ng.Manager.NodeGroupPerProviderID)
+	})
 }
 
 func TestOVHCloudNodeGroup_Autoprovisioned(t *testing.T) {
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// DefaultNodeNamePattern matches the `<cluster-name>-<pool-name>-<suffix>` node names,
+// the cluster name and suffix holding no dash.
+const DefaultNodeNamePattern = `^(?P<cluster>[^-]+)-(?P<pool>.+)-(?P<suffix>[^-]+)$`
+
+// nodeNameGroups are the named groups a node name pattern must define
+var nodeNameGroups = []string{"cluster", "pool", "suffix"}
+
+// ParsedNodeName holds the parts of a node name
+type ParsedNodeName struct {
+	ClusterName string
+	PoolName    string
+	Suffix      string
+}
+
+// ParseVKENodeName splits a node name into its cluster name, pool name and suffix,
+// using the node name pattern of the configuration
+func ParseVKENodeName(name string, cfg *VKECloudProviderConfig) (*ParsedNodeName, error) {
+	re, err := cfg.nodeNameRegexp()
+	if err != nil {
+		return nil, err
+	}
+
+	match := re.FindStringSubmatch(name)
+	if match == nil {
+		return nil, fmt.Errorf("node name %q does not match pattern %s", name, re)
+	}
+
+	return &ParsedNodeName{
+		ClusterName: match[re.SubexpIndex("cluster")],
+		PoolName:    match[re.SubexpIndex("pool")],
+		Suffix:      match[re.SubexpIndex("suffix")],
+	}, nil
+}
+
+// FormatNodeName builds a node name from its parts, replacing the named groups of the node name
+// pattern of the configuration. Patterns holding more than anchors, literals and the named groups
+// cannot be filled, the `<cluster-name>-<pool-name>-<suffix>` convention is used for them.
+func FormatNodeName(clusterName, poolName, suffix string, cfg *VKECloudProviderConfig) string {
+	values := map[string]string{
+		"cluster": clusterName,
+		"pool":    poolName,
+		"suffix":  suffix,
+	}
+
+	pattern := DefaultNodeNamePattern
+	if cfg != nil && cfg.NodeNamePattern != "" {
+		pattern = cfg.NodeNamePattern
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err == nil {
+		var b strings.Builder
+		if fillNodeNamePattern(re, values, &b) {
+			return b.String()
+		}
+	}
+
+	return strings.Join([]string{clusterName, poolName, suffix}, "-")
+}
+
+// fillNodeNamePattern writes the pattern with its named groups replaced by their values.
+// It returns false if the pattern holds anything else than anchors, literals and named groups.
+func fillNodeNamePattern(re *syntax.Regexp, values map[string]string, b *strings.Builder) bool {
+	switch re.Op {
+	case syntax.OpBeginText, syntax.OpEndText, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpEmptyMatch:
+		return true
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCapture:
+		value, ok := values[re.Name]
+		if !ok {
+			return false
+		}
+		b.WriteString(value)
+		return true
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !fillNodeNamePattern(sub, values, b) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// nodeNameRegexp compiles the node name pattern, checking it defines the expected named groups
+func (cfg *VKECloudProviderConfig) nodeNameRegexp() (*regexp.Regexp, error) {
+	pattern := DefaultNodeNamePattern
+	if cfg != nil && cfg.NodeNamePattern != "" {
+		pattern = cfg.NodeNamePattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile node name pattern: %w", err)
+	}
+
+	for _, group := range nodeNameGroups {
+		if re.SubexpIndex(group) < 0 {
+			return nil, fmt.Errorf("node name pattern %s misses the %q named group", pattern, group)
+		}
+	}
+
+	return re, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVKENodeName(t *testing.T) {
+	t.Run("check default pattern", func(t *testing.T) {
+		parsed, err := ParseVKENodeName("prod-nodepool-b2-7-f3k9z", &VKECloudProviderConfig{})
+		assert.NoError(t, err)
+		assert.Equal(t, &ParsedNodeName{ClusterName: "prod", PoolName: "nodepool-b2-7", Suffix: "f3k9z"}, parsed)
+	})
+
+	t.Run("check custom pattern", func(t *testing.T) {
+		cfg := &VKECloudProviderConfig{NodeNamePattern: `^(?P<pool>[a-z0-9-]+)\.node-(?P<suffix>[0-9]+)\.(?P<cluster>[a-z]+)$`}
+
+		parsed, err := ParseVKENodeName("gpu-pool.node-42.prod", cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, &ParsedNodeName{ClusterName: "prod", PoolName: "gpu-pool", Suffix: "42"}, parsed)
+	})
+
+	t.Run("check unexpected name format", func(t *testing.T) {
+		_, err := ParseVKENodeName("standalone", &VKECloudProviderConfig{})
+		assert.ErrorContains(t, err, `node name "standalone" does not match pattern`)
+	})
+
+	t.Run("check pattern without the expected groups", func(t *testing.T) {
+		_, err := ParseVKENodeName("prod-pool-1", &VKECloudProviderConfig{NodeNamePattern: `^(?P<cluster>.+)-(?P<pool>.+)$`})
+		assert.ErrorContains(t, err, `misses the "suffix" named group`)
+	})
+}
+
+func TestFormatNodeName(t *testing.T) {
+	t.Run("check default pattern", func(t *testing.T) {
+		name := FormatNodeName("prod", "nodepool-b2-7", "f3k9z", nil)
+		assert.Equal(t, "prod-nodepool-b2-7-f3k9z", name)
+
+		parsed, err := ParseVKENodeName(name, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &ParsedNodeName{ClusterName: "prod", PoolName: "nodepool-b2-7", Suffix: "f3k9z"}, parsed)
+	})
+
+	t.Run("check custom pattern", func(t *testing.T) {
+		cfg := &VKECloudProviderConfig{NodeNamePattern: `^(?P<pool>[a-z0-9-]+)\.node-(?P<suffix>[0-9]+)\.(?P<cluster>[a-z]+)$`}
+		assert.Equal(t, "gpu-pool.node-42.prod", FormatNodeName("prod", "gpu-pool", "42", cfg))
+	})
+
+	t.Run("check pattern which cannot be filled", func(t *testing.T) {
+		cfg := &VKECloudProviderConfig{NodeNamePattern: `^(?P<cluster>[a-z]+)(-|_)(?P<pool>.+)-(?P<suffix>[^-]+)$`}
+		assert.Equal(t, "prod-gpu-pool-42", FormatNodeName("prod", "gpu-pool", "42", cfg))
+	})
+}
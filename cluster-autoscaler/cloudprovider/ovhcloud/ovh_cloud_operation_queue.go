@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PoolOperationQueue serialises the operations mutating a same node pool, so concurrent updates
+// do not overwrite each other. Operations on different node pools run concurrently.
+type PoolOperationQueue struct {
+	semaphores map[string]chan struct{}
+
+	// targetSizes is the size set by the last resize of the node pools, until they are listed again
+	targetSizes map[string]poolTargetSize
+
+	mutex sync.Mutex
+}
+
+// poolTargetSize is the size set by a resize of a node pool, and when it was set
+type poolTargetSize struct {
+	size  int
+	setAt time.Time
+}
+
+// NewPoolOperationQueue creates an empty operation queue
+func NewPoolOperationQueue() *PoolOperationQueue {
+	return &PoolOperationQueue{
+		semaphores:  make(map[string]chan struct{}),
+		targetSizes: make(map[string]poolTargetSize),
+	}
+}
+
+// Submit waits for the pending operations on the node pool to complete, then runs op.
+// It returns the error of op, or the context error if it is done before op could run.
+func (q *PoolOperationQueue) Submit(ctx context.Context, poolID string, op func(ctx context.Context) error) error {
+	semaphore := q.getSemaphore(poolID)
+
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-semaphore }()
+
+	return op(ctx)
+}
+
+// SubmitResize runs op like Submit, giving it the size to resize the node pool from: the size set by the previous
+// resize when the node pool was not listed since, currentSize otherwise. op returns the new size of the node pool,
+// so that concurrent resizes of a same node pool build on each other rather than on the same stale size.
+// When op fails, it returns the size it left the node pool with, kept if op resized it partially.
+func (q *PoolOperationQueue) SubmitResize(ctx context.Context, poolID string, currentSize int, op func(ctx context.Context, size int) (int, error)) error {
+	return q.Submit(ctx, poolID, func(ctx context.Context) error {
+		size := currentSize
+
+		q.mutex.Lock()
+		if targetSize, ok := q.targetSizes[poolID]; ok {
+			size = targetSize.size
+		}
+		q.mutex.Unlock()
+
+		newSize, err := op(ctx, size)
+		if err == nil || newSize != size {
+			q.mutex.Lock()
+			q.targetSizes[poolID] = poolTargetSize{size: newSize, setAt: time.Now()}
+			q.mutex.Unlock()
+		}
+
+		return err
+	})
+}
+
+// ForgetTargetSizes forgets the sizes set by the resizes done before the node pools were listed,
+// the listed node pools giving their size to the next resizes
+func (q *PoolOperationQueue) ForgetTargetSizes(listedAt time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for poolID, targetSize := range q.targetSizes {
+		if targetSize.setAt.Before(listedAt) {
+			delete(q.targetSizes, poolID)
+		}
+	}
+}
+
+// getSemaphore returns the semaphore of a node pool, creating it if needed
+func (q *PoolOperationQueue) getSemaphore(poolID string) chan struct{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	semaphore, ok := q.semaphores[poolID]
+	if !ok {
+		semaphore = make(chan struct{}, 1)
+		q.semaphores[poolID] = semaphore
+	}
+
+	return semaphore
+}
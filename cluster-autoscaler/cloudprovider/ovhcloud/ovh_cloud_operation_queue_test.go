@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolOperationQueue_Submit(t *testing.T) {
+	t.Run("check operations on the same pool run serially", func(t *testing.T) {
+		queue := NewPoolOperationQueue()
+
+		// Each operation reads the size, then writes it back incremented: concurrent runs would lose updates
+		size := 0
+		running := 0
+		order := make([]int, 0)
+		var mutex sync.Mutex
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				err := queue.Submit(context.Background(), "pool", func(ctx context.Context) error {
+					mutex.Lock()
+					running++
+					assert.Equal(t, 1, running)
+					current := size
+					mutex.Unlock()
+
+					time.Sleep(time.Millisecond)
+
+					mutex.Lock()
+					size = current + 1
+					order = append(order, size)
+					running--
+					mutex.Unlock()
+
+					return nil
+				})
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, size)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, order)
+	})
+
+	t.Run("check operations on different pools run concurrently", func(t *testing.T) {
+		queue := NewPoolOperationQueue()
+		released := make(chan struct{})
+
+		done := make(chan error)
+		go func() {
+			done <- queue.Submit(context.Background(), "pool-a", func(ctx context.Context) error {
+				<-released
+				return nil
+			})
+		}()
+
+		err := queue.Submit(context.Background(), "pool-b", func(ctx context.Context) error {
+			close(released)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("check operation error is returned", func(t *testing.T) {
+		queue := NewPoolOperationQueue()
+
+		err := queue.Submit(context.Background(), "pool", func(ctx context.Context) error {
+			return errors.New("conflict")
+		})
+		assert.EqualError(t, err, "conflict")
+	})
+
+	t.Run("check waiting operation gives up when its context is done", func(t *testing.T) {
+		queue := NewPoolOperationQueue()
+		released := make(chan struct{})
+		defer close(released)
+
+		started := make(chan struct{})
+		go func() {
+			_ = queue.Submit(context.Background(), "pool", func(ctx context.Context) error {
+				close(started)
+				<-released
+				return nil
+			})
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := queue.Submit(ctx, "pool", func(ctx context.Context) error {
+			assert.Fail(t, "operation should not run")
+			return nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestPoolOperationQueue_SubmitResize(t *testing.T) {
+	queue := NewPoolOperationQueue()
+	ctx := context.Background()
+
+	resize := func(delta int) func(ctx context.Context, size int) (int, error) {
+		return func(ctx context.Context, size int) (int, error) {
+			return size + delta, nil
+		}
+	}
+
+	t.Run("check resizes start from the size set by the previous one", func(t *testing.T) {
+		var sizes []int
+		record := func(ctx context.Context, size int) (int, error) {
+			sizes = append(sizes, size)
+			return size, nil
+		}
+
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, resize(2)))
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, resize(-1)))
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, record))
+		assert.NoError(t, queue.SubmitResize(ctx, "other-pool", 3, record))
+		assert.Equal(t, []int{4, 3}, sizes)
+	})
+
+	t.Run("check failed resizes keep the previous size", func(t *testing.T) {
+		err := queue.SubmitResize(ctx, "pool", 3, func(ctx context.Context, size int) (int, error) {
+			return size, errors.New("update failed")
+		})
+		assert.Error(t, err)
+
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 4, size)
+			return size, nil
+		}))
+	})
+
+	t.Run("check partially failed resizes keep the size reached", func(t *testing.T) {
+		err := queue.SubmitResize(ctx, "pool", 3, func(ctx context.Context, size int) (int, error) {
+			return size - 1, errors.New("second batch failed")
+		})
+		assert.Error(t, err)
+
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 3, size)
+			return size + 1, nil
+		}))
+	})
+
+	t.Run("check sizes are forgotten once the node pools are listed again", func(t *testing.T) {
+		queue.ForgetTargetSizes(time.Now())
+
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 3, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 3, size)
+			return size, nil
+		}))
+
+		// Sizes set after the node pools were listed are kept
+		queue.ForgetTargetSizes(time.Now().Add(-time.Hour))
+		assert.NoError(t, queue.SubmitResize(ctx, "pool", 5, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 3, size)
+			return size, nil
+		}))
+	})
+}
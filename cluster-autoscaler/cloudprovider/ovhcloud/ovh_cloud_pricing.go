@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// FlavorAnnotation is the name of the node annotation holding the flavor of the instance
+	FlavorAnnotation = "flavor"
+
+	flavorPricingCacheDuration = 15 * time.Minute
+)
+
+// VKEPricing implements the pricing model from the OVHcloud flavor prices, to be used by the price expander.
+type VKEPricing struct {
+	manager *OvhCloudManager
+	clock   sdk.Clock
+
+	pricesCache     map[string]cachedFlavorPrice
+	pricesCacheLock sync.Mutex
+}
+
+type cachedFlavorPrice struct {
+	hourlyPrice    float64
+	expirationTime time.Time
+}
+
+var _ cloudprovider.PricingModel = &VKEPricing{}
+
+// NewVKEPricing creates the pricing model of the node pools handled by the manager.
+func NewVKEPricing(manager *OvhCloudManager) *VKEPricing {
+	return &VKEPricing{
+		manager:     manager,
+		clock:       sdk.SystemClock{},
+		pricesCache: make(map[string]cachedFlavorPrice),
+	}
+}
+
+// NodePrice returns the price of running the node for the given period, from its flavor hourly price.
+func (p *VKEPricing) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	flavorName := p.nodeFlavor(node)
+	if flavorName == "" {
+		return 0, fmt.Errorf("failed to get flavor of node %s", node.Name)
+	}
+
+	hourlyPrice, err := p.getFlavorHourlyPrice(flavorName)
+	if err != nil {
+		return 0, err
+	}
+
+	return hourlyPrice * endTime.Sub(startTime).Hours(), nil
+}
+
+// PodPrice returns the share of the node price matching the pod CPU requests. Pods not running on a
+// known node pool are priced against the node pool flavor offering the cheapest CPU.
+func (p *VKEPricing) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	flavor, hourlyPrice, err := p.podFlavor(pod)
+	if err != nil {
+		return 0, err
+	}
+
+	if flavor.VCPUs <= 0 {
+		return 0, fmt.Errorf("flavor %s has no CPU", flavor.Name)
+	}
+
+	var requestedMilliCPUs int64
+	for _, container := range pod.Spec.Containers {
+		requestedMilliCPUs += container.Resources.Requests.Cpu().MilliValue()
+	}
+
+	ratio := float64(requestedMilliCPUs) / float64(flavor.VCPUs*1000)
+
+	return ratio * hourlyPrice * endTime.Sub(startTime).Hours(), nil
+}
+
+// nodeFlavor returns the flavor of a node from its annotation, falling back on its instance type label
+// and then on the node pool it belongs to, as template nodes only hold the node pool label.
+func (p *VKEPricing) nodeFlavor(node *apiv1.Node) string {
+	if flavor, ok := node.Annotations[p.manager.ProviderConfig.AnnotationKey(FlavorAnnotation)]; ok {
+		return flavor
+	}
+
+	if flavor, ok := node.Labels[apiv1.LabelInstanceTypeStable]; ok {
+		return flavor
+	}
+
+	for _, pool := range p.manager.NodePools {
+		if pool.Name == node.Labels[NodePoolLabel] {
+			return pool.Flavor
+		}
+	}
+
+	return ""
+}
+
+// podFlavor returns the flavor, and its hourly price, used to price a pod
+func (p *VKEPricing) podFlavor(pod *apiv1.Pod) (sdk.Flavor, float64, error) {
+	if pod.Spec.NodeName != "" {
+		if parsed, err := ParseVKENodeName(pod.Spec.NodeName, p.manager.ProviderConfig); err == nil {
+			for _, pool := range p.manager.NodePools {
+				if pool.Name != parsed.PoolName {
+					continue
+				}
+
+				flavor, err := p.manager.getFlavorByName(pool.Flavor)
+				if err != nil {
+					return sdk.Flavor{}, 0, err
+				}
+
+				hourlyPrice, err := p.getFlavorHourlyPrice(pool.Flavor)
+
+				return flavor, hourlyPrice, err
+			}
+		}
+	}
+
+	var (
+		cheapest      sdk.Flavor
+		cheapestPrice float64
+	)
+
+	for _, pool := range p.manager.NodePools {
+		flavor, err := p.manager.getFlavorByName(pool.Flavor)
+		if err != nil || flavor.VCPUs <= 0 {
+			continue
+		}
+
+		hourlyPrice, err := p.getFlavorHourlyPrice(pool.Flavor)
+		if err != nil {
+			klog.Warningf("Failed to get price of flavor %s: %v", pool.Flavor, err)
+			continue
+		}
+
+		if cheapest.Name == "" || hourlyPrice/float64(flavor.VCPUs) < cheapestPrice/float64(cheapest.VCPUs) {
+			cheapest, cheapestPrice = flavor, hourlyPrice
+		}
+	}
+
+	if cheapest.Name == "" {
+		return sdk.Flavor{}, 0, fmt.Errorf("failed to find a priced flavor for pod %s/%s", pod.Namespace, pod.Name)
+	}
+
+	return cheapest, cheapestPrice, nil
+}
+
+// getFlavorHourlyPrice returns the hourly price of a flavor from cache or from OVHcloud APIs if the cache is outdated
+func (p *VKEPricing) getFlavorHourlyPrice(flavorName string) (float64, error) {
+	p.pricesCacheLock.Lock()
+	defer p.pricesCacheLock.Unlock()
+
+	now := p.clock.Now()
+	if cached, ok := p.pricesCache[flavorName]; ok && now.Before(cached.expirationTime) {
+		return cached.hourlyPrice, nil
+	}
+
+	pricing, err := p.manager.Client.GetFlavorPricing(context.Background(), p.manager.ProjectID, p.manager.ClusterID, flavorName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price of flavor %s: %w", flavorName, err)
+	}
+
+	p.pricesCache[flavorName] = cachedFlavorPrice{
+		hourlyPrice:    pricing.HourlyPrice,
+		expirationTime: now.Add(flavorPricingCacheDuration),
+	}
+
+	return pricing.HourlyPrice, nil
+}
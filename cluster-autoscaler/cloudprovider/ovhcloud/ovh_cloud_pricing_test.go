@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func newTestPricing(t *testing.T) (*VKEPricing, *sdk.ClientMock, *sdk.FakeClock) {
+	provider := newTestProvider(t)
+	client := provider.manager.Client.(*sdk.ClientMock)
+
+	client.On("GetFlavorPricing", context.Background(), "projectID", "clusterID", "b2-7").Return(
+		&sdk.FlavorPricing{Flavor: "b2-7", Currency: "EUR", HourlyPrice: 0.07}, nil,
+	)
+
+	clock := sdk.NewFakeClock(time.Now())
+	pricing := NewVKEPricing(provider.manager)
+	pricing.clock = clock
+
+	return pricing, client, clock
+}
+
+func newTestPricingPod(nodeName string, cpu string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "default",
+		},
+		Spec: apiv1.PodSpec{
+			NodeName: nodeName,
+			Containers: []apiv1.Container{
+				{
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{
+							apiv1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestVKEPricing_NodePrice(t *testing.T) {
+	pricing, client, clock := newTestPricing(t)
+	start := time.Now()
+
+	t.Run("check price from flavor annotation", func(t *testing.T) {
+		node := &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node",
+				Annotations: map[string]string{"vke.autoscaler/flavor": "b2-7"},
+			},
+		}
+
+		price, err := pricing.NodePrice(node, start, start.Add(10*time.Hour))
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.7, price, 1e-9)
+	})
+
+	t.Run("check price from node pool label", func(t *testing.T) {
+		node := &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node",
+				Labels: map[string]string{NodePoolLabel: "pool-1"},
+			},
+		}
+
+		price, err := pricing.NodePrice(node, start, start.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.07, price, 1e-9)
+	})
+
+	t.Run("check prices are cached", func(t *testing.T) {
+		client.AssertNumberOfCalls(t, "GetFlavorPricing", 1)
+
+		clock.Advance(16 * time.Minute)
+
+		node := &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node",
+				Labels: map[string]string{apiv1.LabelInstanceTypeStable: "b2-7"},
+			},
+		}
+
+		_, err := pricing.NodePrice(node, start, start.Add(time.Hour))
+		assert.NoError(t, err)
+		client.AssertNumberOfCalls(t, "GetFlavorPricing", 2)
+	})
+
+	t.Run("check unknown flavor", func(t *testing.T) {
+		_, err := pricing.NodePrice(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}, start, start.Add(time.Hour))
+		assert.Error(t, err)
+	})
+}
+
+func TestVKEPricing_PodPrice(t *testing.T) {
+	pricing, _, _ := newTestPricing(t)
+	start := time.Now()
+	end := start.Add(10 * time.Hour)
+
+	t.Run("check price is proportional to requests", func(t *testing.T) {
+		half, err := pricing.PodPrice(newTestPricingPod("", "1"), start, end)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.35, half, 1e-9)
+
+		quarter, err := pricing.PodPrice(newTestPricingPod("", "500m"), start, end)
+		assert.NoError(t, err)
+		assert.InDelta(t, half/2, quarter, 1e-9)
+	})
+
+	t.Run("check price of a scheduled pod", func(t *testing.T) {
+		price, err := pricing.PodPrice(newTestPricingPod("cluster-pool-2-abc12", "2"), start, end)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.7, price, 1e-9)
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"sort"
+	"strconv"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// PriorityAnnotation is the name of the annotation holding the node pool expansion priority, higher is preferred
+	PriorityAnnotation = "priority"
+
+	// PreferSpotAnnotation is the name of the annotation marking a node pool running spot instances when set to "true"
+	PreferSpotAnnotation = "prefer-spot"
+
+	// NodeGroupPriorityExpanderName is the expander name to use to expand node groups by their annotation priority
+	NodeGroupPriorityExpanderName = "vke-priority"
+)
+
+// NodeGroupPriorityFilter is an expander keeping the options of the highest ranked node groups.
+// Node groups are ranked by their spot preference when PreferSpot is set, then by priority.
+type NodeGroupPriorityFilter struct {
+	PreferSpot bool
+}
+
+// nodeGroupRank holds the node pool annotations used to order node groups
+type nodeGroupRank struct {
+	priority int
+	spot     bool
+}
+
+// BestOptions returns the options whose node groups have the highest rank.
+func (f *NodeGroupPriorityFilter) BestOptions(options []expander.Option, nodeInfo map[string]*schedulerframework.NodeInfo) []expander.Option {
+	if len(options) == 0 {
+		return nil
+	}
+
+	best := make([]expander.Option, 0, len(options))
+	var bestRank nodeGroupRank
+
+	for _, option := range options {
+		rank := getNodeGroupRank(option.NodeGroup)
+
+		switch {
+		case len(best) == 0 || f.less(bestRank, rank):
+			best = append(best[:0], option)
+			bestRank = rank
+		case !f.less(rank, bestRank):
+			best = append(best, option)
+		}
+	}
+
+	return best
+}
+
+// less reports whether a is ranked below b
+func (f *NodeGroupPriorityFilter) less(a, b nodeGroupRank) bool {
+	if f.PreferSpot && a.spot != b.spot {
+		return b.spot
+	}
+
+	return a.priority < b.priority
+}
+
+// SortNodeGroupsByPriority returns the node groups sorted from the most to the least preferred.
+// Spot node groups come first when preferSpot is set, then node groups are sorted by priority.
+func SortNodeGroupsByPriority(groups []cloudprovider.NodeGroup, preferSpot bool) []cloudprovider.NodeGroup {
+	filter := &NodeGroupPriorityFilter{PreferSpot: preferSpot}
+
+	sorted := make([]cloudprovider.NodeGroup, len(groups))
+	copy(sorted, groups)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return filter.less(getNodeGroupRank(sorted[j]), getNodeGroupRank(sorted[i]))
+	})
+
+	return sorted
+}
+
+// getNodeGroupRank reads the ranking annotations of an OVHcloud node group.
+// Other node groups, and invalid priorities, are ranked with the default priority 0.
+func getNodeGroupRank(group cloudprovider.NodeGroup) nodeGroupRank {
+	ng, ok := group.(*NodeGroup)
+	if !ok {
+		return nodeGroupRank{}
+	}
+
+	cfg := ng.Manager.ProviderConfig
+	annotations := ng.Template.Metadata.Annotations
+
+	rank := nodeGroupRank{
+		spot: annotations[cfg.AnnotationKey(PreferSpotAnnotation)] == "true",
+	}
+
+	if value, ok := annotations[cfg.AnnotationKey(PriorityAnnotation)]; ok {
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			klog.V(2).Infof("Ignoring invalid priority %q of node group %s: %v", value, ng.Id(), err)
+		} else {
+			rank.priority = priority
+		}
+	}
+
+	return rank
+}
+
+// ExpanderFilters returns the expanders offered by the cloud provider, registered by the expander factory.
+func (provider *OVHCloudProvider) ExpanderFilters() map[string]func() expander.Filter {
+	return map[string]func() expander.Filter{
+		NodeGroupPriorityExpanderName: func() expander.Filter {
+			return &NodeGroupPriorityFilter{PreferSpot: provider.manager.ProviderConfig.ExpanderPreferSpot}
+		},
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+)
+
+func newTestPriorityNodeGroup(name string, annotations map[string]string) *NodeGroup {
+	ng := &NodeGroup{Manager: newManagerWithClient(nil, "projectID", "clusterID")}
+	ng.Name = name
+
+	ng.Template.Metadata.Annotations = make(map[string]string)
+	for key, value := range annotations {
+		ng.Template.Metadata.Annotations[ng.Manager.ProviderConfig.AnnotationKey(key)] = value
+	}
+
+	return ng
+}
+
+func nodeGroupNames(groups []cloudprovider.NodeGroup) []string {
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Id())
+	}
+
+	return names
+}
+
+func TestSortNodeGroupsByPriority(t *testing.T) {
+	spot := newTestPriorityNodeGroup("spot", map[string]string{PriorityAnnotation: "10", PreferSpotAnnotation: "true"})
+	onDemand := newTestPriorityNodeGroup("on-demand", map[string]string{PriorityAnnotation: "20"})
+	fallback := newTestPriorityNodeGroup("fallback", nil)
+	invalid := newTestPriorityNodeGroup("invalid", map[string]string{PriorityAnnotation: "high"})
+
+	groups := []cloudprovider.NodeGroup{fallback, invalid, spot, onDemand}
+
+	t.Run("check spot node group outranks higher priority when spot is preferred", func(t *testing.T) {
+		sorted := SortNodeGroupsByPriority(groups, true)
+		assert.Equal(t, []string{"spot", "on-demand", "fallback", "invalid"}, nodeGroupNames(sorted))
+	})
+
+	t.Run("check node groups are sorted by priority otherwise", func(t *testing.T) {
+		sorted := SortNodeGroupsByPriority(groups, false)
+		assert.Equal(t, []string{"on-demand", "spot", "fallback", "invalid"}, nodeGroupNames(sorted))
+	})
+
+	t.Run("check input is left untouched", func(t *testing.T) {
+		_ = SortNodeGroupsByPriority(groups, true)
+		assert.Equal(t, []string{"fallback", "invalid", "spot", "on-demand"}, nodeGroupNames(groups))
+	})
+}
+
+func TestNodeGroupPriorityFilter_BestOptions(t *testing.T) {
+	spot := newTestPriorityNodeGroup("spot", map[string]string{PriorityAnnotation: "10", PreferSpotAnnotation: "true"})
+	onDemand := newTestPriorityNodeGroup("on-demand", map[string]string{PriorityAnnotation: "20"})
+	onDemandBis := newTestPriorityNodeGroup("on-demand-bis", map[string]string{PriorityAnnotation: "20"})
+
+	options := []expander.Option{{NodeGroup: spot}, {NodeGroup: onDemand}, {NodeGroup: onDemandBis}}
+
+	t.Run("check spot option is kept when spot is preferred", func(t *testing.T) {
+		filter := &NodeGroupPriorityFilter{PreferSpot: true}
+
+		best := filter.BestOptions(options, nil)
+		assert.Equal(t, []expander.Option{{NodeGroup: spot}}, best)
+	})
+
+	t.Run("check all highest priority options are kept", func(t *testing.T) {
+		filter := &NodeGroupPriorityFilter{}
+
+		best := filter.BestOptions(options, nil)
+		assert.Equal(t, []expander.Option{{NodeGroup: onDemand}, {NodeGroup: onDemandBis}}, best)
+	})
+
+	t.Run("check no options", func(t *testing.T) {
+		filter := &NodeGroupPriorityFilter{}
+
+		assert.Empty(t, filter.BestOptions(nil, nil))
+	})
+}
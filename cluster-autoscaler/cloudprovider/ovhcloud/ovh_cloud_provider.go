@@ -22,6 +22,10 @@ import (
 	"io"
 	"math/rand"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -32,6 +36,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 )
 
 const (
@@ -49,6 +54,9 @@ const (
 	GPUMachineCategory = "t"
 )
 
+// shutdownTimeout bounds the wait for the in-flight API calls on cleanup
+const shutdownTimeout = 30 * time.Second
+
 // OVHCloudProvider implements CloudProvider interface.
 type OVHCloudProvider struct {
 	manager *OvhCloudManager
@@ -56,6 +64,18 @@ type OVHCloudProvider struct {
 	autoscalingOptions config.AutoscalingOptions
 	discoveryOptions   cloudprovider.NodeGroupDiscoveryOptions
 	resourceLimiter    *cloudprovider.ResourceLimiter
+
+	// apiHealthy and driftCount report the outcome of the last refresh to the status writer
+	apiHealthy bool
+	driftCount int
+	statusLock sync.Mutex
+
+	// statusWriter writes the VKE status in the status ConfigMap after every refresh (optional)
+	statusWriter *VKEStatusWriter
+
+	// pricing is built on first use so that flavor prices are cached across calls
+	pricing     *VKEPricing
+	pricingOnce sync.Once
 }
 
 // BuildOVHcloud builds the OVHcloud provider.
@@ -79,6 +99,38 @@ func BuildOVHcloud(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDis
 		klog.Fatalf("Failed to create OVHcloud manager: %v", err)
 	}
 
+	kubeClient := kube_util.CreateKubeClient(opts.KubeClientOpts)
+	manager.KubeClient = kubeClient
+
+	options := []CloudProviderOption{WithPendingPodsLister(NewKubePendingPodsLister(kubeClient))}
+	if manager.ProviderConfig.SoftDeleteMode {
+		options = append(options, WithSoftDeleteMode(kubeClient))
+	}
+	if manager.ProviderConfig.QuotaReservation != nil {
+		options = append(options, WithQuotaReservation(manager.ProviderConfig.QuotaReservation))
+	}
+
+	provider := NewOVHCloudProvider(manager, opts, do, rl, options...)
+	if opts.WriteStatusConfigMap {
+		provider.statusWriter = NewVKEStatusWriter(provider, kubeClient, opts.ConfigNamespace, opts.StatusConfigMapName)
+	}
+
+	// Fail fast when the cluster cannot be reached with the given configuration
+	err = LogStartupBanner(context.Background(), manager.ProviderConfig, manager.Client)
+	if err != nil {
+		klog.Fatalf("Failed to start OVHcloud cloud provider: %v", err)
+	}
+
+	RegisterMetrics()
+
+	return provider
+}
+
+// CloudProviderOption customizes the behaviour of the cloud provider.
+type CloudProviderOption func(provider *OVHCloudProvider)
+
+// NewOVHCloudProvider creates the cloud provider given an already built manager.
+func NewOVHCloudProvider(manager *OvhCloudManager, opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscoveryOptions, rl *cloudprovider.ResourceLimiter, options ...CloudProviderOption) *OVHCloudProvider {
 	provider := &OVHCloudProvider{
 		manager: manager,
 
@@ -87,6 +139,10 @@ func BuildOVHcloud(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDis
 		resourceLimiter:    rl,
 	}
 
+	for _, option := range options {
+		option(provider)
+	}
+
 	return provider
 }
 
@@ -123,10 +179,17 @@ func (provider *OVHCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
 // should not be processed by cluster autoscaler, or non-nil error if such
 // occurred. Must be implemented.
 func (provider *OVHCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	// Nodes which are not OpenStack instances are not managed by OVHcloud node pools
+	instanceID, err := ParseProviderID(node.Spec.ProviderID)
+	if err != nil {
+		klog.V(4).Infof("node %s is not managed by OVHcloud: %v", node.Name, err)
+		return nil, nil
+	}
+
 	// If the provider ID is empty (only the prefix), it means that we are processing an UnregisteredNode retrieved
 	// from OVHCloud APIs, which has just started being created, and the OpenStack instance ID is not yet set.
 	// We won't be able to determine the node group of the node with the information at hand.
-	if node.Spec.ProviderID == providerIDPrefix {
+	if instanceID == "" {
 		return nil, nil
 	}
 
@@ -135,6 +198,11 @@ func (provider *OVHCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovi
 		return ng, nil
 	}
 
+	// Try to find the associated node group from the node pool ID annotated on the node
+	if ng := provider.findNodeGroupFromAnnotation(node); ng != nil {
+		return ng, nil
+	}
+
 	// Try to find the associated node group from the nodepool label on the node
 	if ng := provider.findNodeGroupFromLabel(node); ng != nil {
 		return ng, nil
@@ -185,11 +253,42 @@ func (provider *OVHCloudProvider) findNodeGroupFromLabel(node *apiv1.Node) cloud
 	return nil
 }
 
-// findNodeGroupByListingNodes finds the associated node group from by listing all nodes under autoscaled node pools
-func (provider *OVHCloudProvider) findNodeGroupByListingNodes(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+// findNodeGroupFromAnnotation tries to find the associated node group from the node pool ID annotated on the node,
+// ignoring the annotations of another cluster
+func (provider *OVHCloudProvider) findNodeGroupFromAnnotation(node *apiv1.Node) cloudprovider.NodeGroup {
+	annotations := node.GetAnnotations()
+	poolID, exists := annotations[sdk.NodeGroupIDAnnotation]
+	if !exists || annotations[sdk.ClusterIDAnnotation] != provider.manager.ClusterID {
+		return nil
+	}
+
 	for _, ng := range provider.NodeGroups() {
+		if ng.(*NodeGroup).ID == poolID {
+			return ng
+		}
+	}
+
+	return nil
+}
+
+// findNodeGroupByListingNodes finds the associated node group from by listing all nodes under autoscaled node pools.
+// The node pool named in the node name, if any, is listed first.
+func (provider *OVHCloudProvider) findNodeGroupByListingNodes(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	nodeGroups := provider.NodeGroups()
+
+	if parsed, err := ParseVKENodeName(node.Name, provider.manager.ProviderConfig); err == nil {
+		sort.SliceStable(nodeGroups, func(i, j int) bool {
+			return nodeGroups[i].Id() == parsed.PoolName && nodeGroups[j].Id() != parsed.PoolName
+		})
+	}
+
+	for _, ng := range nodeGroups {
 		// This calls OVHCloud APIs and refreshes the cache
 		instances, err := ng.Nodes()
+		if sdk.IsNodeGroupNotFound(err) {
+			// The node pool has been deleted since the last refresh, look for the node in the other ones
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to list nodes in node group %s: %w", ng.Id(), err)
 		}
@@ -207,8 +306,11 @@ func (provider *OVHCloudProvider) findNodeGroupByListingNodes(node *apiv1.Node)
 // Pricing returns pricing model for this cloud provider or error if not
 // available. Implementation optional.
 func (provider *OVHCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
-	// This is not implemented in API
-	return nil, cloudprovider.ErrNotImplemented
+	provider.pricingOnce.Do(func() {
+		provider.pricing = NewVKEPricing(provider.manager)
+	})
+
+	return provider.pricing, nil
 }
 
 // GetAvailableMachineTypes get all machine types that can be requested from
@@ -251,6 +353,74 @@ func (provider *OVHCloudProvider) NewNodeGroup(machineType string, labels map[st
 	return ng, nil
 }
 
+// logCapacityReservations logs the capacity reserved for each node pool.
+// Failures are only logged as reservations are informative.
+func (provider *OVHCloudProvider) logCapacityReservations(pools []sdk.NodePool) {
+	for _, pool := range pools {
+		reservations, err := provider.manager.Client.ListCapacityReservations(context.Background(), provider.manager.ProjectID, provider.manager.ClusterID, pool.ID)
+		if err != nil {
+			klog.Warningf("failed to list capacity reservations of node pool %s: %v", pool.Name, err)
+			continue
+		}
+
+		for _, reservation := range reservations {
+			klog.V(4).Infof("Node pool %s has %d %s instance(s) reserved until %s", pool.Name, reservation.Quantity, reservation.FlavorID, reservation.ExpiresAt)
+		}
+	}
+}
+
+// detectConfigDrift compares freshly fetched node pools against the cached ones
+// and reports configuration changes which were not performed by the autoscaler.
+// The labels managed by the autoscaler are left out of the comparison.
+// It returns the number of node pools whose configuration drifted.
+func (provider *OVHCloudProvider) detectConfigDrift(pools []sdk.NodePool) int {
+	drifted := 0
+
+	previous := make(map[string]*sdk.NodePool, len(provider.manager.NodePools))
+	for i := range provider.manager.NodePools {
+		previous[provider.manager.NodePools[i].ID] = &provider.manager.NodePools[i]
+	}
+
+	for i := range pools {
+		desired, ok := previous[pools[i].ID]
+		if !ok {
+			continue
+		}
+
+		report := sdk.DiffNodePoolConfig(provider.withoutManagedLabels(desired), provider.withoutManagedLabels(&pools[i]))
+		if !report.HasDrift {
+			continue
+		}
+		drifted++
+
+		for _, diff := range report.Diffs {
+			klog.Warningf("Node pool %s configuration drifted: %s changed from %q to %q", pools[i].Name, diff.Field, diff.Desired, diff.Actual)
+			nodePoolConfigDriftTotal.WithLabelValues(pools[i].Name, diff.Field).Inc()
+		}
+	}
+
+	return drifted
+}
+
+// withoutManagedLabels returns a copy of a node pool whose template has none of the labels the autoscaler sets
+// itself, i.e. the labels under its prefix and the ones set by InitialiseNodePool
+func (provider *OVHCloudProvider) withoutManagedLabels(pool *sdk.NodePool) *sdk.NodePool {
+	prefix := provider.manager.ProviderConfig.AnnotationKey("")
+
+	labels := make(map[string]string, len(pool.Template.Metadata.Labels))
+	for key, value := range pool.Template.Metadata.Labels {
+		if strings.HasPrefix(key, prefix) || key == sdk.AutoscalingEnabledLabel {
+			continue
+		}
+		labels[key] = value
+	}
+
+	copied := *pool
+	copied.Template.Metadata.Labels = labels
+
+	return &copied
+}
+
 // GetResourceLimiter returns struct containing limits (max, min) for
 // resources (cores, memory etc.).
 func (provider *OVHCloudProvider) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
@@ -292,6 +462,16 @@ func (provider *OVHCloudProvider) GetNodeGpuConfig(node *apiv1.Node) *cloudprovi
 // Cleanup cleans up open resources before the cloud provider is destroyed,
 // i.e. go routines etc.
 func (provider *OVHCloudProvider) Cleanup() error {
+	provider.manager.StopSpotInterruptionHandlers()
+
+	// Let the in-flight scale calls complete, so that node pools are not left in an unknown state
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := provider.manager.Client.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down API client: %w", err)
+	}
+
 	return nil
 }
 
@@ -299,8 +479,27 @@ func (provider *OVHCloudProvider) Cleanup() error {
 // update cloud provider state. In particular the list of node groups returned
 // by NodeGroups() can change as a result of CloudProvider.Refresh().
 func (provider *OVHCloudProvider) Refresh() error {
+	err := provider.refresh()
+
+	provider.statusLock.Lock()
+	provider.apiHealthy = err == nil
+	provider.statusLock.Unlock()
+
+	if provider.statusWriter != nil {
+		if _, writeErr := provider.statusWriter.Write(context.Background(), time.Now()); writeErr != nil {
+			klog.Warningf("failed to write VKE status: %v", writeErr)
+		}
+	}
+
+	return err
+}
+
+func (provider *OVHCloudProvider) refresh() error {
 	klog.V(4).Info("Listing node pools to refresh NodeGroups")
 
+	// Refresh is called at the start of every autoscaling loop
+	provider.manager.CallBudget.Reset()
+
 	// Check if OpenStack keystone token need to be revoke and re-create
 	err := provider.manager.ReAuthenticate()
 	if err != nil {
@@ -308,13 +507,42 @@ func (provider *OVHCloudProvider) Refresh() error {
 	}
 
 	// Fetch node pools via OVHcloud API
+	listedAt := time.Now()
 	pools, err := provider.manager.Client.ListNodePools(context.Background(), provider.manager.ProjectID, provider.manager.ClusterID)
 	if err != nil {
 		return fmt.Errorf("failed to refresh node pool list: %w", err)
 	}
 
-	// Update the node pools cache
+	// Warn about node pools whose configuration has been changed outside of the autoscaler
+	drifted := provider.detectConfigDrift(pools)
+
+	provider.statusLock.Lock()
+	provider.driftCount = drifted
+	provider.statusLock.Unlock()
+
+	provider.logCapacityReservations(pools)
+
+	// Keep track of the node pools still provisioning or removing nodes
+	provider.manager.recordTargetSizes(pools, time.Now())
+
+	// Set the resources of the nodes from the cached flavors
+	provider.manager.setFlavorResources(pools)
+
+	// Update the node pools cache, forgetting the node groups of the deleted pools
 	provider.manager.NodePools = pools
+	provider.manager.pruneNodeGroupPerProviderID(pools)
+
+	// The listed node pools include the resizes done before listing them
+	provider.manager.PoolOperations.ForgetTargetSizes(listedAt)
+
+	// Follow the interruption notices of the spot node pools
+	provider.manager.syncSpotInterruptionHandlers(pools)
+
+	// Annotate the new nodes with their node pool, for their node group to be found without listing the node pools nodes
+	provider.manager.annotateNodesWithPoolID(context.Background())
+
+	// Leave the nodes pending deletion out of the target sizes
+	provider.manager.syncPendingDeletions(context.Background())
 
 	return nil
 }
@@ -19,9 +19,11 @@ package ovhcloud
 import (
 	"bytes"
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
@@ -99,6 +101,7 @@ func newTestProvider(t *testing.T) *OVHCloudProvider {
 		}, nil,
 	)
 
+	client.On("ListCapacityReservations", ctx, "projectID", "clusterID", mock.Anything).Return([]sdk.CapacityReservation{}, nil)
 	manager.Client = client
 
 	minLimits := map[string]int64{cloudprovider.ResourceNameCores: 1, cloudprovider.ResourceNameMemory: 10000000}
@@ -211,6 +214,35 @@ func TestOVHCloudProvider_NodeGroupForNode(t *testing.T) {
 		assert.Equal(t, 5, group.MaxSize())
 	})
 
+	t.Run("find node group with annotation on node", func(t *testing.T) {
+		node := &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Annotations: map[string]string{
+					sdk.NodeGroupIDAnnotation: "2",
+					sdk.ClusterIDAnnotation:   provider.manager.ClusterID,
+				},
+				Labels: map[string]string{
+					"nodepool": "pool-1",
+				},
+			},
+			Spec: apiv1.NodeSpec{
+				ProviderID: providerIDPrefix + "0123",
+			},
+		}
+
+		group, err := provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "pool-2", group.Id())
+
+		// The annotations of another cluster are ignored
+		node.Annotations[sdk.ClusterIDAnnotation] = "other-cluster"
+
+		group, err = provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "pool-1", group.Id())
+	})
+
 	t.Run("find node group by listing nodes", func(t *testing.T) {
 		node := &apiv1.Node{
 			ObjectMeta: metav1.ObjectMeta{
@@ -265,6 +297,21 @@ func TestOVHCloudProvider_NodeGroupForNode(t *testing.T) {
 		assert.Nil(t, group)
 	})
 
+	t.Run("ignore node not managed by OVHcloud", func(t *testing.T) {
+		node := &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "unmanaged",
+			},
+			Spec: apiv1.NodeSpec{
+				ProviderID: "kind://docker/kind/kind-worker",
+			},
+		}
+
+		group, err := provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+		assert.Nil(t, group)
+	})
+
 	t.Run("fail to find node group with incorrect label", func(t *testing.T) {
 		node := &apiv1.Node{
 			ObjectMeta: metav1.ObjectMeta{
@@ -316,12 +363,89 @@ func TestOVHCloudProvider_NodeGroupForNode(t *testing.T) {
 	})
 }
 
+func TestOVHCloudProvider_NodeGroupForNodeName(t *testing.T) {
+	provider := newTestProvider(t)
+	client := provider.manager.Client.(*sdk.ClientMock)
+
+	client.On("ListNodePoolNodes", context.Background(), "projectID", "clusterID", "2").Return(
+		[]sdk.Node{
+			{
+				Name:       "cluster-pool-2-abc12",
+				InstanceID: "0123",
+			},
+		}, nil,
+	)
+
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-pool-2-abc12",
+		},
+		Spec: apiv1.NodeSpec{
+			ProviderID: providerIDPrefix + "0123",
+		},
+	}
+
+	t.Run("check pool named in the node name is listed first", func(t *testing.T) {
+		group, err := provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+		assert.NotNil(t, group)
+
+		assert.Equal(t, "pool-2", group.Id())
+		client.AssertNotCalled(t, "ListNodePoolNodes", context.Background(), "projectID", "clusterID", "1")
+	})
+}
+
+func TestOVHCloudProvider_NodeGroupForNode_DeletedNodeGroup(t *testing.T) {
+	provider := newTestProvider(t)
+	client := provider.manager.Client.(*sdk.ClientMock)
+
+	notFound := &sdk.ErrNodeGroupNotFound{PoolID: "1", Err: &sdk.APIError{Code: http.StatusNotFound}}
+	client.On("ListNodePoolNodes", context.Background(), "projectID", "clusterID", "1").Return([]sdk.Node{}, notFound)
+	client.On("ListNodePoolNodes", context.Background(), "projectID", "clusterID", "2").Return(
+		[]sdk.Node{
+			{
+				Name:       "node-1",
+				InstanceID: "0123",
+			},
+		}, nil,
+	)
+
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+		Spec: apiv1.NodeSpec{
+			ProviderID: providerIDPrefix + "0123",
+		},
+	}
+
+	t.Run("check deleted node group is skipped and its nodes forgotten", func(t *testing.T) {
+		deleted := &NodeGroup{NodePool: sdk.NodePool{ID: "1"}}
+		provider.manager.NodeGroupPerProviderID[providerIDPrefix+"4567"] = deleted
+
+		group, err := provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+		assert.NotNil(t, group)
+		assert.Equal(t, "pool-2", group.Id())
+
+		assert.Nil(t, provider.manager.getNodeGroupPerProviderID(providerIDPrefix+"4567"))
+
+		// The node pool itself is only dropped by the next refresh
+		assert.Len(t, provider.manager.NodePools, 2)
+	})
+}
+
 func TestOVHCloudProvider_Pricing(t *testing.T) {
 	provider := newTestProvider(t)
 
-	t.Run("not implemented", func(t *testing.T) {
-		_, err := provider.Pricing()
-		assert.Error(t, err)
+	t.Run("check pricing model is shared", func(t *testing.T) {
+		pricing, err := provider.Pricing()
+		assert.NoError(t, err)
+		assert.IsType(t, &VKEPricing{}, pricing)
+
+		other, err := provider.Pricing()
+		assert.NoError(t, err)
+		assert.Same(t, pricing, other)
 	})
 }
 
@@ -391,11 +515,25 @@ func TestOVHCloudProvider_GetAvailableGPUTypes(t *testing.T) {
 }
 
 func TestOVHCloudProvider_Cleanup(t *testing.T) {
-	provider := newTestProvider(t)
-
 	t.Run("check return nil", func(t *testing.T) {
+		provider := newTestProvider(t)
+		client := provider.manager.Client.(*sdk.ClientMock)
+		client.On("Shutdown", mock.Anything).Return(nil).Once()
+
 		err := provider.Cleanup()
 		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("check abandoned calls are returned", func(t *testing.T) {
+		provider := newTestProvider(t)
+		client := provider.manager.Client.(*sdk.ClientMock)
+		client.On("Shutdown", mock.Anything).Return(&sdk.AbandonedCallsError{Count: 2, Err: context.DeadlineExceeded}).Once()
+
+		err := provider.Cleanup()
+		var abandoned *sdk.AbandonedCallsError
+		assert.ErrorAs(t, err, &abandoned)
+		assert.Equal(t, int64(2), abandoned.Count)
 	})
 }
 
@@ -414,4 +552,75 @@ func TestOVHCloudProvider_Refresh(t *testing.T) {
 		groups = provider.NodeGroups()
 		assert.Equal(t, 2, len(groups))
 	})
+
+	t.Run("check refresh gives the API call budget back", func(t *testing.T) {
+		provider.manager.CallBudget = sdk.NewCallBudget(1)
+		defer func() { provider.manager.CallBudget = nil }()
+		assert.NoError(t, provider.manager.CallBudget.Spend())
+
+		err := provider.Refresh()
+		assert.NoError(t, err)
+		assert.NoError(t, provider.manager.CallBudget.Spend())
+	})
+
+	t.Run("check refresh sets the resources of the node pools", func(t *testing.T) {
+		err := provider.Refresh()
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, provider.manager.NodePools[0].CPUCount)
+		assert.Equal(t, 0, provider.manager.NodePools[0].GPUCount)
+		assert.Equal(t, 7*1024, provider.manager.NodePools[0].MemoryMB)
+	})
+
+	t.Run("check refresh forgets the nodes of deleted node groups", func(t *testing.T) {
+		provider.manager.NodeGroupPerProviderID = map[string]*NodeGroup{
+			providerIDPrefix + "0": {NodePool: sdk.NodePool{ID: "1"}},
+			providerIDPrefix + "1": {NodePool: sdk.NodePool{ID: "deleted"}},
+		}
+
+		err := provider.Refresh()
+		assert.NoError(t, err)
+
+		assert.Contains(t, provider.manager.NodeGroupPerProviderID, providerIDPrefix+"0")
+		assert.NotContains(t, provider.manager.NodeGroupPerProviderID, providerIDPrefix+"1")
+	})
+
+	t.Run("check refresh overrides drifted configuration", func(t *testing.T) {
+		drifted := provider.manager.NodePools[0]
+		drifted.MaxNodes = 10
+		drifted.Flavor = "b2-15"
+		provider.manager.NodePools = []sdk.NodePool{drifted}
+
+		err := provider.Refresh()
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint32(5), provider.manager.NodePools[0].MaxNodes)
+		assert.Equal(t, "b2-7", provider.manager.NodePools[0].Flavor)
+	})
+}
+
+func TestOVHCloudProvider_detectConfigDrift(t *testing.T) {
+	provider := newTestProvider(t)
+
+	cached := sdk.NodePool{ID: "1", Name: "pool-1"}
+	cached.Template.Metadata.Labels = map[string]string{"role": "worker"}
+	provider.manager.NodePools = []sdk.NodePool{cached}
+
+	t.Run("check labels managed by the autoscaler are not drift", func(t *testing.T) {
+		actual := cached
+		actual.Template.Metadata.Labels = map[string]string{
+			"role":                      "worker",
+			"vke.autoscaler/spot":       "true",
+			sdk.AutoscalingEnabledLabel: "true",
+		}
+
+		assert.Equal(t, 0, provider.detectConfigDrift([]sdk.NodePool{actual}))
+	})
+
+	t.Run("check other labels are drift", func(t *testing.T) {
+		actual := cached
+		actual.Template.Metadata.Labels = map[string]string{"role": "system", "vke.autoscaler/spot": "true"}
+
+		assert.Equal(t, 1, provider.detectConfigDrift([]sdk.NodePool{actual}))
+	})
 }
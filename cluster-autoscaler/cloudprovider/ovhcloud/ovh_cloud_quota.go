@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// QuotaReservation defines the amount of project quota the autoscaler must leave
+// available for other workloads.
+type QuotaReservation struct {
+	ReservedCPUs     int `json:"reserved_cpus"`
+	ReservedMemoryGB int `json:"reserved_memory_gb"`
+	ReservedNodes    int `json:"reserved_nodes"`
+}
+
+// EffectiveQuota is the quota available to the autoscaler, reservation excluded.
+type EffectiveQuota struct {
+	AvailableCPUs     int
+	AvailableMemoryGB int
+	AvailableNodes    int
+}
+
+// ScaleUpFeasibility tells whether a scale-up fits in the effective quota.
+type ScaleUpFeasibility struct {
+	Feasible bool
+	Reason   string
+}
+
+// WithQuotaReservation reserves part of the project quota, making scale-ups exceeding the remaining quota unfeasible.
+func WithQuotaReservation(r *QuotaReservation) CloudProviderOption {
+	return func(provider *OVHCloudProvider) {
+		provider.manager.QuotaReservation = r
+	}
+}
+
+// GetEffectiveQuota returns the quota available to the autoscaler in the cluster region.
+func (provider *OVHCloudProvider) GetEffectiveQuota(ctx context.Context) (*EffectiveQuota, error) {
+	return provider.manager.GetEffectiveQuota(ctx)
+}
+
+// CheckScaleUpFeasibility checks whether adding delta nodes of the given flavor fits in the effective quota.
+func (provider *OVHCloudProvider) CheckScaleUpFeasibility(ctx context.Context, flavorName string, delta int) (*ScaleUpFeasibility, error) {
+	return provider.manager.CheckScaleUpFeasibility(ctx, flavorName, delta)
+}
+
+// GetEffectiveQuota returns the quota available in the cluster region minus the reserved amounts.
+func (m *OvhCloudManager) GetEffectiveQuota(ctx context.Context) (*EffectiveQuota, error) {
+	cluster, err := m.Client.GetCluster(ctx, m.ProjectID, m.ClusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	quotas, err := m.Client.ListQuotas(ctx, m.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project quotas: %w", err)
+	}
+
+	for _, quota := range quotas {
+		if quota.Region != cluster.Region || quota.Instance == nil {
+			continue
+		}
+
+		effective := &EffectiveQuota{
+			AvailableCPUs:     quota.Instance.MaxCores - quota.Instance.UsedCores,
+			AvailableMemoryGB: (quota.Instance.MaxRAM - quota.Instance.UsedRAM) / 1024,
+			AvailableNodes:    quota.Instance.MaxInstances - quota.Instance.UsedInstances,
+		}
+
+		if m.QuotaReservation != nil {
+			effective.AvailableCPUs -= m.QuotaReservation.ReservedCPUs
+			effective.AvailableMemoryGB -= m.QuotaReservation.ReservedMemoryGB
+			effective.AvailableNodes -= m.QuotaReservation.ReservedNodes
+		}
+
+		return effective, nil
+	}
+
+	return nil, fmt.Errorf("no instance quota found for region %s", cluster.Region)
+}
+
+// CheckScaleUpFeasibility checks whether adding delta nodes of the given flavor fits in the effective quota.
+func (m *OvhCloudManager) CheckScaleUpFeasibility(ctx context.Context, flavorName string, delta int) (*ScaleUpFeasibility, error) {
+	flavor, err := m.getFlavorByName(flavorName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flavor %s: %w", flavorName, err)
+	}
+
+	quota, err := m.GetEffectiveQuota(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case delta > quota.AvailableNodes:
+		return &ScaleUpFeasibility{Reason: fmt.Sprintf("requested %d node(s), %d available", delta, quota.AvailableNodes)}, nil
+	case delta*flavor.VCPUs > quota.AvailableCPUs:
+		return &ScaleUpFeasibility{Reason: fmt.Sprintf("requested %d CPU(s), %d available", delta*flavor.VCPUs, quota.AvailableCPUs)}, nil
+	case delta*flavor.RAM > quota.AvailableMemoryGB:
+		return &ScaleUpFeasibility{Reason: fmt.Sprintf("requested %d GB of memory, %d available", delta*flavor.RAM, quota.AvailableMemoryGB)}, nil
+	}
+
+	return &ScaleUpFeasibility{Feasible: true}, nil
+}
+
+// ComputeMaxScaleUpFromQuota returns the maximum number of nodes of the given flavor a node pool
+// with currentNodes nodes can be scaled up to without exceeding the quota.
+func ComputeMaxScaleUpFromQuota(quota *EffectiveQuota, flavor *sdk.Flavor, currentNodes int) int {
+	maxNodes, _ := computeMaxScaleUpFromQuota(quota, flavor, currentNodes)
+
+	return maxNodes
+}
+
+// computeMaxScaleUpFromQuota also returns the quota resource limiting the scale up.
+func computeMaxScaleUpFromQuota(quota *EffectiveQuota, flavor *sdk.Flavor, currentNodes int) (int, string) {
+	additional, resource := quota.AvailableNodes, "nodes"
+
+	if flavor.VCPUs > 0 && quota.AvailableCPUs/flavor.VCPUs < additional {
+		additional, resource = quota.AvailableCPUs/flavor.VCPUs, "cpus"
+	}
+
+	if flavor.RAM > 0 && quota.AvailableMemoryGB/flavor.RAM < additional {
+		additional, resource = quota.AvailableMemoryGB/flavor.RAM, "memory"
+	}
+
+	if additional < 0 {
+		additional = 0
+	}
+
+	return currentNodes + additional, resource
+}
+
+// QuotaAwareScaler updates node pools, capping scale-ups to what the project quota allows
+// instead of failing. Capped requests are kept so they can be retried once the quota improves.
+type QuotaAwareScaler struct {
+	Manager *OvhCloudManager
+
+	requestedDesiredNodes map[string]uint32
+	mutex                 sync.Mutex
+}
+
+// NewQuotaAwareScaler creates a scaler calling the API through the given manager.
+func NewQuotaAwareScaler(manager *OvhCloudManager) *QuotaAwareScaler {
+	return &QuotaAwareScaler{
+		Manager:               manager,
+		requestedDesiredNodes: make(map[string]uint32),
+	}
+}
+
+// UpdateNodePool updates the node pool, capping the desired nodes of a scale-up to the quota-allowed maximum.
+func (s *QuotaAwareScaler) UpdateNodePool(ctx context.Context, pool *sdk.NodePool, opts *sdk.UpdateNodePoolOpts) (*sdk.NodePool, error) {
+	if opts.DesiredNodes == nil || *opts.DesiredNodes <= pool.DesiredNodes {
+		s.forgetRequest(pool.ID)
+
+		return s.Manager.Client.UpdateNodePool(ctx, s.Manager.ProjectID, s.Manager.ClusterID, pool.ID, opts)
+	}
+
+	requested := *opts.DesiredNodes
+
+	flavor, err := s.Manager.getFlavorByName(pool.Flavor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flavor %s: %w", pool.Flavor, err)
+	}
+
+	quota, err := s.Manager.GetEffectiveQuota(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maxNodes, resource := computeMaxScaleUpFromQuota(quota, &flavor, int(pool.DesiredNodes))
+	if int(requested) > maxNodes {
+		klog.Warningf("Capping scale up of node pool %s to %d desired nodes instead of %d, limited by %s quota", pool.Name, maxNodes, requested, resource)
+
+		s.mutex.Lock()
+		s.requestedDesiredNodes[pool.ID] = requested
+		s.mutex.Unlock()
+
+		capped := *opts
+		desired := uint32(maxNodes)
+		capped.DesiredNodes = &desired
+		opts = &capped
+	} else {
+		s.forgetRequest(pool.ID)
+	}
+
+	return s.Manager.Client.UpdateNodePool(ctx, s.Manager.ProjectID, s.Manager.ClusterID, pool.ID, opts)
+}
+
+// RequestedDesiredNodes returns the desired nodes of the last capped scale-up of a node pool, if any.
+func (s *QuotaAwareScaler) RequestedDesiredNodes(poolID string) (uint32, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requested, ok := s.requestedDesiredNodes[poolID]
+
+	return requested, ok
+}
+
+// RetryCappedScaleUp tries again the last capped scale-up of a node pool, scaling further if the quota improved.
+func (s *QuotaAwareScaler) RetryCappedScaleUp(ctx context.Context, pool *sdk.NodePool) (*sdk.NodePool, error) {
+	requested, ok := s.RequestedDesiredNodes(pool.ID)
+	if !ok {
+		return pool, nil
+	}
+
+	return s.UpdateNodePool(ctx, pool, &sdk.UpdateNodePoolOpts{DesiredNodes: &requested})
+}
+
+func (s *QuotaAwareScaler) forgetRequest(poolID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.requestedDesiredNodes, poolID)
+}
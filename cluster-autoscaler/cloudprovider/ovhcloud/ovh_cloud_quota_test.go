@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+func (ng *NodeGroup) mockCallQuotas() {
+	client := ng.Manager.Client.(*sdk.ClientMock)
+	ctx := context.Background()
+
+	client.On("GetCluster", ctx, "projectID", "clusterID").Return(&sdk.Cluster{ID: "clusterID", Region: "GRA7"}, nil)
+	client.On("ListQuotas", ctx, "projectID").Return(
+		[]sdk.Quota{
+			{
+				Region: "BHS5",
+				Instance: &sdk.InstanceQuota{
+					MaxCores: 100, MaxInstances: 100, MaxRAM: 100 * 1024,
+				},
+			},
+			{
+				Region: "GRA7",
+				Instance: &sdk.InstanceQuota{
+					MaxCores: 20, MaxInstances: 10, MaxRAM: 100 * 1024,
+					UsedCores: 14, UsedInstances: 5, UsedRAM: 50 * 1024,
+				},
+			},
+		}, nil,
+	)
+}
+
+func TestOvhCloudManager_GetEffectiveQuota(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	ng.mockCallQuotas()
+
+	t.Run("check quota without reservation", func(t *testing.T) {
+		quota, err := ng.Manager.GetEffectiveQuota(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &EffectiveQuota{AvailableCPUs: 6, AvailableMemoryGB: 50, AvailableNodes: 5}, quota)
+	})
+
+	t.Run("check reserved amounts are subtracted", func(t *testing.T) {
+		ng.Manager.QuotaReservation = &QuotaReservation{ReservedCPUs: 2, ReservedMemoryGB: 10, ReservedNodes: 1}
+
+		quota, err := ng.Manager.GetEffectiveQuota(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &EffectiveQuota{AvailableCPUs: 4, AvailableMemoryGB: 40, AvailableNodes: 4}, quota)
+	})
+}
+
+func TestOvhCloudManager_CheckScaleUpFeasibility(t *testing.T) {
+	t.Run("check scale up fits in quota", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.mockCallQuotas()
+
+		feasibility, err := ng.Manager.CheckScaleUpFeasibility(context.Background(), "b2-7", 2)
+		assert.NoError(t, err)
+		assert.True(t, feasibility.Feasible)
+	})
+
+	t.Run("check tight quota with reservation is not feasible", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.mockCallQuotas()
+		ng.Manager.QuotaReservation = &QuotaReservation{ReservedCPUs: 4}
+
+		feasibility, err := ng.Manager.CheckScaleUpFeasibility(context.Background(), "b2-7", 2)
+		assert.NoError(t, err)
+		assert.False(t, feasibility.Feasible)
+		assert.NotEmpty(t, feasibility.Reason)
+	})
+}
+
+func TestOVHCloudNodeGroup_IncreaseSizeWithQuotaReservation(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	ng.mockCallQuotas()
+
+	provider := NewOVHCloudProvider(ng.Manager, config.AutoscalingOptions{}, cloudprovider.NodeGroupDiscoveryOptions{}, nil, WithQuotaReservation(&QuotaReservation{ReservedNodes: 4}))
+	assert.NotNil(t, provider.manager.QuotaReservation)
+
+	err := ng.IncreaseSize(2)
+	assert.Error(t, err)
+	assert.Equal(t, 3, ng.CurrentSize)
+}
+
+func TestComputeMaxScaleUpFromQuota(t *testing.T) {
+	quota := &EffectiveQuota{AvailableCPUs: 6, AvailableMemoryGB: 50, AvailableNodes: 5}
+
+	t.Run("check cpu limited scale up", func(t *testing.T) {
+		assert.Equal(t, 6, ComputeMaxScaleUpFromQuota(quota, &sdk.Flavor{VCPUs: 2, RAM: 7}, 3))
+	})
+
+	t.Run("check memory limited scale up", func(t *testing.T) {
+		assert.Equal(t, 4, ComputeMaxScaleUpFromQuota(quota, &sdk.Flavor{VCPUs: 1, RAM: 45}, 3))
+	})
+
+	t.Run("check nodes limited scale up", func(t *testing.T) {
+		assert.Equal(t, 8, ComputeMaxScaleUpFromQuota(quota, &sdk.Flavor{VCPUs: 1, RAM: 1}, 3))
+	})
+
+	t.Run("check exhausted quota", func(t *testing.T) {
+		assert.Equal(t, 3, ComputeMaxScaleUpFromQuota(&EffectiveQuota{AvailableCPUs: -2}, &sdk.Flavor{VCPUs: 2, RAM: 7}, 3))
+	})
+}
+
+func TestQuotaAwareScaler_UpdateNodePool(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	ng.mockCallQuotas()
+	scaler := NewQuotaAwareScaler(ng.Manager)
+
+	t.Run("check scale up is capped by a tight quota", func(t *testing.T) {
+		ng.mockCallUpdateNodePool(6, nil)
+
+		requested := uint32(8)
+		_, err := scaler.UpdateNodePool(context.Background(), &ng.NodePool, &sdk.UpdateNodePoolOpts{DesiredNodes: &requested})
+		assert.NoError(t, err)
+
+		ng.Manager.Client.(*sdk.ClientMock).AssertCalled(t, "UpdateNodePool", context.Background(), "projectID", "clusterID", "id", &sdk.UpdateNodePoolOpts{DesiredNodes: ptrUint32(6)})
+
+		stored, ok := scaler.RequestedDesiredNodes(ng.ID)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(8), stored)
+	})
+
+	t.Run("check scale up within quota forgets the capped request", func(t *testing.T) {
+		ng.mockCallUpdateNodePool(5, nil)
+
+		requested := uint32(5)
+		_, err := scaler.UpdateNodePool(context.Background(), &ng.NodePool, &sdk.UpdateNodePoolOpts{DesiredNodes: &requested})
+		assert.NoError(t, err)
+
+		_, ok := scaler.RequestedDesiredNodes(ng.ID)
+		assert.False(t, ok)
+	})
+}
+
+func ptrUint32(v uint32) *uint32 {
+	return &v
+}
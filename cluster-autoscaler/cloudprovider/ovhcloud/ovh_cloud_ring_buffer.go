@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"sync"
+	"time"
+)
+
+// scaleHistorySize is the number of scale operations kept in memory per node group.
+const scaleHistorySize = 100
+
+// ScaleOperation records a scale action requested on a node group, for post-mortem debugging.
+type ScaleOperation struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Action    string        `json:"action"`
+	FromSize  int           `json:"fromSize"`
+	ToSize    int           `json:"toSize"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// newScaleOperation forges a scale operation which started at the given time
+func newScaleOperation(action string, fromSize int, toSize int, start time.Time, err error) ScaleOperation {
+	op := ScaleOperation{
+		Timestamp: start,
+		Action:    action,
+		FromSize:  fromSize,
+		ToSize:    toSize,
+		Duration:  time.Since(start),
+	}
+
+	if err != nil {
+		op.Error = err.Error()
+	}
+
+	return op
+}
+
+// ScaleOperationRingBuffer keeps the last scale operations of a node group.
+// It is safe for concurrent use.
+type ScaleOperationRingBuffer struct {
+	mutex      sync.RWMutex
+	operations []ScaleOperation
+	next       int
+	full       bool
+}
+
+// NewScaleOperationRingBuffer creates a ring buffer holding at most size operations.
+func NewScaleOperationRingBuffer(size int) *ScaleOperationRingBuffer {
+	return &ScaleOperationRingBuffer{
+		operations: make([]ScaleOperation, size),
+	}
+}
+
+// Add stores an operation, overwriting the oldest one when the buffer is full.
+func (b *ScaleOperationRingBuffer) Add(op ScaleOperation) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.operations) == 0 {
+		return
+	}
+
+	b.operations[b.next] = op
+	b.next = (b.next + 1) % len(b.operations)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Last returns the n most recent operations, from the oldest to the newest.
+func (b *ScaleOperationRingBuffer) Last(n int) []ScaleOperation {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.operations)
+	}
+	if n > count {
+		n = count
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	last := make([]ScaleOperation, 0, n)
+	for i := n; i > 0; i-- {
+		index := (b.next - i + len(b.operations)) % len(b.operations)
+		last = append(last, b.operations[index])
+	}
+
+	return last
+}
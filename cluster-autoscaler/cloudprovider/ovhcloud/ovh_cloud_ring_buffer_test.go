@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apiserver/pkg/server/mux"
+)
+
+func TestScaleOperationRingBuffer(t *testing.T) {
+	t.Run("check operations are returned from oldest to newest", func(t *testing.T) {
+		buffer := NewScaleOperationRingBuffer(5)
+		for i := 0; i < 3; i++ {
+			buffer.Add(ScaleOperation{ToSize: i})
+		}
+
+		assert.Equal(t, []ScaleOperation{{ToSize: 1}, {ToSize: 2}}, buffer.Last(2))
+		assert.Equal(t, []ScaleOperation{{ToSize: 0}, {ToSize: 1}, {ToSize: 2}}, buffer.Last(10))
+	})
+
+	t.Run("check buffer wraps and keeps the most recent operations", func(t *testing.T) {
+		buffer := NewScaleOperationRingBuffer(3)
+		for i := 0; i < 7; i++ {
+			buffer.Add(ScaleOperation{ToSize: i})
+		}
+
+		assert.Equal(t, []ScaleOperation{{ToSize: 4}, {ToSize: 5}, {ToSize: 6}}, buffer.Last(3))
+		assert.Equal(t, []ScaleOperation{{ToSize: 6}}, buffer.Last(1))
+		assert.Empty(t, buffer.Last(0))
+	})
+
+	t.Run("check concurrent adds are all recorded", func(t *testing.T) {
+		buffer := NewScaleOperationRingBuffer(scaleHistorySize)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				buffer.Add(ScaleOperation{ToSize: i})
+				_ = buffer.Last(10)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Len(t, buffer.Last(scaleHistorySize), 50)
+	})
+}
+
+func TestOVHCloudProvider_ScaleHistoryHandler(t *testing.T) {
+	provider := newTestProvider(t)
+	for i := 0; i < 15; i++ {
+		provider.manager.getScaleHistory("pool-1").Add(ScaleOperation{Action: ScaleUpDirection, FromSize: i, ToSize: i + 1})
+	}
+
+	t.Run("check history is returned as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.ScaleHistoryHandler(recorder, httptest.NewRequest("GET", ScaleHistoryDebugPath+"?pool=pool-1&n=2", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		operations := make([]ScaleOperation, 0)
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &operations))
+		assert.Equal(t, []ScaleOperation{
+			{Action: ScaleUpDirection, FromSize: 13, ToSize: 14},
+			{Action: ScaleUpDirection, FromSize: 14, ToSize: 15},
+		}, operations)
+	})
+
+	t.Run("check default number of operations", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.ScaleHistoryHandler(recorder, httptest.NewRequest("GET", ScaleHistoryDebugPath+"?pool=pool-1", nil))
+
+		operations := make([]ScaleOperation, 0)
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &operations))
+		assert.Len(t, operations, defaultScaleHistoryCount)
+	})
+
+	t.Run("check missing pool is rejected", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		provider.ScaleHistoryHandler(recorder, httptest.NewRequest("GET", ScaleHistoryDebugPath, nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("check history is served by the autoscaler multiplexer", func(t *testing.T) {
+		pathRecorderMux := mux.NewPathRecorderMux("test")
+		provider.RegisterDebugHandlers(pathRecorderMux)
+
+		recorder := httptest.NewRecorder()
+		pathRecorderMux.ServeHTTP(recorder, httptest.NewRequest("GET", ScaleHistoryDebugPath+"?pool=pool-1&n=1", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		operations := make([]ScaleOperation, 0)
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &operations))
+		assert.Len(t, operations, 1)
+	})
+}
+
+func TestOVHCloudNodeGroup_RecordScaleHistory(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	ng.mockCallUpdateNodePool(4, nil)
+
+	err := ng.IncreaseSize(1)
+	assert.NoError(t, err)
+
+	operations := ng.Manager.lastScaleOperations(ng.Id(), 10)
+	assert.Len(t, operations, 1)
+	assert.Equal(t, ScaleUpDirection, operations[0].Action)
+	assert.Equal(t, 3, operations[0].FromSize)
+	assert.Equal(t, 4, operations[0].ToSize)
+	assert.Empty(t, operations[0].Error)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+)
+
+// PendingPodsLister lists the pods waiting to be scheduled.
+type PendingPodsLister func() ([]*apiv1.Pod, error)
+
+// WithPendingPodsLister makes scale-ups warn when none of the pending pods would fit on a new node.
+func WithPendingPodsLister(lister PendingPodsLister) CloudProviderOption {
+	return func(provider *OVHCloudProvider) {
+		provider.manager.PendingPodsLister = lister
+	}
+}
+
+// NewKubePendingPodsLister lists the pods the scheduler failed to place, from the Kubernetes API.
+func NewKubePendingPodsLister(kubeClient kubernetes.Interface) PendingPodsLister {
+	return func() ([]*apiv1.Pod, error) {
+		// Only the pods not bound to a node are listed, the scheduled ones being the most numerous
+		pods, err := kubeClient.CoreV1().Pods(apiv1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+			FieldSelector: "spec.nodeName=",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		unassigned := make([]*apiv1.Pod, 0, len(pods.Items))
+		for i := range pods.Items {
+			unassigned = append(unassigned, &pods.Items[i])
+		}
+
+		return kube_util.UnschedulablePods(unassigned), nil
+	}
+}
+
+// SimulatePodFit splits the pods between the ones which would fit on a new node built from the template,
+// and the ones which would not. Node selectors, required node affinities, taints and resources are checked.
+func SimulatePodFit(templateNode *schedulerframework.NodeInfo, pods []*apiv1.Pod) (schedulable, unschedulable []*apiv1.Pod, err error) {
+	node := templateNode.Node()
+	if node == nil {
+		return nil, nil, fmt.Errorf("template node info has no node")
+	}
+
+	for _, pod := range pods {
+		fits, err := nodeaffinity.GetRequiredNodeAffinity(pod).Match(node)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to match node affinity of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+
+		if fits && toleratesTaints(node, pod) && fitsResources(templateNode, pod) {
+			schedulable = append(schedulable, pod)
+		} else {
+			unschedulable = append(unschedulable, pod)
+		}
+	}
+
+	return schedulable, unschedulable, nil
+}
+
+// toleratesTaints checks that the pod tolerates the taints of the node keeping pods away
+func toleratesTaints(node *apiv1.Node, pod *apiv1.Pod) bool {
+	_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, pod.Spec.Tolerations, func(taint *apiv1.Taint) bool {
+		return taint.Effect == apiv1.TaintEffectNoSchedule || taint.Effect == apiv1.TaintEffectNoExecute
+	})
+
+	return !untolerated
+}
+
+// fitsResources checks that the pod requests fit in the remaining allocatable resources of the node
+func fitsResources(nodeInfo *schedulerframework.NodeInfo, pod *apiv1.Pod) bool {
+	if len(nodeInfo.Pods)+1 > nodeInfo.Allocatable.AllowedPodNumber {
+		return false
+	}
+
+	for name, quantity := range podRequests(pod) {
+		switch name {
+		case apiv1.ResourceCPU:
+			if quantity.MilliValue() > nodeInfo.Allocatable.MilliCPU-nodeInfo.Requested.MilliCPU {
+				return false
+			}
+		case apiv1.ResourceMemory:
+			if quantity.Value() > nodeInfo.Allocatable.Memory-nodeInfo.Requested.Memory {
+				return false
+			}
+		case apiv1.ResourceEphemeralStorage:
+			if quantity.Value() > nodeInfo.Allocatable.EphemeralStorage-nodeInfo.Requested.EphemeralStorage {
+				return false
+			}
+		default:
+			if quantity.Value() > nodeInfo.Allocatable.ScalarResources[name]-nodeInfo.Requested.ScalarResources[name] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// podRequests sums the requests of the pod containers, using their limits when requests are not set.
+// Init containers run sequentially, so only the largest of their requests is kept.
+func podRequests(pod *apiv1.Pod) apiv1.ResourceList {
+	requests := apiv1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range containerRequests(container) {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range containerRequests(container) {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+
+	return requests
+}
+
+func containerRequests(container apiv1.Container) apiv1.ResourceList {
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = apiv1.ResourceList{}
+	}
+
+	for name, quantity := range container.Resources.Limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = quantity.DeepCopy()
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func newTestTemplateNode(cpu string) *schedulerframework.NodeInfo {
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "template",
+			Labels: map[string]string{"disk": "ssd"},
+		},
+		Status: apiv1.NodeStatus{
+			Allocatable: apiv1.ResourceList{
+				apiv1.ResourcePods:   resource.MustParse("110"),
+				apiv1.ResourceCPU:    resource.MustParse(cpu),
+				apiv1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+
+	nodeInfo := schedulerframework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+
+	return nodeInfo
+}
+
+func newTestPendingPod(name string, requests apiv1.ResourceList, limits apiv1.ResourceList) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Name: "main",
+					Resources: apiv1.ResourceRequirements{
+						Requests: requests,
+						Limits:   limits,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewKubePendingPodsLister(t *testing.T) {
+	unschedulable := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		Status: apiv1.PodStatus{
+			Phase: apiv1.PodPending,
+			Conditions: []apiv1.PodCondition{
+				{Type: apiv1.PodScheduled, Status: apiv1.ConditionFalse, Reason: apiv1.PodReasonUnschedulable},
+			},
+		},
+	}
+	scheduled := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+		Spec:       apiv1.PodSpec{NodeName: "node-1"},
+		Status: apiv1.PodStatus{
+			Phase:      apiv1.PodRunning,
+			Conditions: []apiv1.PodCondition{{Type: apiv1.PodScheduled, Status: apiv1.ConditionTrue}},
+		},
+	}
+
+	lister := NewKubePendingPodsLister(fake.NewSimpleClientset(unschedulable, scheduled))
+
+	pods, err := lister()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+	assert.Equal(t, "pending", pods[0].Name)
+}
+
+func TestSimulatePodFit(t *testing.T) {
+	template := newTestTemplateNode("4")
+
+	small := newTestPendingPod("small", apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("2")}, nil)
+	large := newTestPendingPod("large", apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("8")}, nil)
+	limited := newTestPendingPod("limited", nil, apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("16Gi")})
+	gpu := newTestPendingPod("gpu", apiv1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}, nil)
+
+	selected := newTestPendingPod("selected", nil, nil)
+	selected.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+
+	unselected := newTestPendingPod("unselected", nil, nil)
+	unselected.Spec.NodeSelector = map[string]string{"disk": "hdd"}
+
+	affinity := newTestPendingPod("affinity", nil, nil)
+	affinity.Spec.Affinity = &apiv1.Affinity{
+		NodeAffinity: &apiv1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+				NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+					{
+						MatchExpressions: []apiv1.NodeSelectorRequirement{
+							{Key: "disk", Operator: apiv1.NodeSelectorOpIn, Values: []string{"nvme"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	schedulable, unschedulable, err := SimulatePodFit(template, []*apiv1.Pod{small, large, limited, gpu, selected, unselected, affinity})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*apiv1.Pod{small, selected}, schedulable)
+	assert.Equal(t, []*apiv1.Pod{large, limited, gpu, unselected, affinity}, unschedulable)
+
+	t.Run("check taints are tolerated", func(t *testing.T) {
+		tainted := newTestTemplateNode("4")
+		tainted.Node().Spec.Taints = []apiv1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: apiv1.TaintEffectNoSchedule},
+			{Key: "preferred", Value: "true", Effect: apiv1.TaintEffectPreferNoSchedule},
+		}
+
+		tolerating := newTestPendingPod("tolerating", nil, nil)
+		tolerating.Spec.Tolerations = []apiv1.Toleration{{Key: "dedicated", Operator: apiv1.TolerationOpEqual, Value: "gpu", Effect: apiv1.TaintEffectNoSchedule}}
+
+		schedulable, unschedulable, err := SimulatePodFit(tainted, []*apiv1.Pod{small, tolerating})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []*apiv1.Pod{tolerating}, schedulable)
+		assert.Equal(t, []*apiv1.Pod{small}, unschedulable)
+	})
+}
+
+func TestOVHCloudNodeGroup_IncreaseSizeWithPendingPods(t *testing.T) {
+	t.Run("check scale up is not rejected when no pending pod fits", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.mockCallUpdateNodePool(4, nil)
+		ng.Manager.PendingPodsLister = func() ([]*apiv1.Pod, error) {
+			return []*apiv1.Pod{newTestPendingPod("large", apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("8")}, nil)}, nil
+		}
+
+		assert.NoError(t, ng.IncreaseSize(1))
+		assert.Equal(t, 4, ng.CurrentSize)
+	})
+
+	t.Run("check scale up is allowed when a pending pod fits", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.mockCallUpdateNodePool(4, nil)
+		ng.Manager.PendingPodsLister = func() ([]*apiv1.Pod, error) {
+			return []*apiv1.Pod{newTestPendingPod("small", apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("1")}, nil)}, nil
+		}
+
+		assert.NoError(t, ng.IncreaseSize(1))
+	})
+
+	t.Run("check pending pods listing failure does not prevent scale up", func(t *testing.T) {
+		ng := newTestNodeGroup(t, "b2-7")
+		ng.mockCallUpdateNodePool(4, nil)
+		ng.Manager.PendingPodsLister = func() ([]*apiv1.Pod, error) {
+			return nil, fmt.Errorf("API server is down")
+		}
+
+		assert.NoError(t, ng.IncreaseSize(1))
+	})
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+const (
+	// PendingDeletionTaint is the name of the taint keeping new pods away from the nodes waiting for a deletion review
+	PendingDeletionTaint = "pending-deletion"
+
+	// DeletionRequestedAtAnnotation is the name of the annotation holding the time a node deletion was requested
+	DeletionRequestedAtAnnotation = "deletion-requested-at"
+
+	// DeletionConfirmedAtAnnotation is the name of the annotation holding the time a node deletion was confirmed
+	DeletionConfirmedAtAnnotation = "deletion-confirmed-at"
+)
+
+// WithSoftDeleteMode makes scale-downs only cordon and mark the nodes, which are deleted once confirmed
+// with ConfirmNodeDeletion, e.g. after a manual review.
+func WithSoftDeleteMode(k8sClient kubernetes.Interface) CloudProviderOption {
+	return func(provider *OVHCloudProvider) {
+		provider.manager.SoftDeleteMode = true
+		provider.manager.KubeClient = k8sClient
+	}
+}
+
+// SoftDeleteNode cordons a node, taints it and records the time its deletion was requested, leaving the node in its
+// pool until the deletion is confirmed. It returns whether the node was left out of the node pool target size, which
+// is not the case for the nodes already pending deletion.
+func (m *OvhCloudManager) SoftDeleteNode(ctx context.Context, clusterID string, poolID string, nodeName string, k8sClient kubernetes.Interface) (bool, error) {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	// Nodes marked before a restart are only tracked again
+	taintKey := m.ProviderConfig.AnnotationKey(PendingDeletionTaint)
+	if sdk.HasTaint(node, taintKey) {
+		if _, confirmed := node.Annotations[m.ProviderConfig.AnnotationKey(DeletionConfirmedAtAnnotation)]; confirmed {
+			return false, nil
+		}
+
+		return m.addPendingDeletion(poolID, nodeName), nil
+	}
+
+	taints := append(append([]apiv1.Taint(nil), node.Spec.Taints...), apiv1.Taint{
+		Key:    taintKey,
+		Value:  "true",
+		Effect: apiv1.TaintEffectNoSchedule,
+	})
+
+	// The node pool annotations let the nodes pending deletion be tracked again after a restart
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				m.ProviderConfig.AnnotationKey(DeletionRequestedAtAnnotation): time.Now().UTC().Format(time.RFC3339),
+				sdk.NodeGroupIDAnnotation:                                     poolID,
+				sdk.ClusterIDAnnotation:                                       clusterID,
+			},
+		},
+		"spec": map[string]interface{}{
+			"unschedulable": true,
+			"taints":        taints,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal node patch: %w", err)
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark node %s for deletion: %w", nodeName, err)
+	}
+
+	klog.Infof("Node %s of node pool %s is pending deletion review", nodeName, poolID)
+
+	return m.addPendingDeletion(poolID, nodeName), nil
+}
+
+// ConfirmNodeDeletion removes a node pending deletion from its pool, decreasing the pool desired nodes.
+// The target size of the node pool is left as is, the node being already left out of it.
+func (m *OvhCloudManager) ConfirmNodeDeletion(ctx context.Context, clusterID string, poolID string, nodeName string, k8sClient kubernetes.Interface) error {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	if !sdk.HasTaint(node, m.ProviderConfig.AnnotationKey(PendingDeletionTaint)) {
+		return fmt.Errorf("node %s is not pending deletion", nodeName)
+	}
+
+	confirmedKey := m.ProviderConfig.AnnotationKey(DeletionConfirmedAtAnnotation)
+	if _, confirmed := node.Annotations[confirmedKey]; confirmed {
+		return fmt.Errorf("deletion of node %s is already confirmed", nodeName)
+	}
+
+	pool, err := m.Client.GetNodePool(ctx, m.ProjectID, clusterID, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	// The node pool may be cached, the size set by a previous resize prevails over its desired nodes
+	err = m.PoolOperations.SubmitResize(ctx, poolID, m.nodePoolTargetSize(pool), func(ctx context.Context, size int) (int, error) {
+		// A node which is not tracked yet is still counted in the target size
+		target := size
+		if !m.isPendingDeletion(poolID, nodeName) {
+			target--
+		}
+
+		if target < int(pool.MinNodes) {
+			return size, fmt.Errorf("node pool %s size would be below minimum size - desired: %d, min: %d", poolID, target, pool.MinNodes)
+		}
+
+		desired := m.nodePoolDesiredNodes(poolID, target)
+		if target == size {
+			desired--
+		}
+
+		start := time.Now()
+		_, err := m.Client.UpdateNodePool(ctx, m.ProjectID, clusterID, poolID, &sdk.UpdateNodePoolOpts{
+			DesiredNodes:  &desired,
+			NodesToRemove: []string{node.Spec.ProviderID},
+		})
+		if target != size {
+			m.getScaleHistory(pool.Name).Add(newScaleOperation(ScaleDownDirection, size, target, start, err))
+		}
+		if err != nil {
+			return size, fmt.Errorf("failed to delete node %s from node pool %s: %w", nodeName, poolID, err)
+		}
+
+		m.removePendingDeletion(poolID, nodeName)
+
+		if target != size {
+			m.EventBus.PublishAsync(ScaleEvent{
+				NodeGroupID: pool.Name,
+				Direction:   ScaleDownDirection,
+				OldSize:     size,
+				NewSize:     target,
+				Timestamp:   time.Now(),
+			})
+		}
+
+		return target, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The node is not tracked again while it is being deleted
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				confirmedKey: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err == nil {
+		_, err = k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		klog.Warningf("failed to mark deletion of node %s as confirmed: %v", nodeName, err)
+	}
+
+	return nil
+}
+
+// nodePoolDesiredNodes returns the desired nodes of a node pool of the given target size, its nodes pending deletion included
+func (m *OvhCloudManager) nodePoolDesiredNodes(poolID string, size int) uint32 {
+	return uint32(size + m.pendingDeletions(poolID))
+}
+
+// pendingDeletions returns the number of nodes of a node pool waiting for their deletion to be confirmed
+func (m *OvhCloudManager) pendingDeletions(poolID string) int {
+	m.PendingDeletionsPerNodePoolLock.Lock()
+	defer m.PendingDeletionsPerNodePoolLock.Unlock()
+
+	return len(m.PendingDeletionsPerNodePool[poolID])
+}
+
+// isPendingDeletion tells whether a node of a node pool is tracked as waiting for its deletion to be confirmed
+func (m *OvhCloudManager) isPendingDeletion(poolID string, nodeName string) bool {
+	m.PendingDeletionsPerNodePoolLock.Lock()
+	defer m.PendingDeletionsPerNodePoolLock.Unlock()
+
+	_, ok := m.PendingDeletionsPerNodePool[poolID][nodeName]
+	return ok
+}
+
+// addPendingDeletion tracks a node of a node pool as waiting for its deletion to be confirmed,
+// returning false when it was already tracked
+func (m *OvhCloudManager) addPendingDeletion(poolID string, nodeName string) bool {
+	m.PendingDeletionsPerNodePoolLock.Lock()
+	defer m.PendingDeletionsPerNodePoolLock.Unlock()
+
+	nodes, ok := m.PendingDeletionsPerNodePool[poolID]
+	if !ok {
+		nodes = make(map[string]struct{})
+		m.PendingDeletionsPerNodePool[poolID] = nodes
+	}
+
+	if _, ok := nodes[nodeName]; ok {
+		return false
+	}
+	nodes[nodeName] = struct{}{}
+
+	return true
+}
+
+// removePendingDeletion stops tracking a node of a node pool whose deletion is confirmed
+func (m *OvhCloudManager) removePendingDeletion(poolID string, nodeName string) {
+	m.PendingDeletionsPerNodePoolLock.Lock()
+	defer m.PendingDeletionsPerNodePoolLock.Unlock()
+
+	delete(m.PendingDeletionsPerNodePool[poolID], nodeName)
+}
+
+// syncPendingDeletions tracks again the nodes pending deletion from their taint, for the nodes marked before a restart
+// to be left out of the target sizes, and the nodes gone since to be forgotten. Nothing is tracked outside of
+// soft delete mode.
+func (m *OvhCloudManager) syncPendingDeletions(ctx context.Context) {
+	if !m.SoftDeleteMode || m.KubeClient == nil {
+		return
+	}
+
+	nodes, err := m.KubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Warningf("failed to list nodes pending deletion: %v", err)
+		return
+	}
+
+	taintKey := m.ProviderConfig.AnnotationKey(PendingDeletionTaint)
+	confirmedKey := m.ProviderConfig.AnnotationKey(DeletionConfirmedAtAnnotation)
+
+	pending := make(map[string]map[string]struct{})
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !sdk.HasTaint(node, taintKey) || node.Annotations[sdk.ClusterIDAnnotation] != m.ClusterID {
+			continue
+		}
+
+		poolID := node.Annotations[sdk.NodeGroupIDAnnotation]
+		if _, confirmed := node.Annotations[confirmedKey]; confirmed || poolID == "" {
+			continue
+		}
+
+		if _, ok := pending[poolID]; !ok {
+			pending[poolID] = make(map[string]struct{})
+		}
+		pending[poolID][node.Name] = struct{}{}
+	}
+
+	m.PendingDeletionsPerNodePoolLock.Lock()
+	defer m.PendingDeletionsPerNodePoolLock.Unlock()
+
+	m.PendingDeletionsPerNodePool = pending
+}
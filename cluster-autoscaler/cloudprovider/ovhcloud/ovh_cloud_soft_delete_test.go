@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func newTestSoftDeleteNode(name string) *apiv1.Node {
+	return &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiv1.NodeSpec{
+			ProviderID: providerIDPrefix + name,
+			Taints:     []apiv1.Taint{{Key: "dedicated", Value: "web", Effect: apiv1.TaintEffectNoSchedule}},
+		},
+	}
+}
+
+func TestOvhCloudManager_SoftDeleteNode(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	manager := ng.Manager
+	client := manager.Client.(*sdk.ClientMock)
+	ctx := context.Background()
+
+	k8sClient := fake.NewSimpleClientset(newTestSoftDeleteNode("node-1"), newTestSoftDeleteNode("node-2"))
+
+	t.Run("check node is cordoned, tainted and annotated", func(t *testing.T) {
+		before := time.Now().UTC().Truncate(time.Second)
+
+		marked, err := manager.SoftDeleteNode(ctx, "clusterID", "id", "node-1", k8sClient)
+		assert.NoError(t, err)
+		assert.True(t, marked)
+		assert.Equal(t, 1, manager.pendingDeletions("id"))
+
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		assert.True(t, node.Spec.Unschedulable)
+		assert.Equal(t, []apiv1.Taint{
+			{Key: "dedicated", Value: "web", Effect: apiv1.TaintEffectNoSchedule},
+			{Key: "vke.autoscaler/pending-deletion", Value: "true", Effect: apiv1.TaintEffectNoSchedule},
+		}, node.Spec.Taints)
+
+		requestedAt, err := time.Parse(time.RFC3339, node.Annotations["vke.autoscaler/deletion-requested-at"])
+		assert.NoError(t, err)
+		assert.False(t, requestedAt.Before(before))
+		assert.Equal(t, "id", node.Annotations[sdk.NodeGroupIDAnnotation])
+		assert.Equal(t, "clusterID", node.Annotations[sdk.ClusterIDAnnotation])
+
+		client.AssertNotCalled(t, "UpdateNodePool", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("check node already pending deletion is left as is", func(t *testing.T) {
+		marked, err := manager.SoftDeleteNode(ctx, "clusterID", "id", "node-1", k8sClient)
+		assert.NoError(t, err)
+		assert.False(t, marked)
+		assert.Equal(t, 1, manager.pendingDeletions("id"))
+
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, node.Spec.Taints, 2)
+	})
+
+	t.Run("check deletion confirmation removes the node", func(t *testing.T) {
+		// The node pending deletion is left out of the target size of 2
+		client.On("GetNodePool", mock.Anything, "projectID", "clusterID", "id").Return(&sdk.NodePool{ID: "id", DesiredNodes: 3, MinNodes: 1}, nil)
+
+		desired := uint32(2)
+		client.On("UpdateNodePool", mock.Anything, "projectID", "clusterID", "id", &sdk.UpdateNodePoolOpts{
+			DesiredNodes:  &desired,
+			NodesToRemove: []string{"openstack:///node-1"},
+		}).Return(&sdk.NodePool{}, nil).Once()
+
+		err := manager.ConfirmNodeDeletion(ctx, "clusterID", "id", "node-1", k8sClient)
+		assert.NoError(t, err)
+		client.AssertNumberOfCalls(t, "UpdateNodePool", 1)
+		assert.Equal(t, 0, manager.pendingDeletions("id"))
+
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Contains(t, node.Annotations, "vke.autoscaler/deletion-confirmed-at")
+
+		// The target size is recorded for the next resizes
+		assert.NoError(t, manager.PoolOperations.SubmitResize(ctx, "id", 3, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 2, size)
+			return size, nil
+		}))
+	})
+
+	t.Run("check deletion already confirmed is refused", func(t *testing.T) {
+		err := manager.ConfirmNodeDeletion(ctx, "clusterID", "id", "node-1", k8sClient)
+		assert.ErrorContains(t, err, "deletion of node node-1 is already confirmed")
+		client.AssertNumberOfCalls(t, "UpdateNodePool", 1)
+	})
+
+	t.Run("check deletion of node not pending deletion is refused", func(t *testing.T) {
+		err := manager.ConfirmNodeDeletion(ctx, "clusterID", "id", "node-2", k8sClient)
+		assert.ErrorContains(t, err, "node node-2 is not pending deletion")
+	})
+}
+
+func TestOVHCloudNodeGroup_DeleteNodesSoftDeleteMode(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	node := newTestSoftDeleteNode("node-1")
+	k8sClient := fake.NewSimpleClientset(node)
+
+	ng.Manager.SoftDeleteMode = true
+	ng.Manager.KubeClient = k8sClient
+
+	err := ng.DeleteNodes([]*apiv1.Node{node})
+	assert.NoError(t, err)
+
+	targetSize, err := ng.TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, targetSize)
+
+	// The node pool desired nodes still include the node pending deletion
+	ng.CurrentSize = -1
+	targetSize, err = ng.TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, targetSize)
+
+	history := ng.Manager.getScaleHistory(ng.Id()).Last(10)
+	assert.Len(t, history, 1)
+	assert.Equal(t, 3, history[0].FromSize)
+	assert.Equal(t, 2, history[0].ToSize)
+
+	node, err = k8sClient.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, sdk.HasTaint(node, "vke.autoscaler/pending-deletion"))
+
+	ng.Manager.Client.(*sdk.ClientMock).AssertNotCalled(t, "UpdateNodePool", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOvhCloudManager_syncPendingDeletions(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+	ng.CurrentSize = -1
+	manager := ng.Manager
+
+	pending := newTestSoftDeleteNode("pending")
+	pending.Annotations = map[string]string{sdk.NodeGroupIDAnnotation: "id", sdk.ClusterIDAnnotation: "clusterID"}
+	pending.Spec.Taints = append(pending.Spec.Taints, apiv1.Taint{Key: "vke.autoscaler/pending-deletion", Value: "true", Effect: apiv1.TaintEffectNoSchedule})
+
+	confirmed := pending.DeepCopy()
+	confirmed.Name = "confirmed"
+	confirmed.Annotations["vke.autoscaler/deletion-confirmed-at"] = time.Now().UTC().Format(time.RFC3339)
+
+	otherCluster := pending.DeepCopy()
+	otherCluster.Name = "other-cluster"
+	otherCluster.Annotations[sdk.ClusterIDAnnotation] = "otherClusterID"
+
+	manager.KubeClient = fake.NewSimpleClientset(pending, confirmed, otherCluster, newTestSoftDeleteNode("running"))
+	manager.addPendingDeletion("id", "gone")
+
+	t.Run("check nothing is tracked outside of soft delete mode", func(t *testing.T) {
+		manager.syncPendingDeletions(context.Background())
+		assert.True(t, manager.isPendingDeletion("id", "gone"))
+	})
+
+	t.Run("check only the nodes pending deletion of the cluster are tracked", func(t *testing.T) {
+		manager.SoftDeleteMode = true
+		manager.syncPendingDeletions(context.Background())
+
+		assert.Equal(t, 1, manager.pendingDeletions("id"))
+		assert.True(t, manager.isPendingDeletion("id", "pending"))
+
+		targetSize, err := ng.TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, targetSize)
+	})
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// SpotAnnotation is the name of the annotation marking a node pool running spot instances when set to "true"
+const SpotAnnotation = "spot"
+
+// SpotInterruptionGracePeriod is the time given to the pods of an interrupted spot node to terminate.
+// Spot nodes are reclaimed 2 minutes after the notice.
+const SpotInterruptionGracePeriod = 90 * time.Second
+
+// SpotInterruptionPollInterval is the interval between two checks of the spot interruption notices
+var SpotInterruptionPollInterval = 30 * time.Second
+
+const spotDrainRetryInterval = 5 * time.Second
+
+// SpotInterruptionHandler drains and removes the spot nodes of a node pool as soon as their interruption is notified.
+type SpotInterruptionHandler struct {
+	manager   *OvhCloudManager
+	k8sClient kubernetes.Interface
+
+	clusterID string
+	poolID    string
+
+	// handled holds the nodes of the pending notices already processed
+	handled map[string]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartSpotInterruptionHandler starts handling the spot interruption notices of a node pool in background,
+// until the context is done or the returned function is called. The handler is stopped on cleanup as well.
+func (m *OvhCloudManager) StartSpotInterruptionHandler(ctx context.Context, clusterID string, poolID string, k8sClient kubernetes.Interface) func() {
+	ctx, cancel := context.WithCancel(ctx)
+
+	h := &SpotInterruptionHandler{
+		manager:   m,
+		k8sClient: k8sClient,
+		clusterID: clusterID,
+		poolID:    poolID,
+		handled:   make(map[string]struct{}),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	m.SpotInterruptionHandlersLock.Lock()
+	m.SpotInterruptionHandlers = append(m.SpotInterruptionHandlers, h)
+	m.SpotInterruptionHandlersLock.Unlock()
+
+	go func() {
+		defer close(h.done)
+		wait.UntilWithContext(ctx, h.poll, SpotInterruptionPollInterval)
+	}()
+
+	return h.Stop
+}
+
+// Stop stops the handler, waiting for the interruption being processed, if any.
+func (h *SpotInterruptionHandler) Stop() {
+	h.cancel()
+	<-h.done
+}
+
+// StopSpotInterruptionHandlers stops all the spot interruption handlers started by the manager
+func (m *OvhCloudManager) StopSpotInterruptionHandlers() {
+	m.SpotInterruptionHandlersLock.Lock()
+	handlers := m.SpotInterruptionHandlers
+	m.SpotInterruptionHandlers = nil
+	m.SpotInterruptionHandlersLock.Unlock()
+
+	for _, h := range handlers {
+		h.Stop()
+	}
+}
+
+// syncSpotInterruptionHandlers runs a spot interruption handler for every node pool annotated as running spot
+// instances, and stops the handlers of the other node pools. Nothing is started without a Kubernetes client.
+func (m *OvhCloudManager) syncSpotInterruptionHandlers(pools []sdk.NodePool) {
+	if m.KubeClient == nil {
+		return
+	}
+
+	spotPools := make(map[string]struct{})
+	for _, pool := range pools {
+		if pool.Template.Metadata.Annotations[m.ProviderConfig.AnnotationKey(SpotAnnotation)] == "true" {
+			spotPools[pool.ID] = struct{}{}
+		}
+	}
+
+	m.SpotInterruptionHandlersLock.Lock()
+	running := make([]*SpotInterruptionHandler, 0, len(m.SpotInterruptionHandlers))
+	for _, h := range m.SpotInterruptionHandlers {
+		if _, ok := spotPools[h.poolID]; !ok {
+			// Not waited for, not to hold the refresh while an interrupted node is drained
+			klog.V(2).Infof("Stopping the spot interruption handler of node pool %s", h.poolID)
+			h.cancel()
+			continue
+		}
+
+		running = append(running, h)
+		delete(spotPools, h.poolID)
+	}
+	m.SpotInterruptionHandlers = running
+	m.SpotInterruptionHandlersLock.Unlock()
+
+	for poolID := range spotPools {
+		klog.V(2).Infof("Starting the spot interruption handler of node pool %s", poolID)
+		m.StartSpotInterruptionHandler(context.Background(), m.ClusterID, poolID, m.KubeClient)
+	}
+}
+
+// poll processes the interruption notices not handled yet
+func (h *SpotInterruptionHandler) poll(ctx context.Context) {
+	interruptions, err := h.manager.Client.ListSpotInterruptions(ctx, h.manager.ProjectID, h.clusterID, h.poolID)
+	if err != nil {
+		klog.Warningf("Failed to list spot interruptions of node pool %s: %v", h.poolID, err)
+		return
+	}
+
+	pending := make(map[string]struct{}, len(interruptions))
+	for _, interruption := range interruptions {
+		pending[interruption.NodeID] = struct{}{}
+		if _, ok := h.handled[interruption.NodeID]; ok {
+			continue
+		}
+		h.handled[interruption.NodeID] = struct{}{}
+
+		klog.Infof("Spot node %s of node pool %s is interrupted at %s", interruption.NodeName, h.poolID, interruption.TerminationTime)
+
+		err := h.manager.ScaleDownNode(ctx, h.clusterID, h.poolID, interruption, SpotInterruptionGracePeriod, h.k8sClient)
+		if err != nil {
+			klog.Warningf("Failed to scale down interrupted spot node %s: %v", interruption.NodeName, err)
+		}
+	}
+
+	// Forget the notices which are gone with their nodes
+	for nodeID := range h.handled {
+		if _, ok := pending[nodeID]; !ok {
+			delete(h.handled, nodeID)
+		}
+	}
+}
+
+// ScaleDownNode drains an interrupted spot node within the grace period, then removes it from its node pool.
+// The node is removed even if it could not be fully drained, as it is reclaimed anyway.
+func (m *OvhCloudManager) ScaleDownNode(ctx context.Context, clusterID string, poolID string, interruption sdk.SpotInterruption, gracePeriod time.Duration, k8sClient kubernetes.Interface) error {
+	drainCtx, cancel := context.WithTimeout(ctx, gracePeriod)
+	evicted, err := sdk.DrainNode(drainCtx, k8sClient, interruption.NodeName, int64(gracePeriod.Seconds()), spotDrainRetryInterval)
+	cancel()
+	if err != nil {
+		klog.Warningf("Failed to fully drain spot node %s: %v", interruption.NodeName, err)
+	}
+	klog.V(2).Infof("Evicted %d pod(s) from spot node %s", len(evicted), interruption.NodeName)
+
+	pool, err := m.Client.GetNodePool(ctx, m.ProjectID, clusterID, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	// The node pool may be cached, the size set by a previous resize prevails over its desired nodes
+	return m.PoolOperations.SubmitResize(ctx, poolID, m.nodePoolTargetSize(pool), func(ctx context.Context, size int) (int, error) {
+		// The node is replaced when the node pool is at its minimum size
+		desired := size
+		if desired > int(pool.MinNodes) {
+			desired--
+		}
+
+		apiDesired := m.nodePoolDesiredNodes(poolID, desired)
+		start := time.Now()
+		_, err := m.Client.UpdateNodePool(ctx, m.ProjectID, clusterID, poolID, &sdk.UpdateNodePoolOpts{
+			DesiredNodes:  &apiDesired,
+			NodesToRemove: []string{providerIDPrefix + interruption.InstanceID},
+		})
+		m.getScaleHistory(pool.Name).Add(newScaleOperation(ScaleDownDirection, size, desired, start, err))
+		if err != nil {
+			return size, fmt.Errorf("failed to remove spot node %s from node pool %s: %w", interruption.NodeName, poolID, err)
+		}
+
+		if desired != size {
+			m.EventBus.PublishAsync(ScaleEvent{
+				NodeGroupID: pool.Name,
+				Direction:   ScaleDownDirection,
+				OldSize:     size,
+				NewSize:     desired,
+				Timestamp:   time.Now(),
+			})
+		}
+
+		return desired, nil
+	})
+}
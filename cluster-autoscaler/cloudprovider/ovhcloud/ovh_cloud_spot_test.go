@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func TestOvhCloudManager_StartSpotInterruptionHandler(t *testing.T) {
+	defer func(interval time.Duration) { SpotInterruptionPollInterval = interval }(SpotInterruptionPollInterval)
+	SpotInterruptionPollInterval = 10 * time.Millisecond
+
+	provider := newTestProvider(t)
+	manager := provider.manager
+	client := manager.Client.(*sdk.ClientMock)
+
+	var polls int32
+	countPoll := func(mock.Arguments) { atomic.AddInt32(&polls, 1) }
+
+	interruption := sdk.SpotInterruption{NodeID: "node-id", NodeName: "spot-node", InstanceID: "0123"}
+	client.On("ListSpotInterruptions", mock.Anything, "projectID", "clusterID", "1").Return([]sdk.SpotInterruption{}, nil).Twice().Run(countPoll)
+	client.On("ListSpotInterruptions", mock.Anything, "projectID", "clusterID", "1").Return([]sdk.SpotInterruption{interruption}, nil).Run(countPoll)
+	client.On("GetNodePool", mock.Anything, "projectID", "clusterID", "1").Return(&sdk.NodePool{ID: "1", DesiredNodes: 2, MinNodes: 1}, nil)
+	client.On("Shutdown", mock.Anything).Return(nil)
+
+	removed := make(chan struct{})
+	desired := uint32(1)
+	client.On("UpdateNodePool", mock.Anything, "projectID", "clusterID", "1", &sdk.UpdateNodePoolOpts{
+		DesiredNodes:  &desired,
+		NodesToRemove: []string{"openstack:///0123"},
+	}).Return(&sdk.NodePool{}, nil).Once().Run(func(mock.Arguments) { close(removed) })
+
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       apiv1.PodSpec{NodeName: "spot-node"},
+	}
+	k8sClient := fake.NewSimpleClientset(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spot-node"}}, pod)
+
+	var drainedAtPoll int32
+	k8sClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		atomic.StoreInt32(&drainedAtPoll, atomic.LoadInt32(&polls))
+		_ = k8sClient.Tracker().Delete(apiv1.SchemeGroupVersion.WithResource("pods"), "default", "web")
+
+		return true, nil, nil
+	})
+
+	stop := manager.StartSpotInterruptionHandler(context.Background(), "clusterID", "1", k8sClient)
+
+	select {
+	case <-removed:
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "interrupted spot node was not removed")
+	}
+
+	t.Run("check node is drained on the third poll", func(t *testing.T) {
+		assert.Equal(t, int32(3), atomic.LoadInt32(&drainedAtPoll))
+	})
+
+	t.Run("check notice is handled once", func(t *testing.T) {
+		// Let the handler poll the same notice again
+		time.Sleep(5 * SpotInterruptionPollInterval)
+		client.AssertNumberOfCalls(t, "UpdateNodePool", 1)
+	})
+
+	t.Run("check handler is stopped on cleanup", func(t *testing.T) {
+		assert.NoError(t, provider.Cleanup())
+		assert.Empty(t, manager.SpotInterruptionHandlers)
+
+		calls := atomic.LoadInt32(&polls)
+		time.Sleep(5 * SpotInterruptionPollInterval)
+		assert.Equal(t, calls, atomic.LoadInt32(&polls))
+
+		// Stopping again is harmless
+		stop()
+	})
+}
+
+func TestOvhCloudManager_ScaleDownNode(t *testing.T) {
+	provider := newTestProvider(t)
+	manager := provider.manager
+	client := manager.Client.(*sdk.ClientMock)
+	ctx := context.Background()
+
+	// The cached node pool is older than the last resize
+	client.On("GetNodePool", mock.Anything, "projectID", "clusterID", "1").Return(&sdk.NodePool{ID: "1", Name: "pool-1", DesiredNodes: 2, MinNodes: 1}, nil)
+	assert.NoError(t, manager.PoolOperations.SubmitResize(ctx, "1", 2, func(ctx context.Context, size int) (int, error) {
+		return 4, nil
+	}))
+
+	desired := uint32(3)
+	client.On("UpdateNodePool", mock.Anything, "projectID", "clusterID", "1", &sdk.UpdateNodePoolOpts{
+		DesiredNodes:  &desired,
+		NodesToRemove: []string{"openstack:///0123"},
+	}).Return(&sdk.NodePool{}, nil).Once()
+
+	interruption := sdk.SpotInterruption{NodeID: "node-id", NodeName: "spot-node", InstanceID: "0123"}
+	k8sClient := fake.NewSimpleClientset(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spot-node"}})
+
+	t.Run("check the node pool is resized from the size of the last resize", func(t *testing.T) {
+		err := manager.ScaleDownNode(ctx, "clusterID", "1", interruption, time.Second, k8sClient)
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("check the new size is recorded for the next resizes", func(t *testing.T) {
+		assert.NoError(t, manager.PoolOperations.SubmitResize(ctx, "1", 2, func(ctx context.Context, size int) (int, error) {
+			assert.Equal(t, 3, size)
+			return size, nil
+		}))
+
+		history := manager.getScaleHistory("pool-1").Last(10)
+		assert.Len(t, history, 1)
+		assert.Equal(t, 4, history[0].FromSize)
+		assert.Equal(t, 3, history[0].ToSize)
+	})
+}
+
+func TestOvhCloudManager_SyncSpotInterruptionHandlers(t *testing.T) {
+	provider := newTestProvider(t)
+	manager := provider.manager
+	client := manager.Client.(*sdk.ClientMock)
+	client.On("ListSpotInterruptions", mock.Anything, "projectID", "clusterID", "1").Return([]sdk.SpotInterruption{}, nil)
+
+	spot := manager.NodePools[0]
+	spot.Template.Metadata.Annotations = map[string]string{"vke.autoscaler/spot": "true"}
+	pools := []sdk.NodePool{spot, manager.NodePools[1]}
+
+	t.Run("check nothing is started without kube client", func(t *testing.T) {
+		manager.syncSpotInterruptionHandlers(pools)
+		assert.Empty(t, manager.SpotInterruptionHandlers)
+	})
+
+	manager.KubeClient = fake.NewSimpleClientset()
+
+	t.Run("check a handler is started once for the spot node pools", func(t *testing.T) {
+		manager.syncSpotInterruptionHandlers(pools)
+		manager.syncSpotInterruptionHandlers(pools)
+
+		assert.Len(t, manager.SpotInterruptionHandlers, 1)
+		assert.Equal(t, "1", manager.SpotInterruptionHandlers[0].poolID)
+	})
+
+	t.Run("check the handlers of the pools not running spot instances anymore are stopped", func(t *testing.T) {
+		handler := manager.SpotInterruptionHandlers[0]
+
+		manager.syncSpotInterruptionHandlers(manager.NodePools)
+		assert.Empty(t, manager.SpotInterruptionHandlers)
+
+		select {
+		case <-handler.done:
+		case <-time.After(time.Second):
+			assert.Fail(t, "spot interruption handler not stopped")
+		}
+	})
+}
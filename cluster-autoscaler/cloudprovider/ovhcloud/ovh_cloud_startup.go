@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// startupScalingEventsWindow is how far back the scaling events of the node pools are logged at startup
+const startupScalingEventsWindow = 24 * time.Hour
+
+// LogStartupBanner validates the configuration, checks the cluster is reachable
+// and logs a summary of the effective configuration for operators.
+func LogStartupBanner(ctx context.Context, cfg *VKECloudProviderConfig, c ClientInterface) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	cluster, err := c.GetCluster(ctx, cfg.ProjectID, cfg.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to reach cluster %s: %w", cfg.ClusterID, err)
+	}
+
+	pools, err := c.ListNodePools(ctx, cfg.ProjectID, cfg.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to list node pools of cluster %s: %w", cfg.ClusterID, err)
+	}
+
+	nodes := uint32(0)
+	for _, pool := range pools {
+		nodes += pool.CurrentNodes
+	}
+
+	endpoint := cfg.ApplicationEndpoint
+	if cfg.AuthenticationType == OpenStackAuthenticationType {
+		endpoint = cfg.OpenStackAuthUrl
+	}
+
+	klog.V(0).Infof("OVHcloud cloud provider: endpoint=%q authentication=%s project=%s", endpoint, cfg.AuthenticationType, cfg.ProjectID)
+	klog.V(0).Infof("OVHcloud cloud provider: cluster=%s name=%q version=%s status=%s node_pools=%d nodes=%d",
+		cluster.ID, cluster.Name, cluster.Version, cluster.Status, len(pools), nodes)
+	klog.V(0).Infof("OVHcloud cloud provider: max_batch_delete_nodes=%d node_group_cache_ttl=%s api_budget_per_cycle=%d worker_pool_size=%d",
+		cfg.MaxBatchDeleteNodes, cfg.NodeGroupCacheTTL.Duration, cfg.APIBudgetPerCycle, cfg.WorkerPoolSize)
+
+	logRecentScalingEvents(ctx, cfg, c, pools)
+
+	return nil
+}
+
+// logRecentScalingEvents logs the scale actions of the last day, to help understanding what happened before a restart.
+// Failing to list them does not prevent the provider from starting.
+func logRecentScalingEvents(ctx context.Context, cfg *VKECloudProviderConfig, c ClientInterface, pools []sdk.NodePool) {
+	since := time.Now().Add(-startupScalingEventsWindow)
+
+	for _, pool := range pools {
+		events, err := c.GetScalingEvents(ctx, cfg.ProjectID, cfg.ClusterID, pool.ID, since)
+		if err != nil {
+			klog.Warningf("failed to list scaling events of node pool %s: %v", pool.Name, err)
+			continue
+		}
+
+		for _, event := range events {
+			klog.V(2).Infof("OVHcloud cloud provider: node pool %s %s to %d nodes (actual %d) at %s: status=%s reason=%q",
+				pool.Name, event.Type, event.RequestedSize, event.ActualSize, event.OccurredAt.Format(time.RFC3339), event.Status, event.Reason)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func newTestStartupConfig() *VKECloudProviderConfig {
+	return &VKECloudProviderConfig{
+		Config: Config{
+			ProjectID:              "projectID",
+			ClusterID:              "clusterID",
+			AuthenticationType:     ApplicationConsumerAuthenticationType,
+			ApplicationEndpoint:    "ovh-eu",
+			ApplicationKey:         "key",
+			ApplicationSecret:      "secret",
+			ApplicationConsumerKey: "consumer_key",
+		},
+		NodeGroupCacheTTL:   metav1.Duration{Duration: 30 * time.Second},
+		MaxBatchDeleteNodes: 4,
+		WorkerPoolSize:      2,
+	}
+}
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+
+	klog.LogToStderr(false)
+	klog.SetOutput(buf)
+	t.Cleanup(func() {
+		klog.SetOutput(os.Stderr)
+		klog.LogToStderr(true)
+	})
+
+	return buf
+}
+
+func TestLogStartupBanner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check configuration summary is logged", func(t *testing.T) {
+		client := &sdk.ClientMock{}
+		client.On("GetCluster", ctx, "projectID", "clusterID").Return(&sdk.Cluster{ID: "clusterID", Name: "my-cluster", Version: "1.28", Status: "READY"}, nil)
+		client.On("ListNodePools", ctx, "projectID", "clusterID").Return([]sdk.NodePool{{ID: "pool-1", CurrentNodes: 2}, {ID: "pool-2", CurrentNodes: 3}}, nil)
+		client.On("GetScalingEvents", ctx, "projectID", "clusterID", mock.Anything, mock.Anything).Return([]sdk.ScalingEvent{}, nil)
+
+		logs := captureLogs(t)
+
+		err := LogStartupBanner(ctx, newTestStartupConfig(), client)
+		assert.NoError(t, err)
+
+		klog.Flush()
+		assert.Contains(t, logs.String(), `endpoint="ovh-eu" authentication=consumer project=projectID`)
+		assert.Contains(t, logs.String(), `cluster=clusterID name="my-cluster" version=1.28 status=READY node_pools=2 nodes=5`)
+		assert.Contains(t, logs.String(), `max_batch_delete_nodes=4 node_group_cache_ttl=30s api_budget_per_cycle=0 worker_pool_size=2`)
+	})
+
+	t.Run("check recent scaling events are logged", func(t *testing.T) {
+		client := &sdk.ClientMock{}
+		client.On("GetCluster", ctx, "projectID", "clusterID").Return(&sdk.Cluster{ID: "clusterID"}, nil)
+		client.On("ListNodePools", ctx, "projectID", "clusterID").Return([]sdk.NodePool{{ID: "pool-1", Name: "workers"}, {ID: "pool-2", Name: "gpus"}}, nil)
+		client.On("GetScalingEvents", ctx, "projectID", "clusterID", "pool-1", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) >= startupScalingEventsWindow
+		})).Return([]sdk.ScalingEvent{{
+			Type:          sdk.ScalingEventScaleUp,
+			Status:        "DONE",
+			RequestedSize: 3,
+			ActualSize:    2,
+			Reason:        "pending pods",
+			OccurredAt:    time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC),
+		}}, nil)
+		client.On("GetScalingEvents", ctx, "projectID", "clusterID", "pool-2", mock.Anything).Return([]sdk.ScalingEvent{}, errors.New("not found"))
+
+		var level klog.Level
+		_ = level.Set("2")
+		t.Cleanup(func() { _ = level.Set("0") })
+
+		logs := captureLogs(t)
+
+		err := LogStartupBanner(ctx, newTestStartupConfig(), client)
+		assert.NoError(t, err)
+
+		klog.Flush()
+		assert.Contains(t, logs.String(), `node pool workers scale-up to 3 nodes (actual 2) at 2023-03-01T08:00:00Z: status=DONE reason="pending pods"`)
+		assert.Contains(t, logs.String(), "failed to list scaling events of node pool gpus: not found")
+	})
+
+	t.Run("check invalid configuration is rejected", func(t *testing.T) {
+		cfg := newTestStartupConfig()
+		cfg.MaxBatchDeleteNodes = -1
+
+		err := LogStartupBanner(ctx, cfg, &sdk.ClientMock{})
+		assert.Error(t, err)
+	})
+
+	t.Run("check unreachable cluster is reported", func(t *testing.T) {
+		client := &sdk.ClientMock{}
+		client.On("GetCluster", ctx, "projectID", "clusterID").Return(&sdk.Cluster{}, errors.New("connection refused"))
+
+		err := LogStartupBanner(ctx, newTestStartupConfig(), client)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
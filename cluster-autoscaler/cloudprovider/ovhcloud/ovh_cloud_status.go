@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+)
+
+// VKEStatusConfigMapKey is the key of the status ConfigMap data holding the VKE status.
+const VKEStatusConfigMapKey = "vke"
+
+// VKEStatus is the VKE specific status written next to the autoscaler status.
+type VKEStatus struct {
+	APIHealthy bool                `json:"vke_api_healthy"`
+	NodePools  []VKENodePoolStatus `json:"node_pools"`
+	DriftCount int                 `json:"drift_count"`
+}
+
+// VKENodePoolStatus is the status of a node pool as last seen by the autoscaler.
+type VKENodePoolStatus struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	CurrentNodes uint32 `json:"current_nodes"`
+	DesiredNodes uint32 `json:"desired_nodes"`
+	MinNodes     uint32 `json:"min_nodes"`
+	MaxNodes     uint32 `json:"max_nodes"`
+}
+
+// VKEStatusWriter writes the VKE status in the autoscaler status ConfigMap, next to the autoscaler status.
+type VKEStatusWriter struct {
+	provider *OVHCloudProvider
+
+	kubeClient          kube_client.Interface
+	namespace           string
+	statusConfigMapName string
+}
+
+// NewVKEStatusWriter creates a status writer reporting the state of the given provider.
+func NewVKEStatusWriter(provider *OVHCloudProvider, kubeClient kube_client.Interface, namespace, statusConfigMapName string) *VKEStatusWriter {
+	return &VKEStatusWriter{
+		provider:            provider,
+		kubeClient:          kubeClient,
+		namespace:           namespace,
+		statusConfigMapName: statusConfigMapName,
+	}
+}
+
+// Status returns the VKE status collected during the last provider refresh.
+func (w *VKEStatusWriter) Status() VKEStatus {
+	w.provider.statusLock.Lock()
+	status := VKEStatus{
+		APIHealthy: w.provider.apiHealthy,
+		DriftCount: w.provider.driftCount,
+	}
+	w.provider.statusLock.Unlock()
+
+	pools := w.provider.manager.NodePools
+	status.NodePools = make([]VKENodePoolStatus, 0, len(pools))
+	for _, pool := range pools {
+		status.NodePools = append(status.NodePools, VKENodePoolStatus{
+			Name:         pool.Name,
+			Status:       pool.Status,
+			CurrentNodes: pool.CurrentNodes,
+			DesiredNodes: pool.DesiredNodes,
+			MinNodes:     pool.MinNodes,
+			MaxNodes:     pool.MaxNodes,
+		})
+	}
+
+	return status
+}
+
+// Write stores the VKE status under the VKEStatusConfigMapKey key of the status ConfigMap, creating the ConfigMap
+// if it does not exist yet. The autoscaler status, written in the same ConfigMap by the autoscaler, is left untouched.
+func (w *VKEStatusWriter) Write(ctx context.Context, currentTime time.Time) (*apiv1.ConfigMap, error) {
+	block, err := yaml.Marshal(w.Status())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VKE status: %w", err)
+	}
+
+	maps := w.kubeClient.CoreV1().ConfigMaps(w.namespace)
+
+	configMap, err := maps.Get(ctx, w.statusConfigMapName, metav1.GetOptions{})
+	notFound := kube_errors.IsNotFound(err)
+	if notFound {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: w.namespace,
+				Name:      w.statusConfigMapName,
+			},
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get status ConfigMap: %w", err)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[VKEStatusConfigMapKey] = string(block)
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	configMap.Annotations[utils.ConfigMapLastUpdatedKey] = currentTime.Format(utils.ConfigMapLastUpdateFormat)
+
+	if notFound {
+		configMap, err = maps.Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		configMap, err = maps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write VKE status in status ConfigMap: %w", err)
+	}
+
+	return configMap, nil
+}
+
+// VKEAutoscalerStatus extends the autoscaler status with VKE specific fields,
+// written together in the status entry of the status ConfigMap.
+type VKEAutoscalerStatus struct {
+	api.ClusterAutoscalerStatus
+
+	VKEAPIHealthy        bool      `json:"vkeApiHealthy"`
+	VKEAPILatencyMs      int64     `json:"vkeApiLatencyMs"`
+	LastTokenRefresh     time.Time `json:"lastTokenRefresh"`
+	ActiveNodeGroupLocks []string  `json:"activeNodeGroupLocks,omitempty"`
+	DriftedNodeGroups    []string  `json:"driftedNodeGroups,omitempty"`
+}
+
+// WriteVKEStatusConfigMap writes the extended status in the status ConfigMap,
+// or creates the ConfigMap if it does not exist.
+func WriteVKEStatusConfigMap(kubeClient kube_client.Interface, namespace string, status VKEAutoscalerStatus, statusConfigMapName string, currentTime time.Time) (*apiv1.ConfigMap, error) {
+	statusUpdateTime := currentTime.Format(utils.ConfigMapLastUpdateFormat)
+	status.Time = statusUpdateTime
+
+	statusYaml, err := yaml.Marshal(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VKE status: %w", err)
+	}
+
+	maps := kubeClient.CoreV1().ConfigMaps(namespace)
+
+	configMap, err := maps.Get(context.Background(), statusConfigMapName, metav1.GetOptions{})
+	notFound := kube_errors.IsNotFound(err)
+	if notFound {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      statusConfigMapName,
+			},
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get status ConfigMap: %w", err)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data["status"] = string(statusYaml)
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	configMap.Annotations[utils.ConfigMapLastUpdatedKey] = statusUpdateTime
+
+	if notFound {
+		configMap, err = maps.Create(context.Background(), configMap, metav1.CreateOptions{})
+	} else {
+		configMap, err = maps.Update(context.Background(), configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write status ConfigMap: %w", err)
+	}
+
+	return configMap, nil
+}
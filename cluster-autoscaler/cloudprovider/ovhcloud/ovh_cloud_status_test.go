@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+)
+
+func TestVKEStatusWriter_Write(t *testing.T) {
+	provider := newTestProvider(t)
+
+	// Simulate a change of the maximum size performed outside of the autoscaler
+	provider.manager.NodePools = append([]sdk.NodePool(nil), provider.manager.NodePools...)
+	provider.manager.NodePools[0].MaxNodes = 10
+	assert.NoError(t, provider.Refresh())
+
+	client := fake.NewSimpleClientset()
+	writer := NewVKEStatusWriter(provider, client, "kube-system", "cluster-autoscaler-status")
+	ctx := context.Background()
+
+	t.Run("create the ConfigMap with the VKE status", func(t *testing.T) {
+		configMap, err := writer.Write(ctx, time.Now())
+		assert.NoError(t, err)
+		assert.Contains(t, configMap.Data[VKEStatusConfigMapKey], "vke_api_healthy: true")
+		assert.NotContains(t, configMap.Data, "status")
+	})
+
+	t.Run("write VKE status next to the autoscaler status", func(t *testing.T) {
+		_, err := utils.WriteStatusConfigMap(client, "kube-system", api.ClusterAutoscalerStatus{Message: "TEST_MSG"}, nil, "cluster-autoscaler-status", time.Now())
+		assert.NoError(t, err)
+
+		configMap, err := writer.Write(ctx, time.Now())
+		assert.NoError(t, err)
+
+		configMap, err = client.CoreV1().ConfigMaps("kube-system").Get(ctx, configMap.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Contains(t, configMap.Data["status"], "TEST_MSG")
+		assert.Contains(t, configMap.Data[VKEStatusConfigMapKey], "vke_api_healthy: true")
+
+		var status VKEStatus
+		assert.NoError(t, yaml.Unmarshal([]byte(configMap.Data[VKEStatusConfigMapKey]), &status))
+		assert.Equal(t, 1, status.DriftCount)
+		assert.Len(t, status.NodePools, 2)
+		assert.Equal(t, VKENodePoolStatus{Name: "pool-1", DesiredNodes: 2, MinNodes: 1, MaxNodes: 5}, status.NodePools[0])
+	})
+
+	t.Run("report an unhealthy API after a failed refresh", func(t *testing.T) {
+		provider.statusWriter = writer
+		defer func() { provider.statusWriter = nil }()
+
+		failing := &sdk.ClientMock{}
+		failing.On("ListNodePools", context.Background(), "projectID", "clusterID").Return([]sdk.NodePool(nil), errors.New("unavailable"))
+		provider.manager.Client = failing
+		assert.Error(t, provider.Refresh())
+
+		configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "cluster-autoscaler-status", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Contains(t, configMap.Data["status"], "TEST_MSG")
+		assert.Contains(t, configMap.Data[VKEStatusConfigMapKey], "vke_api_healthy: false")
+	})
+}
+
+func TestWriteVKEStatusConfigMap(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	refresh := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	status := VKEAutoscalerStatus{
+		ClusterAutoscalerStatus: api.ClusterAutoscalerStatus{
+			AutoscalerStatus: api.ClusterAutoscalerRunning,
+			Message:          "TEST_MSG",
+		},
+		VKEAPIHealthy:        true,
+		VKEAPILatencyMs:      42,
+		LastTokenRefresh:     refresh,
+		ActiveNodeGroupLocks: []string{"pool-1"},
+		DriftedNodeGroups:    []string{"pool-2"},
+	}
+
+	t.Run("create the ConfigMap with the extended status", func(t *testing.T) {
+		configMap, err := WriteVKEStatusConfigMap(client, "kube-system", status, "cluster-autoscaler-status", time.Now())
+		assert.NoError(t, err)
+
+		data := configMap.Data["status"]
+		assert.Contains(t, data, "autoscalerStatus: Running")
+		assert.Contains(t, data, "message: TEST_MSG")
+		assert.Contains(t, data, "vkeApiHealthy: true")
+		assert.Contains(t, data, "vkeApiLatencyMs: 42")
+		assert.Contains(t, data, `lastTokenRefresh: "2024-03-01T12:00:00Z"`)
+		assert.Contains(t, data, "activeNodeGroupLocks:\n- pool-1")
+		assert.Contains(t, data, "driftedNodeGroups:\n- pool-2")
+	})
+
+	t.Run("update the existing ConfigMap", func(t *testing.T) {
+		status.VKEAPIHealthy = false
+
+		_, err := WriteVKEStatusConfigMap(client, "kube-system", status, "cluster-autoscaler-status", time.Now())
+		assert.NoError(t, err)
+
+		configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "cluster-autoscaler-status", metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		var written VKEAutoscalerStatus
+		assert.NoError(t, yaml.Unmarshal([]byte(configMap.Data["status"]), &written))
+		assert.False(t, written.VKEAPIHealthy)
+		assert.Equal(t, "TEST_MSG", written.Message)
+		assert.Equal(t, refresh, written.LastTokenRefresh)
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// targetSizeHistorySize is the number of target size records kept in memory per node pool.
+const targetSizeHistorySize = 100
+
+// TargetSizeRecord records a change of the desired and current sizes of a node pool, as seen
+// during a refresh. A record whose sizes differ means the pool was still being reconciled,
+// and it stays the last one as long as the sizes do not change.
+type TargetSizeRecord struct {
+	Timestamp    time.Time `json:"timestamp"`
+	DesiredNodes uint32    `json:"desiredNodes"`
+	CurrentNodes uint32    `json:"currentNodes"`
+}
+
+// Reconciled tells whether the node pool was running its desired number of nodes
+func (r TargetSizeRecord) Reconciled() bool {
+	return r.DesiredNodes == r.CurrentNodes
+}
+
+// GetTargetSizeHistory returns the target size records of a node pool, oldest first
+func (m *OvhCloudManager) GetTargetSizeHistory(poolID string) []TargetSizeRecord {
+	m.TargetSizeHistoryPerNodePoolLock.Lock()
+	defer m.TargetSizeHistoryPerNodePoolLock.Unlock()
+
+	return append([]TargetSizeRecord(nil), m.TargetSizeHistoryPerNodePool[poolID]...)
+}
+
+// recordTargetSizes records the node pools whose current size differs from the desired one,
+// and the pools which caught up since the previous refresh
+func (m *OvhCloudManager) recordTargetSizes(pools []sdk.NodePool, now time.Time) {
+	m.TargetSizeHistoryPerNodePoolLock.Lock()
+	defer m.TargetSizeHistoryPerNodePoolLock.Unlock()
+
+	if m.TargetSizeHistoryPerNodePool == nil {
+		m.TargetSizeHistoryPerNodePool = make(map[string][]TargetSizeRecord)
+	}
+
+	for _, pool := range pools {
+		record := TargetSizeRecord{
+			Timestamp:    now,
+			DesiredNodes: pool.DesiredNodes,
+			CurrentNodes: pool.CurrentNodes,
+		}
+
+		history := m.TargetSizeHistoryPerNodePool[pool.ID]
+		if len(history) == 0 {
+			if record.Reconciled() {
+				continue
+			}
+		} else {
+			last := history[len(history)-1]
+			if last.DesiredNodes == record.DesiredNodes && last.CurrentNodes == record.CurrentNodes {
+				continue
+			}
+		}
+
+		history = append(history, record)
+		if len(history) > targetSizeHistorySize {
+			history = history[len(history)-targetSizeHistorySize:]
+		}
+		m.TargetSizeHistoryPerNodePool[pool.ID] = history
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func TestOvhCloudManager_GetTargetSizeHistory(t *testing.T) {
+	manager := newManagerWithClient(&sdk.ClientMock{}, "projectID", "clusterID")
+	start := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	refresh := func(minutes int, desired, current uint32) {
+		manager.recordTargetSizes([]sdk.NodePool{
+			{ID: "pool-1", DesiredNodes: desired, CurrentNodes: current},
+			{ID: "pool-2", DesiredNodes: 2, CurrentNodes: 2},
+		}, start.Add(time.Duration(minutes)*time.Minute))
+	}
+
+	t.Run("check reconciled pools are not recorded", func(t *testing.T) {
+		refresh(0, 3, 3)
+
+		assert.Empty(t, manager.GetTargetSizeHistory("pool-1"))
+		assert.Empty(t, manager.GetTargetSizeHistory("pool-2"))
+	})
+
+	t.Run("check stalled provisioning keeps its first record", func(t *testing.T) {
+		refresh(1, 5, 3)
+		refresh(2, 5, 3)
+		refresh(3, 5, 4)
+		refresh(4, 5, 4)
+
+		assert.Equal(t, []TargetSizeRecord{
+			{Timestamp: start.Add(1 * time.Minute), DesiredNodes: 5, CurrentNodes: 3},
+			{Timestamp: start.Add(3 * time.Minute), DesiredNodes: 5, CurrentNodes: 4},
+		}, manager.GetTargetSizeHistory("pool-1"))
+	})
+
+	t.Run("check reconciliation is recorded", func(t *testing.T) {
+		refresh(5, 5, 5)
+
+		history := manager.GetTargetSizeHistory("pool-1")
+		assert.Len(t, history, 3)
+		assert.True(t, history[2].Reconciled())
+		assert.Empty(t, manager.GetTargetSizeHistory("pool-2"))
+	})
+
+	t.Run("check history is bounded", func(t *testing.T) {
+		for i := 0; i < 2*targetSizeHistorySize; i++ {
+			refresh(10+i, 5, uint32(i%5))
+		}
+
+		history := manager.GetTargetSizeHistory("pool-1")
+		assert.Len(t, history, targetSizeHistorySize)
+		assert.Equal(t, start.Add(time.Duration(10+targetSizeHistorySize)*time.Minute), history[0].Timestamp)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+// ScaleFunc scales a node pool by delta nodes, removing nodes when negative.
+type ScaleFunc func(delta int) error
+
+// WarmupScheduler adds warm nodes to a node pool following its warmup schedule,
+// and removes them at cooldown time.
+type WarmupScheduler struct {
+	cron  *cron.Cron
+	scale ScaleFunc
+
+	active bool
+	mutex  sync.Mutex
+}
+
+// NewWarmupScheduler creates an inactive scheduler calling scale at warmup and cooldown times.
+func NewWarmupScheduler(scale ScaleFunc) *WarmupScheduler {
+	return &WarmupScheduler{
+		cron:  cron.New(),
+		scale: scale,
+	}
+}
+
+// Start validates the schedule, registers its cron jobs and activates the scheduler.
+func (s *WarmupScheduler) Start(sched *sdk.WarmupSchedule) error {
+	if err := sched.Validate(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.active {
+		return fmt.Errorf("warmup scheduler is already active")
+	}
+
+	if _, err := s.cron.AddFunc(sched.CronExpression, s.job(sched.WarmNodes)); err != nil {
+		return fmt.Errorf("failed to schedule warmup: %w", err)
+	}
+
+	if sched.CooldownCronExpression != "" {
+		if _, err := s.cron.AddFunc(sched.CooldownCronExpression, s.job(-sched.WarmNodes)); err != nil {
+			return fmt.Errorf("failed to schedule cooldown: %w", err)
+		}
+	}
+
+	s.cron.Start()
+	s.active = true
+
+	return nil
+}
+
+// Stop deactivates the scheduler, waiting for the running scale calls to complete.
+func (s *WarmupScheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.active {
+		return
+	}
+
+	<-s.cron.Stop().Done()
+	for _, entry := range s.cron.Entries() {
+		s.cron.Remove(entry.ID)
+	}
+	s.active = false
+}
+
+func (s *WarmupScheduler) job(delta int) func() {
+	return func() {
+		klog.V(4).Infof("Scaling node pool by %d warm node(s)", delta)
+
+		if err := s.scale(delta); err != nil {
+			klog.Warningf("failed to scale node pool by %d warm node(s): %v", delta, err)
+		}
+	}
+}
+
+// ScaleNodePool scales the node group by delta nodes, within its min and max sizes.
+// Nodes to remove when scaling down are chosen by the platform.
+func (ng *NodeGroup) ScaleNodePool(delta int) error {
+	if delta >= 0 {
+		return ng.IncreaseSize(delta)
+	}
+
+	if err := ng.checkNotInMaintenance(); err != nil {
+		return err
+	}
+
+	size, err := ng.TargetSize()
+	if err != nil {
+		return fmt.Errorf("failed to get NodeGroup target size")
+	}
+
+	desired := size + delta
+	if desired < ng.MinSize() {
+		desired = ng.MinSize()
+	}
+
+	if desired == size {
+		return nil
+	}
+
+	target := uint32(desired)
+	_, err = ng.Manager.Client.UpdateNodePool(context.Background(), ng.Manager.ProjectID, ng.Manager.ClusterID, ng.ID, &sdk.UpdateNodePoolOpts{
+		DesiredNodes: &target,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrease node pool desired size: %w", err)
+	}
+	ng.CurrentSize = desired
+
+	return nil
+}
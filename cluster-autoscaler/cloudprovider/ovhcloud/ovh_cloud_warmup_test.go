@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovhcloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/ovhcloud/sdk"
+)
+
+func TestWarmupScheduler(t *testing.T) {
+	t.Run("check cron fires and scale is called", func(t *testing.T) {
+		deltas := make(chan int, 10)
+		scheduler := NewWarmupScheduler(func(delta int) error {
+			deltas <- delta
+			return nil
+		})
+
+		err := scheduler.Start(&sdk.WarmupSchedule{CronExpression: "@every 1s", WarmNodes: 2})
+		assert.NoError(t, err)
+		defer scheduler.Stop()
+
+		select {
+		case delta := <-deltas:
+			assert.Equal(t, 2, delta)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "warmup has not been triggered")
+		}
+	})
+
+	t.Run("check invalid expression is rejected", func(t *testing.T) {
+		scheduler := NewWarmupScheduler(func(delta int) error { return nil })
+
+		err := scheduler.Start(&sdk.WarmupSchedule{CronExpression: "0 25 * * *", WarmNodes: 2})
+		assert.Error(t, err)
+	})
+
+	t.Run("check scheduler can not be started twice", func(t *testing.T) {
+		scheduler := NewWarmupScheduler(func(delta int) error { return nil })
+
+		assert.NoError(t, scheduler.Start(&sdk.WarmupSchedule{CronExpression: "0 8 * * *", WarmNodes: 1}))
+		assert.Error(t, scheduler.Start(&sdk.WarmupSchedule{CronExpression: "0 8 * * *", WarmNodes: 1}))
+
+		scheduler.Stop()
+		assert.NoError(t, scheduler.Start(&sdk.WarmupSchedule{CronExpression: "0 8 * * *", WarmNodes: 1}))
+		scheduler.Stop()
+	})
+}
+
+func TestOVHCloudNodeGroup_ScaleNodePool(t *testing.T) {
+	ng := newTestNodeGroup(t, "b2-7")
+
+	t.Run("check warmup increases size", func(t *testing.T) {
+		ng.mockCallUpdateNodePool(5, nil)
+
+		assert.NoError(t, ng.ScaleNodePool(2))
+		assert.Equal(t, 5, ng.CurrentSize)
+	})
+
+	t.Run("check cooldown decreases size down to min size", func(t *testing.T) {
+		ng.mockCallUpdateNodePool(1, nil)
+
+		assert.NoError(t, ng.ScaleNodePool(-10))
+		assert.Equal(t, 1, ng.CurrentSize)
+	})
+}
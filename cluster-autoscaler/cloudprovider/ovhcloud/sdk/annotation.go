@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+// DefaultLabelPrefix is prepended to the names of the labels and annotations managed by the autoscaler
+const DefaultLabelPrefix = "vke.autoscaler/"
+
+// AnnotationKey builds the key of a label or annotation from its name and prefix.
+// DefaultLabelPrefix is used when the prefix is empty.
+func AnnotationKey(prefix string, name string) string {
+	if prefix == "" {
+		prefix = DefaultLabelPrefix
+	}
+
+	return prefix + name
+}
+
+// WithLabelPrefix sets the prefix of the labels and annotations managed by the client
+func WithLabelPrefix(prefix string) ClientOption {
+	return func(client *Client) error {
+		client.LabelPrefix = prefix
+		return nil
+	}
+}
+
+// AnnotationKey builds the key of a label or annotation managed by the client
+func (c *Client) AnnotationKey(name string) string {
+	return AnnotationKey(c.LabelPrefix, name)
+}
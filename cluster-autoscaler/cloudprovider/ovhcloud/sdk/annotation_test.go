@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotationKey(t *testing.T) {
+	assert.Equal(t, "vke.autoscaler/locked", AnnotationKey("", LockedAnnotation))
+	assert.Equal(t, "example.com/locked", AnnotationKey("example.com/", LockedAnnotation))
+}
+
+func TestClient_LabelPrefix(t *testing.T) {
+	client, pool := newTemplateTestClient(t, nil)
+	client.LabelPrefix = "example.com/"
+	ctx := context.Background()
+
+	err := client.LockNodePool(ctx, "projectID", "clusterID", "poolID", "maintenance", time.Hour)
+	assert.NoError(t, err)
+
+	err = client.SetWarmupSchedule(ctx, "projectID", "clusterID", "poolID", &WarmupSchedule{CronExpression: "0 8 * * *", WarmNodes: 1, CooldownCronExpression: "0 20 * * *"})
+	assert.NoError(t, err)
+
+	err = client.InitialiseNodePool(ctx, "projectID", "clusterID", "poolID", &InitialiseOpts{OwnerEmail: "owner@example.com"})
+	assert.NoError(t, err)
+
+	for _, name := range []string{LockedAnnotation, LockExpiresAnnotation, LockReasonAnnotation, WarmupCronAnnotation, WarmupNodesAnnotation, CooldownCronAnnotation, OwnerAnnotation} {
+		assert.Contains(t, pool.Template.Metadata.Annotations, "example.com/"+name)
+	}
+
+	for key := range pool.Template.Metadata.Annotations {
+		assert.False(t, strings.HasPrefix(key, DefaultLabelPrefix), "unexpected annotation %s", key)
+	}
+
+	locked, _, err := IsNodePoolLocked(pool, client.LabelPrefix)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+}
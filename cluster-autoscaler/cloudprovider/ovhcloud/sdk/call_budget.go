@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCallBudgetExhausted is returned by the requests sent once the budget of the cycle is spent
+var ErrCallBudgetExhausted = errors.New("API call budget of the cycle is exhausted")
+
+// CallBudget limits the number of requests sent between two resets, e.g. per autoscaling loop.
+// It is safe for concurrent use, and a nil budget allows every request.
+type CallBudget struct {
+	limit int
+	spent int
+	mutex sync.Mutex
+}
+
+// NewCallBudget creates a budget of limit requests per cycle
+func NewCallBudget(limit int) *CallBudget {
+	return &CallBudget{limit: limit}
+}
+
+// Spend takes a request from the budget, returning ErrCallBudgetExhausted when none is left
+func (b *CallBudget) Spend() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.spent >= b.limit {
+		return ErrCallBudgetExhausted
+	}
+	b.spent++
+
+	return nil
+}
+
+// Reset gives the whole budget back, at the start of a new cycle
+func (b *CallBudget) Reset() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.spent = 0
+}
+
+// WithCallBudget makes every request of the client spend the budget, shared by the clients given the same one
+func WithCallBudget(budget *CallBudget) ClientOption {
+	return func(client *Client) error {
+		if budget != nil && budget.limit < 1 {
+			return fmt.Errorf("call budget should allow at least 1 request, got %d", budget.limit)
+		}
+
+		client.callBudget = budget
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CallAPIWithCallBudget(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	budget := NewCallBudget(2)
+	assert.NoError(t, WithCallBudget(budget)(client))
+	ctx := context.Background()
+
+	t.Run("check requests are refused once the budget is spent", func(t *testing.T) {
+		assert.NoError(t, client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true))
+		assert.NoError(t, client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true))
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.True(t, errors.Is(err, ErrCallBudgetExhausted))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("check the budget is given back on reset", func(t *testing.T) {
+		budget.Reset()
+
+		assert.NoError(t, client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("check nil budgets allow every request", func(t *testing.T) {
+		var unlimited *CallBudget
+		assert.NoError(t, unlimited.Spend())
+		unlimited.Reset()
+	})
+
+	t.Run("check empty budgets are rejected", func(t *testing.T) {
+		assert.Error(t, WithCallBudget(NewCallBudget(0))(client))
+	})
+}
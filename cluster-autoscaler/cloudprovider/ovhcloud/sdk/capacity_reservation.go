@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CapacityReservation guarantees instances of a flavor are available for a node pool until it expires
+type CapacityReservation struct {
+	ID        string    `json:"id"`
+	FlavorID  string    `json:"flavorId"`
+	Quantity  int       `json:"quantity"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CapacityReservationOpts defines required fields to create a capacity reservation
+type CapacityReservationOpts struct {
+	FlavorID  string    `json:"flavorId"`
+	Quantity  int       `json:"quantity"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Validate checks the capacity reservation options
+func (opts *CapacityReservationOpts) Validate() error {
+	return opts.validate(time.Now())
+}
+
+// validate checks the capacity reservation options, the expiry being compared to now
+func (opts *CapacityReservationOpts) validate(now time.Time) error {
+	if opts.FlavorID == "" {
+		return fmt.Errorf("capacity reservation flavor should not be empty")
+	}
+
+	if opts.Quantity <= 0 {
+		return fmt.Errorf("capacity reservation quantity should be positive, got %d", opts.Quantity)
+	}
+
+	if !opts.ExpiresAt.After(now) {
+		return fmt.Errorf("capacity reservation expiry %s should be in the future", opts.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// CreateCapacityReservation allows to reserve capacity for a node pool
+func (c *Client) CreateCapacityReservation(ctx context.Context, projectID string, clusterID string, poolID string, opts *CapacityReservationOpts) (*CapacityReservation, error) {
+	if err := opts.validate(c.now()); err != nil {
+		return nil, err
+	}
+
+	reservation := &CapacityReservation{}
+
+	return reservation, c.CallAPIWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/capacityReservation", projectID, clusterID, poolID),
+		opts,
+		&reservation,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// ListCapacityReservations allows to list the capacity reservations of a node pool
+func (c *Client) ListCapacityReservations(ctx context.Context, projectID string, clusterID string, poolID string) ([]CapacityReservation, error) {
+	reservations := make([]CapacityReservation, 0)
+
+	return reservations, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/capacityReservation", projectID, clusterID, poolID),
+		nil,
+		&reservations,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// DeleteCapacityReservation allows to release a capacity reservation of a node pool
+func (c *Client) DeleteCapacityReservation(ctx context.Context, projectID string, clusterID string, poolID string, reservationID string) error {
+	return c.CallAPIWithContext(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/capacityReservation/%s", projectID, clusterID, poolID, reservationID),
+		nil,
+		nil,
+		nil,
+		nil,
+		true,
+	)
+}
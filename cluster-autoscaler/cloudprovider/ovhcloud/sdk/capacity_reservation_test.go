@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCapacityReservationTestClient(t *testing.T) *Client {
+	mutex := sync.Mutex{}
+	reservations := make([]CapacityReservation, 0)
+
+	path := "/cloud/project/projectID/kube/clusterID/nodepool/poolID/capacityReservation"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.Method == "POST" {
+			opts := CapacityReservationOpts{}
+			_ = json.NewDecoder(r.Body).Decode(&opts)
+
+			reservation := CapacityReservation{ID: "reservation-1", FlavorID: opts.FlavorID, Quantity: opts.Quantity, ExpiresAt: opts.ExpiresAt}
+			reservations = append(reservations, reservation)
+			_ = json.NewEncoder(w).Encode(reservation)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(reservations)
+	})
+	mux.HandleFunc(path+"/", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, path+"/")
+		for i, reservation := range reservations {
+			if reservation.ID == id && r.Method == "DELETE" {
+				reservations = append(reservations[:i], reservations[i+1:]...)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return newTestClient(t, mux)
+}
+
+func TestClient_CapacityReservations(t *testing.T) {
+	client := newCapacityReservationTestClient(t)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+
+	t.Run("check creation", func(t *testing.T) {
+		reservation, err := client.CreateCapacityReservation(ctx, "projectID", "clusterID", "poolID", &CapacityReservationOpts{
+			FlavorID:  "b2-7",
+			Quantity:  3,
+			ExpiresAt: expiresAt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "reservation-1", reservation.ID)
+		assert.Equal(t, 3, reservation.Quantity)
+	})
+
+	t.Run("check listing", func(t *testing.T) {
+		reservations, err := client.ListCapacityReservations(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, []CapacityReservation{{ID: "reservation-1", FlavorID: "b2-7", Quantity: 3, ExpiresAt: expiresAt}}, reservations)
+	})
+
+	t.Run("check deletion", func(t *testing.T) {
+		err := client.DeleteCapacityReservation(ctx, "projectID", "clusterID", "poolID", "reservation-1")
+		assert.NoError(t, err)
+
+		reservations, err := client.ListCapacityReservations(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Empty(t, reservations)
+
+		err = client.DeleteCapacityReservation(ctx, "projectID", "clusterID", "poolID", "reservation-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("check expired reservation is rejected", func(t *testing.T) {
+		clock := NewFakeClock(expiresAt)
+		client.clock = clock
+		defer func() { client.clock = SystemClock{} }()
+
+		_, err := client.CreateCapacityReservation(ctx, "projectID", "clusterID", "poolID", &CapacityReservationOpts{
+			FlavorID:  "b2-7",
+			Quantity:  3,
+			ExpiresAt: expiresAt,
+		})
+		assert.Error(t, err)
+
+		clock.SetNow(expiresAt.Add(-time.Second))
+
+		_, err = client.CreateCapacityReservation(ctx, "projectID", "clusterID", "poolID", &CapacityReservationOpts{
+			FlavorID:  "b2-7",
+			Quantity:  3,
+			ExpiresAt: expiresAt,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("check invalid quantity is rejected", func(t *testing.T) {
+		_, err := client.CreateCapacityReservation(ctx, "projectID", "clusterID", "poolID", &CapacityReservationOpts{
+			FlavorID:  "b2-7",
+			ExpiresAt: expiresAt,
+		})
+		assert.Error(t, err)
+	})
+}
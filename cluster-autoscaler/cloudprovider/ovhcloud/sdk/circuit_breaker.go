@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker of the client is open
+var ErrCircuitOpen = errors.New("circuit breaker is open, the API is considered down")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every call until the reset timeout is elapsed
+	CircuitOpen
+
+	// CircuitHalfOpen lets a few probe calls through to check whether the API is back
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// CircuitBreakerConfig defines when a circuit breaker opens and closes again
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the breaker
+	FailureThreshold int
+
+	// ResetTimeout is how long the breaker stays open before letting probe calls through
+	ResetTimeout time.Duration
+
+	// ProbeCount is the number of successful probe calls closing the breaker
+	ProbeCount int
+}
+
+// CircuitBreaker stops calling the API after consecutive failures, until probe calls succeed again.
+// It is safe for concurrent use, and a nil breaker lets every call through.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+	clock  Clock
+
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+	mutex     sync.Mutex
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(config CircuitBreakerConfig, clock Clock) *CircuitBreaker {
+	return &CircuitBreaker{
+		config: config,
+		clock:  clock,
+	}
+}
+
+// WithCircuitBreaker protects the API from the calls of the client while it fails
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(client *Client) error {
+		if config.FailureThreshold < 1 || config.ProbeCount < 1 {
+			return fmt.Errorf("circuit breaker failure threshold and probe count should be at least 1, got %d and %d", config.FailureThreshold, config.ProbeCount)
+		}
+		if config.ResetTimeout <= 0 {
+			return fmt.Errorf("circuit breaker reset timeout should be positive, got %s", config.ResetTimeout)
+		}
+
+		client.circuitBreakerConfig = &config
+		return nil
+	}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+// Allow returns ErrCircuitOpen when a call must not be made.
+// Once the reset timeout is elapsed, ProbeCount calls are allowed to probe the API.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == CircuitOpen {
+		if b.clock.Now().Sub(b.openedAt) < b.config.ResetTimeout {
+			return ErrCircuitOpen
+		}
+
+		b.state = CircuitHalfOpen
+		b.probes = 0
+		b.successes = 0
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.config.ProbeCount {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	return nil
+}
+
+// Record drives the state of the breaker with the result of an allowed call
+func (b *CircuitBreaker) Record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	failure := isCircuitFailure(err)
+
+	switch b.state {
+	case CircuitClosed:
+		if !failure {
+			b.failures = 0
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.config.FailureThreshold || errors.Is(err, ErrAPIDown) {
+			b.open(err)
+		}
+	case CircuitHalfOpen:
+		if failure {
+			b.open(err)
+			return
+		}
+
+		b.successes++
+		if b.successes >= b.config.ProbeCount {
+			klog.Infof("API circuit breaker closed after %d successful probes", b.successes)
+			b.state = CircuitClosed
+			b.failures = 0
+		}
+	}
+}
+
+func (b *CircuitBreaker) open(err error) {
+	klog.Warningf("API circuit breaker opened for %s: %v", b.config.ResetTimeout, err)
+
+	b.state = CircuitOpen
+	b.openedAt = b.clock.Now()
+}
+
+// isCircuitFailure tells whether an error shows the API is down: network errors and server side errors.
+// Client side errors, such as a 404, show the API is answering.
+func isCircuitFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, ErrAPIDown) {
+		return true
+	}
+
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.Code >= http.StatusInternalServerError || apiError.Code == http.StatusTooManyRequests
+	}
+
+	var urlError *url.Error
+	return errors.As(err, &urlError)
+}
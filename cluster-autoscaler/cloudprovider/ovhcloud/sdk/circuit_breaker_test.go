@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 3, ResetTimeout: time.Minute, ProbeCount: 2}, clock)
+	unavailable := &APIError{Code: http.StatusServiceUnavailable}
+
+	t.Run("check breaker opens after consecutive failures", func(t *testing.T) {
+		breaker.Record(unavailable)
+		breaker.Record(unavailable)
+		breaker.Record(nil)
+		assert.Equal(t, CircuitClosed, breaker.State())
+
+		// Client side errors show the API is answering
+		breaker.Record(&APIError{Code: http.StatusNotFound})
+		breaker.Record(unavailable)
+		breaker.Record(unavailable)
+		assert.Equal(t, CircuitClosed, breaker.State())
+
+		breaker.Record(unavailable)
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	})
+
+	t.Run("check breaker reopens when a probe fails", func(t *testing.T) {
+		clock.Advance(time.Minute)
+
+		assert.NoError(t, breaker.Allow())
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+
+		breaker.Record(unavailable)
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	})
+
+	t.Run("check breaker closes after successful probes", func(t *testing.T) {
+		clock.Advance(time.Minute)
+
+		assert.NoError(t, breaker.Allow())
+		assert.NoError(t, breaker.Allow())
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+		breaker.Record(nil)
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+		breaker.Record(nil)
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.NoError(t, breaker.Allow())
+	})
+
+	t.Run("check API down errors open the breaker at once", func(t *testing.T) {
+		breaker.Record(ErrAPIDown)
+		assert.Equal(t, CircuitOpen, breaker.State())
+	})
+
+	t.Run("check concurrent accesses", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if breaker.Allow() == nil {
+					breaker.Record(nil)
+				}
+				breaker.State()
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("check nil breakers allow every call", func(t *testing.T) {
+		var disabled *CircuitBreaker
+		disabled.Record(unavailable)
+		assert.NoError(t, disabled.Allow())
+		assert.Equal(t, CircuitClosed, disabled.State())
+	})
+}
+
+func TestClient_CallAPIWithCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+
+	t.Run("check no call is made while the breaker is open", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusBadGateway, http.StatusBadGateway)
+		client.retryPolicy = nil
+		client.CircuitBreaker = NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, ResetTimeout: time.Minute, ProbeCount: 1}, clock)
+
+		for i := 0; i < 3; i++ {
+			_ = client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		}
+		assert.Equal(t, 2, sequence.attempts)
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 2, sequence.attempts)
+
+		clock.Advance(time.Minute)
+		err = client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, sequence.attempts)
+		assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+	})
+
+	t.Run("check retries stop once the breaker opens", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		client.CircuitBreaker = NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, ResetTimeout: time.Minute, ProbeCount: 1}, clock)
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 2, sequence.attempts)
+	})
+
+	t.Run("check a cancelled rate limit wait does not take a probe", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t)
+		client.retryPolicy = nil
+		client.CircuitBreaker = NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Minute, ProbeCount: 1}, clock)
+		client.CircuitBreaker.Record(ErrAPIDown)
+		clock.Advance(time.Minute)
+
+		// The only request allowed by the limiter is already taken
+		assert.NoError(t, WithRateLimit(0.001, 1)(client))
+		assert.NoError(t, client.rateLimiter.Wait(ctx))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := client.CallAPIWithContext(cancelled, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, sequence.attempts)
+
+		client.rateLimiter = nil
+		err = client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sequence.attempts)
+		assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+	})
+
+	t.Run("check an unreachable time endpoint opens the breaker", func(t *testing.T) {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		client.openStackToken = ""
+		client.CircuitBreaker = NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 5, ResetTimeout: time.Minute, ProbeCount: 1}, clock)
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.True(t, errors.Is(err, ErrAPIDown))
+		assert.Equal(t, CircuitOpen, client.CircuitBreaker.State())
+	})
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	client, err := NewClient(OvhEU, "key", "secret", "consumer_key", WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 5, ResetTimeout: time.Minute, ProbeCount: 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+
+	_, err = NewClient(OvhEU, "key", "secret", "consumer_key", WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 5}))
+	assert.Error(t, err)
+}
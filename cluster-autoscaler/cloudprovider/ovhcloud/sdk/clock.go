@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock gives the current time, allowing tests to control it
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After returns a channel receiving the current time once the given duration has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock reading the local machine time
+type SystemClock struct{}
+
+// Now returns the local machine time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse on the local machine
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FakeClock is a Clock whose time only changes when set or advanced
+type FakeClock struct {
+	now      time.Time
+	watchers []chan time.Time
+	waiters  []fakeClockWaiter
+	mutex    sync.Mutex
+}
+
+// fakeClockWaiter is a channel returned by FakeClock.After, fired once the fake time reaches target
+type fakeClockWaiter struct {
+	target time.Time
+	ch     chan time.Time
+}
+
+// NewFakeClock creates a fake clock set to the given time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake clock time
+func (f *FakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.now
+}
+
+// SetNow sets the fake clock time
+func (f *FakeClock) SetNow(t time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.setNow(t)
+}
+
+// Advance moves the fake clock time forward by the given duration
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.setNow(f.now.Add(d))
+}
+
+// NowChannel returns a channel receiving the fake clock time each time it changes.
+// Only the latest time is kept when the channel is not read.
+func (f *FakeClock) NowChannel() <-chan time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	f.watchers = append(f.watchers, ch)
+
+	return ch
+}
+
+// After returns a channel receiving the fake clock time once it has been advanced by the given duration
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+
+	f.waiters = append(f.waiters, fakeClockWaiter{target: f.now.Add(d), ch: ch})
+
+	return ch
+}
+
+// Waiters returns the number of channels returned by After which have not fired yet
+func (f *FakeClock) Waiters() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return len(f.waiters)
+}
+
+func (f *FakeClock) setNow(t time.Time) {
+	f.now = t
+
+	pending := f.waiters[:0]
+	for _, waiter := range f.waiters {
+		if waiter.target.After(t) {
+			pending = append(pending, waiter)
+			continue
+		}
+
+		waiter.ch <- t
+	}
+	f.waiters = pending
+
+	for _, ch := range f.watchers {
+		// Drop the time not read yet, if any
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- t
+	}
+}
+
+// WithClock sets the clock used by the client, the local machine time by default
+func WithClock(clock Clock) ClientOption {
+	return func(client *Client) error {
+		client.clock = clock
+		return nil
+	}
+}
+
+// now returns the current time given by the client clock
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+
+	return c.clock.Now()
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ch := clock.NowChannel()
+
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+
+	// Only the latest time is kept in the channel
+	clock.SetNow(start.Add(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), <-ch)
+
+	select {
+	case now := <-ch:
+		assert.Fail(t, "unexpected time received", now)
+	default:
+	}
+}
+
+func TestClient_SignatureTimestamp(t *testing.T) {
+	t.Parallel()
+
+	serverTime := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+	timestamps := make(chan string, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/time", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(serverTime.Unix())
+	})
+	mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+		timestamps <- r.Header.Get("X-Ovh-Timestamp")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// The local machine is 10 seconds ahead of the API
+	clock := NewFakeClock(serverTime.Add(10 * time.Second))
+
+	client, err := NewClient(server.URL, "key", "secret", "consumer_key", WithClock(clock))
+	assert.NoError(t, err)
+
+	delta, err := client.TimeDelta()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, delta)
+
+	clock.Advance(5 * time.Second)
+
+	err = client.CallAPIWithContext(context.Background(), "GET", "/signed", nil, nil, nil, nil, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, strconv.FormatInt(serverTime.Add(5*time.Second).Unix(), 10), <-timestamps)
+}
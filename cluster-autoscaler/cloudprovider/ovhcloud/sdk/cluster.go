@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Cluster defines a managed Kubernetes cluster deployed on OVHcloud
+type Cluster struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Version string `json:"version"`
+	Status  string `json:"status"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GetCluster allows to display information for a specific cluster
+func (c *Client) GetCluster(ctx context.Context, projectID string, clusterID string) (*Cluster, error) {
+	cluster := &Cluster{}
+
+	return cluster, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s", projectID, clusterID),
+		nil,
+		&cluster,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// ListClusters allows to display information for every cluster of a project.
+// The API only lists the cluster identifiers, so every cluster is then requested.
+func (c *Client) ListClusters(ctx context.Context, projectID string) ([]Cluster, error) {
+	clusterIDs := make([]string, 0)
+
+	err := c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube", projectID),
+		nil,
+		&clusterIDs,
+		nil,
+		nil,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]Cluster, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		cluster, err := c.GetCluster(ctx, projectID, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster %s: %w", clusterID, err)
+		}
+
+		clusters = append(clusters, *cluster)
+	}
+
+	return clusters, nil
+}
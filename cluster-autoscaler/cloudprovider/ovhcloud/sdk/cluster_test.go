@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListClusters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["cluster-1", "cluster-2"]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/cluster-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "cluster-1", "name": "prod", "region": "GRA7", "version": "1.28", "status": "READY", "createdAt": "2023-03-01T08:00:00Z"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/cluster-2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "cluster-2", "name": "staging", "region": "BHS5", "version": "1.27", "status": "UPDATING"}`))
+	})
+	mux.HandleFunc("/cloud/project/otherProjectID/kube", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["missing"]`))
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check every cluster is detailed", func(t *testing.T) {
+		clusters, err := client.ListClusters(context.Background(), "projectID")
+		assert.NoError(t, err)
+		assert.Len(t, clusters, 2)
+		assert.Equal(t, Cluster{
+			ID:        "cluster-1",
+			Name:      "prod",
+			Region:    "GRA7",
+			Version:   "1.28",
+			Status:    "READY",
+			CreatedAt: time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC),
+		}, clusters[0])
+		assert.Equal(t, "staging", clusters[1].Name)
+	})
+
+	t.Run("check missing cluster fails", func(t *testing.T) {
+		_, err := client.ListClusters(context.Background(), "otherProjectID")
+		assert.ErrorContains(t, err, "failed to get cluster missing")
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the size, in bytes, above which request bodies are gzip-compressed
+const DefaultCompressionThreshold = 4096
+
+// compressBody gzip-compresses a request body
+func compressBody(body []byte) ([]byte, error) {
+	compressed := &bytes.Buffer{}
+
+	writer := gzip.NewWriter(compressed)
+	if _, err := writer.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	return compressed.Bytes(), nil
+}
+
+// responseBody returns the body of a response, decompressed when the API compressed it.
+// Closing it closes the response body.
+func responseBody(response *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return response.Body, nil
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response body: %w", err)
+	}
+
+	return &gzipBody{Reader: reader, body: response.Body}, nil
+}
+
+// gzipBody closes both the gzip reader and the response body it reads
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	_ = b.Reader.Close()
+
+	return b.body.Close()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Compression(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			assert.NoError(t, err)
+			body = reader
+		}
+
+		payload := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(body).Decode(&payload))
+		payload["compressed"] = r.Header.Get("Content-Encoding") == "gzip"
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		defer writer.Close()
+		_ = json.NewEncoder(writer).Encode(payload)
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check small bodies are sent as is", func(t *testing.T) {
+		result := map[string]interface{}{}
+		err := client.CallAPI("POST", "/echo", map[string]string{"node": "node-1"}, &result, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"node": "node-1", "compressed": false}, result)
+	})
+
+	t.Run("check large bodies are compressed", func(t *testing.T) {
+		nodes := strings.Repeat("node-", DefaultCompressionThreshold)
+
+		result := map[string]interface{}{}
+		err := client.CallAPI("POST", "/echo", map[string]string{"node": nodes}, &result, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"node": nodes, "compressed": true}, result)
+	})
+
+	t.Run("check compression threshold can be changed", func(t *testing.T) {
+		client.CompressionThreshold = 8
+		defer func() { client.CompressionThreshold = 0 }()
+
+		result := map[string]interface{}{}
+		err := client.CallAPI("POST", "/echo", map[string]string{"node": "node-1"}, &result, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, true, result["compressed"])
+	})
+}
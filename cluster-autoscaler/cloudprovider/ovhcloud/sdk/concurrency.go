@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithMaxConcurrentCalls limits the number of calls of the client running at the same time,
+// the next calls waiting for one of them to complete
+func WithMaxConcurrentCalls(maxCalls int) ClientOption {
+	return func(client *Client) error {
+		if maxCalls < 1 {
+			return fmt.Errorf("at least 1 concurrent call should be allowed, got %d", maxCalls)
+		}
+
+		client.callSlots = make(chan struct{}, maxCalls)
+		return nil
+	}
+}
+
+// acquireCallSlot waits for a call to be allowed to run, returning the function releasing its slot
+func (c *Client) acquireCallSlot(ctx context.Context) (func(), error) {
+	if c.callSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.callSlots <- struct{}{}:
+		return func() { <-c.callSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CallAPIWithMaxConcurrentCalls(t *testing.T) {
+	var running, maxRunning int
+	var mutex sync.Mutex
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	assert.NoError(t, WithMaxConcurrentCalls(2)(client))
+
+	t.Run("check calls above the limit wait for a running one", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 2, maxRunning)
+	})
+
+	t.Run("check waiting stops with the context", func(t *testing.T) {
+		assert.NoError(t, WithMaxConcurrentCalls(1)(client))
+		client.callSlots <- struct{}{}
+		defer func() { <-client.callSlots }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("check invalid limits are rejected", func(t *testing.T) {
+		assert.Error(t, WithMaxConcurrentCalls(0)(client))
+	})
+}
@@ -17,8 +17,14 @@ limitations under the License.
 package sdk
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 // Use variables for easier test overload
@@ -28,21 +34,75 @@ var (
 	localConfigPath  = "./ovh.conf"
 )
 
+// Environment variables used when the client parameters are empty
+const (
+	EnvURL       = "VKE_URL"
+	EnvAppKey    = "VKE_APP_KEY"
+	EnvAppSecret = "VKE_APP_SECRET"
+	EnvTimeout   = "VKE_TIMEOUT"
+)
+
+// configSection is the section of the configuration files read by the client
+const configSection = "default"
+
+// WithConfigFile makes the client read its configuration file from path,
+// instead of looking for one in the local, user and system paths
+func WithConfigFile(path string) ClientOption {
+	return func(client *Client) error {
+		client.ConfigFile = path
+		return nil
+	}
+}
+
 // loadConfig loads client configuration from params, environments or configuration
 // files (by order of decreasing precedence).
 //
-// loadConfig will check OVH_CONSUMER_KEY, OVH_APPLICATION_KEY, OVH_APPLICATION_SECRET
-// and OVH_ENDPOINT environment variables. If any is present, it will take precedence
-// over any configuration from file.
+// The endpoint, application key and application secret given to the constructor are
+// used first. When empty, loadConfig falls back on the VKE_URL, VKE_APP_KEY and
+// VKE_APP_SECRET environment variables, then on the endpoint, application_key and
+// application_secret keys of the [default] section of the configuration files.
+// The consumer key may also be read from their consumer_key key. The configuration
+// files are only read when one of these settings is still missing.
 //
-// Configuration files are ini files. They share the same format as python-ovh,
-// node-ovh, php-ovh and all other wrappers. If any wrapper is configured, all
-// can re-use the same configuration. loadConfig will check for configuration in:
+// Configuration files are ini files. The client ConfigFile is read when set, otherwise
+// loadConfig merges, by decreasing precedence:
 //
 // - ./ovh.conf
 // - $HOME/.ovh.conf
 // - /etc/ovh.conf
+//
+// VKE_TIMEOUT, a Go duration such as "30s", overrides DefaultTimeout.
 func (c *Client) loadConfig(endpointName string) error {
+	if endpointName == "" {
+		endpointName = os.Getenv(EnvURL)
+	}
+	if c.AppKey == "" {
+		c.AppKey = os.Getenv(EnvAppKey)
+	}
+	if c.AppSecret == "" {
+		c.AppSecret = os.Getenv(EnvAppSecret)
+	}
+
+	if endpointName == "" || c.AppKey == "" || c.AppSecret == "" || c.ConsumerKey == "" {
+		file, err := c.readConfigFiles()
+		if err != nil {
+			return err
+		}
+
+		endpointName = firstNonEmpty(endpointName, file["endpoint"])
+		c.AppKey = firstNonEmpty(c.AppKey, file["application_key"])
+		c.AppSecret = firstNonEmpty(c.AppSecret, file["application_secret"])
+		c.ConsumerKey = firstNonEmpty(c.ConsumerKey, file["consumer_key"])
+	}
+
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s duration %q: %w", EnvTimeout, value, err)
+		}
+		c.Timeout = timeout
+	}
+
 	// Load real endpoint URL by name. If endpoint contains a '/', consider it as a URL
 	if strings.Contains(endpointName, "/") {
 		c.endpoint = endpointName
@@ -63,3 +123,86 @@ func (c *Client) loadConfig(endpointName string) error {
 
 	return nil
 }
+
+// readConfigFiles returns the settings of the configuration files, the local file overriding the user and system ones
+func (c *Client) readConfigFiles() (map[string]string, error) {
+	if c.ConfigFile != "" {
+		return readConfigFile(c.ConfigFile)
+	}
+
+	paths := []string{systemConfigPath}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, userConfigPath))
+	}
+	paths = append(paths, localConfigPath)
+
+	settings := make(map[string]string)
+	for _, path := range paths {
+		file, err := readConfigFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range file {
+			settings[key] = value
+		}
+	}
+
+	return settings, nil
+}
+
+// readConfigFile returns the keys of the [default] section of an ini file. Only the lines of this section are
+// checked, the other sections being left to the other tools sharing the file.
+func readConfigFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	settings := make(map[string]string)
+	section := ""
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			continue
+		}
+
+		if section != configSection {
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %d of configuration file %s: %q", line, path, text)
+		}
+
+		settings[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %s: %w", path, err)
+	}
+
+	return settings, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_loadConfig(t *testing.T) {
+	t.Run("check constructor arguments take precedence over environment", func(t *testing.T) {
+		t.Setenv(EnvURL, "https://env.example.com/1.0")
+		t.Setenv(EnvAppKey, "env_key")
+		t.Setenv(EnvAppSecret, "env_secret")
+
+		client, err := NewClient(OvhEU, "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, OvhEU, client.endpoint)
+		assert.Equal(t, "key", client.AppKey)
+		assert.Equal(t, "secret", client.AppSecret)
+	})
+
+	t.Run("check environment is used when arguments are empty", func(t *testing.T) {
+		t.Setenv(EnvURL, "https://env.example.com/1.0")
+		t.Setenv(EnvAppKey, "env_key")
+		t.Setenv(EnvAppSecret, "env_secret")
+
+		client, err := NewClient("", "", "", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://env.example.com/1.0", client.endpoint)
+		assert.Equal(t, "env_key", client.AppKey)
+		assert.Equal(t, "env_secret", client.AppSecret)
+		assert.Equal(t, DefaultTimeout, client.Timeout)
+	})
+
+	t.Run("check environment endpoint may be a name", func(t *testing.T) {
+		t.Setenv(EnvURL, "ovh-ca")
+
+		client, err := NewClient("", "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, OvhCA, client.endpoint)
+	})
+
+	t.Run("check missing settings fail", func(t *testing.T) {
+		t.Setenv(EnvAppKey, "")
+		t.Setenv(EnvAppSecret, "env_secret")
+
+		_, err := NewClient(OvhEU, "", "", "consumer_key")
+		assert.ErrorContains(t, err, "missing application key")
+
+		t.Setenv(EnvURL, "")
+		_, err = NewDefaultClient()
+		assert.ErrorContains(t, err, "unknown endpoint")
+	})
+
+	t.Run("check timeout is overridden by environment", func(t *testing.T) {
+		t.Setenv(EnvTimeout, "45s")
+
+		client, err := NewClient(OvhEU, "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, 45*time.Second, client.Timeout)
+
+		t.Setenv(EnvTimeout, "45")
+		_, err = NewClient(OvhEU, "key", "secret", "consumer_key")
+		assert.ErrorContains(t, err, "invalid VKE_TIMEOUT duration")
+	})
+}
+
+// useTestConfigPaths makes the configuration files be searched in a temporary directory,
+// returning the paths of the local, user and system files
+func useTestConfigPaths(t *testing.T) (string, string, string) {
+	dir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	previousSystem, previousUser, previousLocal := systemConfigPath, userConfigPath, localConfigPath
+	t.Cleanup(func() {
+		systemConfigPath, userConfigPath, localConfigPath = previousSystem, previousUser, previousLocal
+	})
+
+	systemConfigPath = filepath.Join(dir, "etc", "ovh.conf")
+	localConfigPath = filepath.Join(dir, "ovh.conf")
+	userConfigPath = "/.ovh.conf"
+
+	for _, path := range []string{systemConfigPath, filepath.Join(dir, "home", userConfigPath)} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	}
+
+	return localConfigPath, filepath.Join(dir, "home", userConfigPath), systemConfigPath
+}
+
+func writeTestConfigFile(t *testing.T, path string, content string) {
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestClient_loadConfigFiles(t *testing.T) {
+	t.Run("check credentials are read from the default section", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, `
+; comment
+[default]
+endpoint = ovh-ca
+application_key = file_key
+application_secret = "file_secret"
+consumer_key = file_consumer_key
+
+[ovh-eu]
+application_key = other_key
+`)
+
+		client, err := NewClient("", "", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, OvhCA, client.endpoint)
+		assert.Equal(t, "file_key", client.AppKey)
+		assert.Equal(t, "file_secret", client.AppSecret)
+		assert.Equal(t, "file_consumer_key", client.ConsumerKey)
+	})
+
+	t.Run("check local file takes precedence over user and system files", func(t *testing.T) {
+		local, user, system := useTestConfigPaths(t)
+		writeTestConfigFile(t, system, "[default]\nendpoint=ovh-us\napplication_key=system_key\napplication_secret=system_secret\n")
+		writeTestConfigFile(t, user, "[default]\napplication_key=user_key\napplication_secret=user_secret\n")
+		writeTestConfigFile(t, local, "[default]\napplication_secret=local_secret\n")
+
+		client, err := NewClient("", "", "", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, OvhUS, client.endpoint)
+		assert.Equal(t, "user_key", client.AppKey)
+		assert.Equal(t, "local_secret", client.AppSecret)
+	})
+
+	t.Run("check partial files are completed by environment and arguments", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, "[default]\nendpoint=ovh-ca\napplication_key=file_key\napplication_secret=file_secret\n")
+		t.Setenv(EnvAppSecret, "env_secret")
+
+		client, err := NewClient(OvhEU, "", "", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, OvhEU, client.endpoint)
+		assert.Equal(t, "file_key", client.AppKey)
+		assert.Equal(t, "env_secret", client.AppSecret)
+	})
+
+	t.Run("check configuration file can be given", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, "[default]\napplication_key=local_key\n")
+
+		path := filepath.Join(t.TempDir(), "vke.conf")
+		writeTestConfigFile(t, path, "[default]\napplication_key=given_key\napplication_secret=given_secret\n")
+
+		client, err := NewClient(OvhEU, "", "", "consumer_key", WithConfigFile(path))
+		assert.NoError(t, err)
+		assert.Equal(t, path, client.ConfigFile)
+		assert.Equal(t, "given_key", client.AppKey)
+
+		_, err = NewClient(OvhEU, "", "", "consumer_key", WithConfigFile(path+".missing"))
+		assert.ErrorContains(t, err, "failed to open configuration file")
+	})
+
+	t.Run("check invalid files fail", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, "[default]\napplication_key\n")
+
+		_, err := NewClient(OvhEU, "", "secret", "consumer_key")
+		assert.ErrorContains(t, err, "invalid line 2")
+	})
+
+	t.Run("check invalid lines of other sections are ignored", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, "[other]\nflag\n[default]\napplication_key=file_key\n")
+
+		client, err := NewClient(OvhEU, "", "secret", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, "file_key", client.AppKey)
+	})
+
+	t.Run("check files are not read when every setting is given", func(t *testing.T) {
+		local, _, _ := useTestConfigPaths(t)
+		writeTestConfigFile(t, local, "[default]\napplication_key\n")
+
+		client, err := NewClient(OvhEU, "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+		assert.Equal(t, "key", client.AppKey)
+	})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCooldownPeriod is the default delay between two mutations of a node pool
+const DefaultCooldownPeriod = 60 * time.Second
+
+// ErrCooldownActive is returned when mutating a node pool too soon after its last mutation,
+// the API rejecting rapid successive updates of a node pool
+type ErrCooldownActive struct {
+	PoolID string
+
+	// RetryAfter is when the node pool may be mutated again
+	RetryAfter time.Time
+}
+
+func (e *ErrCooldownActive) Error() string {
+	return fmt.Sprintf("node pool %s was mutated recently, retry after %s", e.PoolID, e.RetryAfter.Format(time.RFC3339))
+}
+
+// IsCooldownActive returns whether the error is due to a node pool being mutated too soon after its last mutation
+func IsCooldownActive(err error) bool {
+	var cooldown *ErrCooldownActive
+	return errors.As(err, &cooldown)
+}
+
+// CooldownManager tracks the last mutation of the node pools, to space out their mutations by CooldownPeriod.
+// It is safe for concurrent use, and a nil manager allows every mutation.
+type CooldownManager struct {
+	CooldownPeriod time.Duration
+
+	clock        Clock
+	lastMutation map[string]time.Time
+	mutex        sync.Mutex
+}
+
+// NewCooldownManager creates a cooldown manager spacing out the mutations of the node pools by period
+func NewCooldownManager(period time.Duration, clock Clock) *CooldownManager {
+	return &CooldownManager{
+		CooldownPeriod: period,
+		clock:          clock,
+		lastMutation:   make(map[string]time.Time),
+	}
+}
+
+// Allow returns whether the last mutation of the node pool was more than CooldownPeriod ago
+func (m *CooldownManager) Allow(poolID string) bool {
+	return m.check(poolID) == nil
+}
+
+// Record sets the last mutation of the node pool to now
+func (m *CooldownManager) Record(poolID string) {
+	if m == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.lastMutation[poolID] = m.clock.Now()
+}
+
+// reserve records a mutation of the node pool now, or returns an ErrCooldownActive when it may not be mutated yet.
+// Checking and recording under the same lock, concurrent mutations of a node pool may not all be allowed.
+// The returned release undoes the reservation, for a failed mutation not to delay the next one.
+func (m *CooldownManager) reserve(poolID string) (release func(), err error) {
+	if m == nil {
+		return func() {}, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err := m.checkLocked(poolID); err != nil {
+		return nil, err
+	}
+
+	previous, mutated := m.lastMutation[poolID]
+	reserved := m.clock.Now()
+	m.lastMutation[poolID] = reserved
+
+	return func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		// The node pool was mutated since, its mutation is kept
+		if !m.lastMutation[poolID].Equal(reserved) {
+			return
+		}
+
+		if mutated {
+			m.lastMutation[poolID] = previous
+		} else {
+			delete(m.lastMutation, poolID)
+		}
+	}, nil
+}
+
+// check returns an ErrCooldownActive when the node pool may not be mutated yet
+func (m *CooldownManager) check(poolID string) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.checkLocked(poolID)
+}
+
+// checkLocked is check, the lock being held
+func (m *CooldownManager) checkLocked(poolID string) error {
+	last, ok := m.lastMutation[poolID]
+	if !ok {
+		return nil
+	}
+
+	retryAfter := last.Add(m.CooldownPeriod)
+	if !m.clock.Now().Before(retryAfter) {
+		return nil
+	}
+
+	return &ErrCooldownActive{PoolID: poolID, RetryAfter: retryAfter}
+}
+
+// WithCooldown makes UpdateNodePool and PatchNodePool return an ErrCooldownActive when a node pool
+// was mutated less than period ago, DefaultCooldownPeriod being used when period is zero
+func WithCooldown(period time.Duration) ClientOption {
+	return func(client *Client) error {
+		if period < 0 {
+			return fmt.Errorf("cooldown period should not be negative, got %s", period)
+		}
+		if period == 0 {
+			period = DefaultCooldownPeriod
+		}
+
+		client.cooldownPeriod = period
+		return nil
+	}
+}
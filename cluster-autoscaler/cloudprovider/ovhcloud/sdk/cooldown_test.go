@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCooldownManager(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	manager := NewCooldownManager(time.Minute, clock)
+
+	t.Run("check pools never mutated are allowed", func(t *testing.T) {
+		assert.True(t, manager.Allow("pool-1"))
+	})
+
+	t.Run("check mutations are spaced out by the cooldown period", func(t *testing.T) {
+		manager.Record("pool-1")
+		assert.False(t, manager.Allow("pool-1"))
+		assert.True(t, manager.Allow("pool-2"))
+
+		err := manager.check("pool-1")
+		assert.Equal(t, &ErrCooldownActive{PoolID: "pool-1", RetryAfter: clock.Now().Add(time.Minute)}, err)
+		assert.True(t, IsCooldownActive(err))
+
+		clock.Advance(59 * time.Second)
+		assert.False(t, manager.Allow("pool-1"))
+
+		clock.Advance(time.Second)
+		assert.True(t, manager.Allow("pool-1"))
+	})
+
+	t.Run("check concurrent mutations of a pool are not all allowed", func(t *testing.T) {
+		var allowed int
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := manager.reserve("pool-3"); err == nil {
+					mutex.Lock()
+					allowed++
+					mutex.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, allowed)
+	})
+
+	t.Run("check released reservations do not start the cooldown", func(t *testing.T) {
+		release, err := manager.reserve("pool-4")
+		assert.NoError(t, err)
+		assert.False(t, manager.Allow("pool-4"))
+
+		release()
+		assert.True(t, manager.Allow("pool-4"))
+	})
+
+	t.Run("check nil managers allow every mutation", func(t *testing.T) {
+		var disabled *CooldownManager
+		disabled.Record("pool-1")
+		assert.True(t, disabled.Allow("pool-1"))
+
+		release, err := disabled.reserve("pool-1")
+		assert.NoError(t, err)
+		release()
+	})
+}
+
+func TestWithCooldown(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "poolID"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(StaticTokenProvider("token")), WithClock(clock), WithCooldown(0))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCooldownPeriod, client.Cooldown.CooldownPeriod)
+
+	ctx := context.Background()
+	desired := uint32(3)
+
+	t.Run("check failed updates do not start the cooldown", func(t *testing.T) {
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{DesiredNodes: &desired})
+		assert.Error(t, err)
+		assert.False(t, IsCooldownActive(err))
+
+		_, err = client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{DesiredNodes: &desired})
+		assert.NoError(t, err)
+	})
+
+	t.Run("check updates are rejected during the cooldown", func(t *testing.T) {
+		_, err := client.PatchNodePool(ctx, "projectID", "clusterID", "poolID", &PatchNodePoolOpts{DesiredNodes: &desired})
+
+		var cooldown *ErrCooldownActive
+		assert.True(t, errors.As(err, &cooldown))
+		assert.Equal(t, clock.Now().Add(DefaultCooldownPeriod), cooldown.RetryAfter)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("check updates are allowed after the cooldown", func(t *testing.T) {
+		clock.Advance(DefaultCooldownPeriod)
+
+		_, err := client.PatchNodePool(ctx, "projectID", "clusterID", "poolID", &PatchNodePoolOpts{DesiredNodes: &desired})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("check negative periods are rejected", func(t *testing.T) {
+		_, err := NewClient(server.URL, "none", "none", "none", WithCooldown(-time.Second))
+		assert.Error(t, err)
+	})
+}
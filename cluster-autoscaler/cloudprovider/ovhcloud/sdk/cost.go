@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// FlavorPricingCacheTTL is how long the price of a flavor is kept, as it rarely changes
+const FlavorPricingCacheTTL = 24 * time.Hour
+
+type flavorPricingKey struct {
+	clusterID string
+	flavor    string
+}
+
+type flavorPricingEntry struct {
+	pricing   FlavorPricing
+	expiresAt time.Time
+}
+
+// CostBreakdown details the hourly cost of a node pool
+type CostBreakdown struct {
+	ComputePerHour float64 `json:"computePerHour"`
+	StoragePerHour float64 `json:"storagePerHour"`
+	NetworkPerHour float64 `json:"networkPerHour"`
+	TotalPerHour   float64 `json:"totalPerHour"`
+	Currency       string  `json:"currency"`
+}
+
+// GetNodePoolCostBreakdown allows to display the itemised cost of a node pool
+func (c *Client) GetNodePoolCostBreakdown(ctx context.Context, projectID string, clusterID string, poolID string) (*CostBreakdown, error) {
+	cost := &CostBreakdown{}
+
+	return cost, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/cost", projectID, clusterID, poolID),
+		nil,
+		&cost,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// GetClusterTotalCost sums the cost breakdowns of all the node pools of a cluster
+func (c *Client) GetClusterTotalCost(ctx context.Context, projectID string, clusterID string) (*CostBreakdown, error) {
+	pools, err := c.ListNodePools(ctx, projectID, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node pools: %w", err)
+	}
+
+	total := &CostBreakdown{}
+	for _, pool := range pools {
+		cost, err := c.GetNodePoolCostBreakdown(ctx, projectID, clusterID, pool.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cost of node pool %s: %w", pool.ID, err)
+		}
+
+		if total.Currency != "" && cost.Currency != total.Currency {
+			return nil, fmt.Errorf("node pool %s cost is in %s while other pools are in %s", pool.ID, cost.Currency, total.Currency)
+		}
+
+		total.ComputePerHour += cost.ComputePerHour
+		total.StoragePerHour += cost.StoragePerHour
+		total.NetworkPerHour += cost.NetworkPerHour
+		total.TotalPerHour += cost.TotalPerHour
+		total.Currency = cost.Currency
+	}
+
+	return total, nil
+}
+
+// CompareCostBreakdowns returns the percentage difference of b total cost relative to a,
+// e.g. 25 when b costs a quarter more than a. It returns +Inf when only a is free.
+func CompareCostBreakdowns(a, b *CostBreakdown) float64 {
+	if a.TotalPerHour == 0 {
+		if b.TotalPerHour == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+
+	return (b.TotalPerHour - a.TotalPerHour) / a.TotalPerHour * 100
+}
+
+// EstimateNodePoolCostPerHour returns the hourly cost of desiredNodes nodes of a node pool, and its currency,
+// from the price of the pool flavor. When the price cannot be read, a warning is logged and a zero cost
+// with no currency is returned without error, so that the estimation never blocks a scale up.
+func (c *Client) EstimateNodePoolCostPerHour(ctx context.Context, projectID string, clusterID string, poolID string, desiredNodes int) (float64, string, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	pricing, err := c.cachedFlavorPricing(ctx, projectID, clusterID, pool.Flavor)
+	if err != nil {
+		klog.Warningf("Failed to get price of flavor %s, cannot estimate cost of node pool %s: %v", pool.Flavor, poolID, err)
+		return 0, "", nil
+	}
+
+	return pricing.HourlyPrice * float64(desiredNodes), pricing.Currency, nil
+}
+
+// cachedFlavorPricing returns the price of a flavor, read from the API at most once every FlavorPricingCacheTTL
+func (c *Client) cachedFlavorPricing(ctx context.Context, projectID string, clusterID string, flavor string) (*FlavorPricing, error) {
+	key := flavorPricingKey{clusterID: clusterID, flavor: flavor}
+
+	c.pricingMutex.Lock()
+	entry, ok := c.pricingCache[key]
+	c.pricingMutex.Unlock()
+
+	if ok && c.now().Before(entry.expiresAt) {
+		return &entry.pricing, nil
+	}
+
+	pricing, err := c.GetFlavorPricing(ctx, projectID, clusterID, flavor)
+	if err != nil {
+		return nil, err
+	}
+
+	c.pricingMutex.Lock()
+	defer c.pricingMutex.Unlock()
+
+	if c.pricingCache == nil {
+		c.pricingCache = make(map[flavorPricingKey]flavorPricingEntry)
+	}
+	c.pricingCache[key] = flavorPricingEntry{pricing: *pricing, expiresAt: c.now().Add(FlavorPricingCacheTTL)}
+
+	return pricing, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCostTestClient(t *testing.T, secondCurrency string) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "pool-1"}, {"id": "pool-2"}]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-1/cost", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"computePerHour": 0.5, "storagePerHour": 0.125, "networkPerHour": 0.125, "totalPerHour": 0.75, "currency": "EUR"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-2/cost", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"computePerHour": 1, "storagePerHour": 0.25, "networkPerHour": 0, "totalPerHour": 1.25, "currency": "` + secondCurrency + `"}`))
+	})
+
+	return newTestClient(t, mux)
+}
+
+func TestClient_GetNodePoolCostBreakdown(t *testing.T) {
+	client := newCostTestClient(t, "EUR")
+
+	cost, err := client.GetNodePoolCostBreakdown(context.Background(), "projectID", "clusterID", "pool-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &CostBreakdown{ComputePerHour: 0.5, StoragePerHour: 0.125, NetworkPerHour: 0.125, TotalPerHour: 0.75, Currency: "EUR"}, cost)
+}
+
+func TestClient_GetClusterTotalCost(t *testing.T) {
+	t.Run("check costs are summed", func(t *testing.T) {
+		client := newCostTestClient(t, "EUR")
+
+		cost, err := client.GetClusterTotalCost(context.Background(), "projectID", "clusterID")
+		assert.NoError(t, err)
+		assert.Equal(t, &CostBreakdown{ComputePerHour: 1.5, StoragePerHour: 0.375, NetworkPerHour: 0.125, TotalPerHour: 2, Currency: "EUR"}, cost)
+	})
+
+	t.Run("check mixed currencies are rejected", func(t *testing.T) {
+		client := newCostTestClient(t, "USD")
+
+		_, err := client.GetClusterTotalCost(context.Background(), "projectID", "clusterID")
+		assert.Error(t, err)
+	})
+}
+
+func TestCompareCostBreakdowns(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     float64
+		expected float64
+	}{
+		{name: "more expensive", a: 2, b: 2.5, expected: 25},
+		{name: "cheaper", a: 2, b: 1.5, expected: -25},
+		{name: "same cost", a: 0.75, b: 0.75, expected: 0},
+		{name: "both free", a: 0, b: 0, expected: 0},
+		{name: "from free", a: 0, b: 1, expected: math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff := CompareCostBreakdowns(&CostBreakdown{TotalPerHour: test.a}, &CostBreakdown{TotalPerHour: test.b})
+			assert.Equal(t, test.expected, diff)
+		})
+	}
+}
+
+func TestClient_EstimateNodePoolCostPerHour(t *testing.T) {
+	ctx := context.Background()
+
+	pricingCalls := 0
+	pricingAvailable := true
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "flavor": "b2-7"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/flavors/b2-7/pricing", func(w http.ResponseWriter, r *http.Request) {
+		pricingCalls++
+		if !pricingAvailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"flavor": "b2-7", "currency": "EUR", "hourlyPrice": 0.0681}`))
+	})
+	client := newTestClient(t, mux)
+	clock := NewFakeClock(time.Now())
+	client.clock = clock
+
+	t.Run("check cost is the flavor price of the desired nodes", func(t *testing.T) {
+		cost, currency, err := client.EstimateNodePoolCostPerHour(ctx, "projectID", "clusterID", "poolID", 3)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.2043, cost, 1e-9)
+		assert.Equal(t, "EUR", currency)
+	})
+
+	t.Run("check price is cached for a day", func(t *testing.T) {
+		_, _, err := client.EstimateNodePoolCostPerHour(ctx, "projectID", "clusterID", "poolID", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pricingCalls)
+
+		clock.Advance(FlavorPricingCacheTTL)
+		_, _, err = client.EstimateNodePoolCostPerHour(ctx, "projectID", "clusterID", "poolID", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pricingCalls)
+	})
+
+	t.Run("check unavailable pricing does not fail the estimation", func(t *testing.T) {
+		pricingAvailable = false
+		clock.Advance(FlavorPricingCacheTTL)
+
+		cost, currency, err := client.EstimateNodePoolCostPerHour(ctx, "projectID", "clusterID", "poolID", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(0), cost)
+		assert.Equal(t, "", currency)
+	})
+}
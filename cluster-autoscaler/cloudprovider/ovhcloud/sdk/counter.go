@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// requestKey identifies the requests counted together, the path being a route template
+type requestKey struct {
+	method string
+	path   string
+}
+
+// RequestCounter counts the API requests performed by a client since its creation, or its last reset.
+// It is safe for concurrent use.
+type RequestCounter struct {
+	counts map[requestKey]int64
+	total  int64
+	mutex  sync.RWMutex
+}
+
+// NewRequestCounter creates an empty request counter
+func NewRequestCounter() *RequestCounter {
+	return &RequestCounter{
+		counts: make(map[requestKey]int64),
+	}
+}
+
+// Increment counts a request under the route of its path, see routeTemplate. It does nothing on a nil counter.
+func (rc *RequestCounter) Increment(method string, path string) {
+	if rc == nil {
+		return
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.counts[requestKey{method: method, path: routeTemplate(path)}]++
+	rc.total++
+}
+
+// Total returns the number of requests counted
+func (rc *RequestCounter) Total() int64 {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	return rc.total
+}
+
+// ByMethod returns the number of requests counted for an HTTP method
+func (rc *RequestCounter) ByMethod(method string) int64 {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	var count int64
+	for key, value := range rc.counts {
+		if key.method == method {
+			count += value
+		}
+	}
+
+	return count
+}
+
+// Reset forgets the requests counted so far
+func (rc *RequestCounter) Reset() {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.counts = make(map[requestKey]int64)
+	rc.total = 0
+}
+
+// snapshot returns a copy of the counts
+func (rc *RequestCounter) snapshot() map[requestKey]int64 {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	counts := make(map[requestKey]int64, len(rc.counts))
+	for key, value := range rc.counts {
+		counts[key] = value
+	}
+
+	return counts
+}
+
+var requestsTotalDesc = prometheus.NewDesc(
+	"vke_sdk_requests_total",
+	"Number of OVHcloud API requests performed by the autoscaler, by method and route",
+	[]string{"method", "path"},
+	nil,
+)
+
+// requestCounterCollector exposes a request counter as a Prometheus counter
+type requestCounterCollector struct {
+	counter *RequestCounter
+}
+
+// Describe implements prometheus.Collector
+func (c requestCounterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- requestsTotalDesc
+}
+
+// Collect implements prometheus.Collector
+func (c requestCounterCollector) Collect(ch chan<- prometheus.Metric) {
+	for key, value := range c.counter.snapshot() {
+		ch <- prometheus.MustNewConstMetric(requestsTotalDesc, prometheus.CounterValue, float64(value), key.method, key.path)
+	}
+}
+
+// ExportCounterMetrics registers the request counter of the client as the vke_sdk_requests_total metric
+func (c *Client) ExportCounterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(requestCounterCollector{counter: c.RequestCounter})
+}
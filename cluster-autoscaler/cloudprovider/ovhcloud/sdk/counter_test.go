@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RequestCounter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	client := newTestClient(t, mux)
+
+	methods := []string{"GET", "PUT", "POST", "DELETE"}
+	expected := make(map[string]*int64, len(methods))
+	for _, method := range methods {
+		expected[method] = new(int64)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+
+			err := client.CallAPIWithContext(context.Background(), method, "/resource", nil, nil, nil, nil, true)
+			assert.NoError(t, err)
+			atomic.AddInt64(expected[method], 1)
+		}(methods[i%len(methods)])
+	}
+	wg.Wait()
+
+	t.Run("check requests are counted by method", func(t *testing.T) {
+		var total int64
+		for _, method := range methods {
+			assert.Equal(t, atomic.LoadInt64(expected[method]), client.RequestCounter.ByMethod(method), method)
+			total += atomic.LoadInt64(expected[method])
+		}
+
+		assert.Equal(t, total, client.RequestCounter.Total())
+		assert.Equal(t, int64(0), client.RequestCounter.ByMethod("PATCH"))
+	})
+
+	t.Run("check counts are exported", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		assert.NoError(t, client.ExportCounterMetrics(reg))
+
+		families, err := reg.Gather()
+		assert.NoError(t, err)
+		assert.Len(t, families, 1)
+		assert.Equal(t, "vke_sdk_requests_total", families[0].GetName())
+		assert.Len(t, families[0].GetMetric(), len(methods))
+
+		for _, metric := range families[0].GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			assert.Equal(t, "/resource", labels["path"])
+			assert.Equal(t, float64(10), metric.GetCounter().GetValue(), labels["method"])
+		}
+	})
+
+	t.Run("check requests are counted by route", func(t *testing.T) {
+		counter := NewRequestCounter()
+		counter.Increment("GET", "/cloud/project/projectID/kube/clusterID/nodepool/pool-1")
+		counter.Increment("GET", "/cloud/project/projectID/kube/clusterID/nodepool/pool-2")
+
+		assert.Equal(t, map[requestKey]int64{
+			{method: "GET", path: "/cloud/project/{id}/kube/{id}/nodepool/{id}"}: 2,
+		}, counter.snapshot())
+	})
+
+	t.Run("check counter reset", func(t *testing.T) {
+		client.RequestCounter.Reset()
+		assert.Equal(t, int64(0), client.RequestCounter.Total())
+		assert.Equal(t, int64(0), client.RequestCounter.ByMethod("GET"))
+	})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+
+	"k8s.io/klog/v2"
+)
+
+// DryRunEntry defines a mutating request which would have been sent if the client was not in dry-run mode
+type DryRunEntry struct {
+	Method string
+	URL    string
+	Body   interface{}
+}
+
+// recordDryRun logs and records a request not sent because of the dry-run mode
+func (c *Client) recordDryRun(method string, path string, body interface{}) {
+	entry := DryRunEntry{
+		Method: method,
+		URL:    c.endpoint + path,
+		Body:   body,
+	}
+
+	if klog.V(1).Enabled() {
+		payload, _ := json.Marshal(body)
+		klog.Infof("%s %s %s (dry run)", entry.Method, entry.URL, payload)
+	}
+
+	c.dryRunMutex.Lock()
+	defer c.dryRunMutex.Unlock()
+
+	c.DryRunRecord = append(c.DryRunRecord, entry)
+}
+
+// dryRunNodePool returns the node pool as it would be once updated with opts, starting from its cached version if any
+func (c *Client) dryRunNodePool(clusterID string, poolID string, opts *UpdateNodePoolOpts) *NodePool {
+	pool, ok := c.NodePoolCache.Get(clusterID, poolID)
+	if !ok {
+		pool = &NodePool{ID: poolID}
+	}
+
+	if opts == nil {
+		return pool
+	}
+
+	if opts.DesiredNodes != nil {
+		pool.DesiredNodes = *opts.DesiredNodes
+	}
+	if opts.MinNodes != nil {
+		pool.MinNodes = *opts.MinNodes
+	}
+	if opts.MaxNodes != nil {
+		pool.MaxNodes = *opts.MaxNodes
+	}
+	if opts.Autoscale != nil {
+		pool.Autoscale = *opts.Autoscale
+	}
+	if opts.Template != nil {
+		pool.Template = *opts.Template
+	}
+
+	return pool
+}
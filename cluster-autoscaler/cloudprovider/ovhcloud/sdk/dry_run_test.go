@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	mutations := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			mutations++
+		}
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "workers", "desiredNodes": 2, "minNodes": 1, "maxNodes": 5}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/node/", func(w http.ResponseWriter, r *http.Request) {
+		mutations++
+	})
+	client := newTestClient(t, mux)
+	client.DryRun = true
+
+	ctx := context.Background()
+
+	t.Run("check scale is recorded instead of sent", func(t *testing.T) {
+		pool, err := client.ScaleNodePoolToSize(ctx, "projectID", "clusterID", "poolID", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, "workers", pool.Name)
+		assert.Equal(t, uint32(4), pool.DesiredNodes)
+		assert.Equal(t, uint32(5), pool.MaxNodes)
+
+		size := uint32(4)
+		assert.Equal(t, []DryRunEntry{{
+			Method: "PUT",
+			URL:    client.endpoint + "/cloud/project/projectID/kube/clusterID/nodepool/poolID",
+			Body:   &UpdateNodePoolOpts{DesiredNodes: &size},
+		}}, client.DryRunRecord)
+	})
+
+	t.Run("check node deletion is recorded instead of sent", func(t *testing.T) {
+		err := client.DeleteNode(ctx, "projectID", "clusterID", "nodeID")
+		assert.NoError(t, err)
+		assert.Len(t, client.DryRunRecord, 2)
+		assert.Equal(t, "DELETE", client.DryRunRecord[1].Method)
+		assert.Equal(t, client.endpoint+"/cloud/project/projectID/kube/clusterID/node/nodeID", client.DryRunRecord[1].URL)
+	})
+
+	assert.Equal(t, 0, mutations)
+}
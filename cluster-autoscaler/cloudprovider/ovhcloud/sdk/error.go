@@ -20,25 +20,103 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const canadianTenantSyncErrorMessage = "Internal Server Error"
 
+// Machine-readable codes of the API errors
+const (
+	// ErrorCodeQuotaExceeded is the code of the errors due to a quota being reached
+	ErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
+	// ErrorCodeNodeGroupNotFound is the code of the errors due to an unknown node pool
+	ErrorCodeNodeGroupNotFound = "NODE_GROUP_NOT_FOUND"
+	// ErrorCodeRateLimited is the code of the errors due to too many requests
+	ErrorCodeRateLimited = "RATE_LIMITED"
+)
+
 // APIError represents an error that can occurred while calling the API.
 type APIError struct {
 	// Error message.
 	Message string
 	// HTTP code.
 	Code int
+	// Machine-readable code of the error, when given by the API
+	ErrorCode string `json:"errorCode"`
 	// ID of the request
 	QueryID string
+	// ID sent with the request in the X-Request-ID header, to match it with the API logs
+	RequestID string `json:"-"`
+	// How long to wait before calling the API again, given by the Retry-After header
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (err *APIError) Error() string {
 	return fmt.Sprintf("Error %d: %q", err.Code, err.Message)
 }
 
+// IsNotFound returns whether the error is due to the requested resource not existing
+func IsNotFound(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && (apiError.Code == http.StatusNotFound || apiError.ErrorCode == ErrorCodeNodeGroupNotFound)
+}
+
+// ErrNodeGroupNotFound is returned when reading a node pool which does not exist,
+// to tell it apart from a failure of the API
+type ErrNodeGroupNotFound struct {
+	PoolID string
+
+	// Err is the error returned by the API
+	Err error
+}
+
+func (e *ErrNodeGroupNotFound) Error() string {
+	return fmt.Sprintf("node pool %s not found: %v", e.PoolID, e.Err)
+}
+
+func (e *ErrNodeGroupNotFound) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound returns true, the node pool not existing
+func (e *ErrNodeGroupNotFound) IsNotFound() bool {
+	return true
+}
+
+// IsNodeGroupNotFound returns whether the error is due to a node pool not existing
+func IsNodeGroupNotFound(err error) bool {
+	var notFound *ErrNodeGroupNotFound
+	return errors.As(err, &notFound) && notFound.IsNotFound()
+}
+
+// nodeGroupNotFound wraps the not found errors returned when reading a node pool in an ErrNodeGroupNotFound
+func nodeGroupNotFound(poolID string, err error) error {
+	if IsNotFound(err) && !IsNodeGroupNotFound(err) {
+		return &ErrNodeGroupNotFound{PoolID: poolID, Err: err}
+	}
+
+	return err
+}
+
+// IsQuotaExceeded returns whether the error is due to a quota being reached, either reported by the API or by CheckNodePoolQuota
+func IsQuotaExceeded(err error) bool {
+	if errors.Is(err, ErrQuotaExceeded) {
+		return true
+	}
+
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.ErrorCode == ErrorCodeQuotaExceeded
+}
+
+// IsRateLimited returns whether the error is due to too many requests being sent to the API
+func IsRateLimited(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && (apiError.Code == http.StatusTooManyRequests || apiError.ErrorCode == ErrorCodeRateLimited)
+}
+
 type (
 	// Error struct
 	Error struct {
@@ -63,3 +141,43 @@ func IsPossiblyCanadianTenantSyncError(err error, url string) bool {
 		apiError.Code == http.StatusInternalServerError &&
 		apiError.Message == canadianTenantSyncErrorMessage
 }
+
+// regionSyncPathPattern matches the paths of the clusters and of their resources
+var regionSyncPathPattern = regexp.MustCompile(`/cloud/project/[^/]+/kube/[^/?]+`)
+
+// IsRegionSyncError returns whether the given error and URL could be due to a cluster resource not being synchronized yet
+// on the EU or US region shard serving the request: the API answered not found on a cluster path of one of these endpoints,
+// while the resource might exist on another shard.
+func IsRegionSyncError(err error, url string) bool {
+	if !IsNotFound(err) {
+		return false
+	}
+
+	for _, endpoint := range []string{OvhEU, OvhUS, "https://api.ovh.com/1.0"} {
+		if strings.HasPrefix(url, endpoint) {
+			return regionSyncPathPattern.MatchString(strings.TrimPrefix(url, endpoint))
+		}
+	}
+
+	return false
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRegionSyncError(t *testing.T) {
+	notFound := &APIError{Code: http.StatusNotFound, Message: "not found"}
+	clusterPath := "/cloud/project/projectID/kube/clusterID/nodepool/poolID"
+
+	tests := []struct {
+		name     string
+		err      error
+		url      string
+		expected bool
+	}{
+		{name: "not found on a cluster path of the EU endpoint", err: notFound, url: OvhEU + clusterPath, expected: true},
+		{name: "not found on a cluster path of the US endpoint", err: notFound, url: OvhUS + "/cloud/project/projectID/kube/clusterID", expected: true},
+		{name: "wrapped not found on a cluster path", err: fmt.Errorf("failed: %w", notFound), url: OvhEU + clusterPath, expected: true},
+		{name: "not found on a cluster path of the CA endpoint", err: notFound, url: OvhCA + clusterPath, expected: false},
+		{name: "not found on a non cluster path", err: notFound, url: OvhEU + "/cloud/project/projectID/flavor", expected: false},
+		{name: "not found on the cluster list", err: notFound, url: OvhEU + "/cloud/project/projectID/kube", expected: false},
+		{name: "service unavailable on a cluster path", err: &APIError{Code: http.StatusServiceUnavailable}, url: OvhEU + clusterPath, expected: false},
+		{name: "not an API error", err: errors.New("not found"), url: OvhEU + clusterPath, expected: false},
+		{name: "nil error", err: nil, url: OvhEU + clusterPath, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsRegionSyncError(test.err, test.url))
+		})
+	}
+}
+
+func TestAPIErrorPredicates(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		notFound      bool
+		quotaExceeded bool
+		rateLimited   bool
+	}{
+		{name: "not found status", err: &APIError{Code: http.StatusNotFound}, notFound: true},
+		{name: "node group not found code", err: &APIError{Code: http.StatusBadRequest, ErrorCode: ErrorCodeNodeGroupNotFound}, notFound: true},
+		{name: "quota exceeded code", err: fmt.Errorf("failed: %w", &APIError{Code: http.StatusForbidden, ErrorCode: ErrorCodeQuotaExceeded}), quotaExceeded: true},
+		{name: "quota exceeded sentinel", err: fmt.Errorf("%w: requested 1 node(s)", ErrQuotaExceeded), quotaExceeded: true},
+		{name: "too many requests status", err: &APIError{Code: http.StatusTooManyRequests}, rateLimited: true},
+		{name: "rate limited code", err: &APIError{Code: http.StatusForbidden, ErrorCode: ErrorCodeRateLimited}, rateLimited: true},
+		{name: "other API error", err: &APIError{Code: http.StatusInternalServerError}},
+		{name: "not an API error", err: errors.New("not found")},
+		{name: "nil error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.notFound, IsNotFound(test.err))
+			assert.Equal(t, test.quotaExceeded, IsQuotaExceeded(test.err))
+			assert.Equal(t, test.rateLimited, IsRateLimited(test.err))
+		})
+	}
+}
+
+func TestClient_UnmarshalErrorCode(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/quota", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "too many nodes", "errorCode": "QUOTA_EXCEEDED"}`))
+	})
+	client := newTestClient(t, mux)
+
+	err := client.Get("/quota", nil, nil)
+	assert.True(t, IsQuotaExceeded(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func TestClient_NodeGroupNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/unknown", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "node pool not found"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/unknown/nodes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "node pool not found"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/failing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check unknown node pools are not found", func(t *testing.T) {
+		pool, err := client.GetNodePool(context.Background(), "projectID", "clusterID", "unknown")
+		assert.Nil(t, pool)
+		assert.True(t, IsNodeGroupNotFound(err))
+		assert.True(t, IsNotFound(err))
+
+		var notFound *ErrNodeGroupNotFound
+		assert.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "unknown", notFound.PoolID)
+		assert.True(t, notFound.IsNotFound())
+
+		_, err = client.ListNodePoolNodes(context.Background(), "projectID", "clusterID", "unknown")
+		assert.True(t, IsNodeGroupNotFound(err))
+	})
+
+	t.Run("check API failures are told apart", func(t *testing.T) {
+		pool, err := client.GetNodePool(context.Background(), "projectID", "clusterID", "failing")
+		assert.Nil(t, pool)
+		assert.Error(t, err)
+		assert.False(t, IsNodeGroupNotFound(err))
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrConflict is returned when updating a node pool modified since its ETag was read.
+// The node pool should be read again before retrying the update.
+var ErrConflict = errors.New("node pool was modified concurrently")
+
+// responseHeaderKey holds, in the contexts of the requests, where to copy the headers of their response
+type responseHeaderKey struct{}
+
+// withResponseHeader copies the headers of the response of the request made with the returned context to header
+func withResponseHeader(ctx context.Context, header *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, header)
+}
+
+// captureResponseHeader copies the headers of a response when its request context asks for them
+func captureResponseHeader(ctx context.Context, response *http.Response) {
+	if header, ok := ctx.Value(responseHeaderKey{}).(*http.Header); ok && header != nil {
+		*header = response.Header.Clone()
+	}
+}
+
+// ifMatchHeaders returns the headers making a request conditional on the given ETag, none when it is empty
+func ifMatchHeaders(ifMatch string) map[string]interface{} {
+	if ifMatch == "" {
+		return nil
+	}
+
+	return map[string]interface{}{"If-Match": ifMatch}
+}
+
+// conflictError returns ErrConflict, along with the API error, when the request precondition failed
+func conflictError(poolID string, err error) error {
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.Code == http.StatusPreconditionFailed {
+		return fmt.Errorf("failed to update node pool %s: %w: %w", poolID, ErrConflict, err)
+	}
+
+	return err
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newVersionedPoolServer serves a node pool whose ETag changes on every update,
+// rejecting the updates made with an outdated If-Match header
+func newVersionedPoolServer(t *testing.T) (*http.ServeMux, *NodePool) {
+	var mutex sync.Mutex
+	version := 1
+	pool := &NodePool{ID: "poolID", MinNodes: 1, MaxNodes: 5}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		etag := fmt.Sprintf(`"v%d"`, version)
+		if r.Method != "GET" {
+			if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = w.Write([]byte(`{"message": "precondition failed"}`))
+				return
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(pool)
+			version++
+			etag = fmt.Sprintf(`"v%d"`, version)
+		}
+
+		w.Header().Set("ETag", etag)
+		_ = json.NewEncoder(w).Encode(pool)
+	})
+
+	return mux, pool
+}
+
+func TestClient_OptimisticLocking(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check ETag is read with the node pool", func(t *testing.T) {
+		mux, _ := newVersionedPoolServer(t)
+		client := newTestClient(t, mux)
+
+		pool, err := client.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, `"v1"`, pool.ETag)
+	})
+
+	t.Run("check concurrent updates conflict", func(t *testing.T) {
+		mux, pool := newVersionedPoolServer(t)
+
+		// Two instances update the same node pool, each with its own client and cache
+		first := newTestClient(t, mux)
+		second := newTestClient(t, mux)
+
+		firstPool, err := first.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		secondPool, err := second.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		min := uint32(2)
+		_, err = first.PatchNodePool(ctx, "projectID", "clusterID", "poolID", &PatchNodePoolOpts{MinNodes: &min, IfMatch: firstPool.ETag})
+		assert.NoError(t, err)
+
+		max := uint32(10)
+		_, err = second.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{MaxNodes: &max, IfMatch: secondPool.ETag})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, uint32(5), pool.MaxNodes)
+
+		// Read the node pool again, then retry
+		secondPool, err = second.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, `"v2"`, secondPool.ETag)
+		assert.Equal(t, uint32(2), secondPool.MinNodes)
+
+		_, err = second.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{MaxNodes: &max, IfMatch: secondPool.ETag})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), pool.MinNodes)
+		assert.Equal(t, uint32(10), pool.MaxNodes)
+	})
+
+	t.Run("check updates without ETag are not conditional", func(t *testing.T) {
+		mux, _ := newVersionedPoolServer(t)
+		client := newTestClient(t, mux)
+
+		max := uint32(10)
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{MaxNodes: &max})
+		assert.NoError(t, err)
+	})
+}
@@ -29,6 +29,7 @@ type Flavor struct {
 	VCPUs    int    `json:"vCPUs"`
 	GPUs     int    `json:"gpus"`
 	RAM      int    `json:"ram"`
+	Disk     int    `json:"disk"`
 }
 
 // ListClusterFlavors allows to display flavors available for nodes templates
@@ -46,3 +47,43 @@ func (c *Client) ListClusterFlavors(ctx context.Context, projectID string, clust
 		true,
 	)
 }
+
+// GetFlavorInfo allows to get the resources of a flavor available for nodes templates
+func (c *Client) GetFlavorInfo(ctx context.Context, projectID string, clusterID string, flavorName string) (*Flavor, error) {
+	flavors, err := c.ListClusterFlavors(ctx, projectID, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range flavors {
+		if flavors[i].Name == flavorName {
+			return &flavors[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("flavor %s not found in available flavors", flavorName)
+}
+
+// FlavorPricing defines the price of an instance type available on OVHcloud
+type FlavorPricing struct {
+	Flavor       string  `json:"flavor"`
+	Currency     string  `json:"currency"`
+	HourlyPrice  float64 `json:"hourlyPrice"`
+	MonthlyPrice float64 `json:"monthlyPrice"`
+}
+
+// GetFlavorPricing allows to get the price of a flavor usable for nodes templates
+func (c *Client) GetFlavorPricing(ctx context.Context, projectID string, clusterID string, flavorName string) (*FlavorPricing, error) {
+	pricing := &FlavorPricing{}
+
+	return pricing, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/flavors/%s/pricing", projectID, clusterID, flavorName),
+		nil,
+		pricing,
+		nil,
+		nil,
+		true,
+	)
+}
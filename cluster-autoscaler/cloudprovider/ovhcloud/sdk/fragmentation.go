@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeLoad holds the CPU usage of a node, computed from the requests of its pods
+type nodeLoad struct {
+	name        string
+	allocatable int64
+	requested   int64
+	pods        []int64
+}
+
+func (l *nodeLoad) utilisation() float64 {
+	if l.allocatable <= 0 {
+		return 1
+	}
+
+	return float64(l.requested) / float64(l.allocatable)
+}
+
+// GetNodeFragmentationScore measures how scattered the workloads of a node pool are.
+// It returns a score from 0 (all nodes fully packed) to 1 (all nodes empty), computed
+// as the complement of the average CPU requests utilisation of the pool nodes.
+func (c *Client) GetNodeFragmentationScore(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) (float64, error) {
+	poolNodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	if len(poolNodes) == 0 {
+		return 0, nil
+	}
+
+	total := 0.0
+	for _, poolNode := range poolNodes {
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, poolNode.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get node %s: %w", poolNode.Name, err)
+		}
+
+		pods, err := listNodePods(ctx, k8sClient, node.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		load := computeNodeLoad(node, pods)
+		total += load.utilisation()
+	}
+
+	score := 1 - total/float64(len(poolNodes))
+	if score < 0 {
+		score = 0
+	}
+
+	return score, nil
+}
+
+// SuggestNodesToRemoveForDefragmentation returns the names of at most maxToRemove nodes which
+// could be drained to reduce fragmentation. Nodes are considered from the least loaded one, and
+// are only suggested if all their pods can be re-packed (best fit) on the remaining nodes.
+// The pods scheduled on the nodes are given along with them, a node not carrying the requests of its pods.
+func SuggestNodesToRemoveForDefragmentation(nodes []v1.Node, pods []v1.Pod, maxToRemove int) []string {
+	loads := make([]*nodeLoad, 0, len(nodes))
+	for i := range nodes {
+		loads = append(loads, computeNodeLoad(&nodes[i], pods))
+	}
+
+	// Least loaded nodes are the most drainable ones
+	sort.SliceStable(loads, func(i, j int) bool {
+		if loads[i].utilisation() == loads[j].utilisation() {
+			return len(loads[i].pods) < len(loads[j].pods)
+		}
+		return loads[i].utilisation() < loads[j].utilisation()
+	})
+
+	free := make(map[string]int64, len(loads))
+	for _, load := range loads {
+		free[load.name] = load.allocatable - load.requested
+	}
+
+	removed := make(map[string]bool)
+	suggestions := make([]string, 0)
+
+	for _, candidate := range loads {
+		if len(suggestions) >= maxToRemove {
+			break
+		}
+
+		// Try to place every pod of the candidate on the other remaining nodes
+		placement := make(map[string]int64, len(free))
+		for name, value := range free {
+			placement[name] = value
+		}
+
+		requests := append([]int64(nil), candidate.pods...)
+		sort.Slice(requests, func(i, j int) bool { return requests[i] > requests[j] })
+
+		fits := true
+		for _, request := range requests {
+			best := ""
+			for _, target := range loads {
+				if target.name == candidate.name || removed[target.name] || placement[target.name] < request {
+					continue
+				}
+				if best == "" || placement[target.name] < placement[best] {
+					best = target.name
+				}
+			}
+
+			if best == "" {
+				fits = false
+				break
+			}
+			placement[best] -= request
+		}
+
+		if !fits {
+			continue
+		}
+
+		free = placement
+		removed[candidate.name] = true
+		suggestions = append(suggestions, candidate.name)
+	}
+
+	return suggestions
+}
+
+// listNodePods lists the pods scheduled on a node
+func listNodePods(ctx context.Context, k8sClient kubernetes.Interface, nodeName string) ([]v1.Pod, error) {
+	pods, err := k8sClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of node %s: %w", nodeName, err)
+	}
+
+	return pods.Items, nil
+}
+
+// computeNodeLoad sums the CPU requests of the running pods scheduled on the given node
+func computeNodeLoad(node *v1.Node, pods []v1.Pod) *nodeLoad {
+	load := &nodeLoad{
+		name:        node.Name,
+		allocatable: node.Status.Allocatable.Cpu().MilliValue(),
+		pods:        make([]int64, 0),
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != node.Name || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		request := podCPURequestMilli(pod)
+		load.requested += request
+		load.pods = append(load.pods, request)
+	}
+
+	return load
+}
+
+// podCPURequestMilli sums the CPU requests of the containers of a pod
+func podCPURequestMilli(pod *v1.Pod) int64 {
+	total := int64(0)
+	for _, container := range pod.Spec.Containers {
+		total += container.Resources.Requests.Cpu().MilliValue()
+	}
+
+	return total
+}
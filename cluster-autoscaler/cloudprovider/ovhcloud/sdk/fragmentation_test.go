@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestK8sNode(name string, cpu string) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
+
+func newTestPod(name string, nodeName string, cpu string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{
+				{
+					Name: "main",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestSuggestNodesToRemoveForDefragmentation(t *testing.T) {
+	// 10 nodes of 4 CPUs with varying numbers of 500m pods
+	podCounts := []int{6, 1, 7, 0, 5, 2, 7, 3, 6, 4}
+
+	nodes := make([]v1.Node, 0)
+	pods := make([]v1.Pod, 0)
+	for i, count := range podCounts {
+		nodeName := fmt.Sprintf("node-%d", i)
+		nodes = append(nodes, newTestK8sNode(nodeName, "4"))
+
+		for j := 0; j < count; j++ {
+			pods = append(pods, newTestPod(fmt.Sprintf("%s-pod-%d", nodeName, j), nodeName, "500m"))
+		}
+	}
+
+	t.Run("check least loaded nodes are suggested", func(t *testing.T) {
+		suggestions := SuggestNodesToRemoveForDefragmentation(nodes, pods, 3)
+
+		assert.Equal(t, []string{"node-3", "node-1", "node-5"}, suggestions)
+	})
+
+	t.Run("check nodes are not suggested when pods cannot be re-packed", func(t *testing.T) {
+		fullNodes := []v1.Node{newTestK8sNode("node-a", "1"), newTestK8sNode("node-b", "1")}
+		fullPods := []v1.Pod{newTestPod("pod-a", "node-a", "800m"), newTestPod("pod-b", "node-b", "800m")}
+
+		suggestions := SuggestNodesToRemoveForDefragmentation(fullNodes, fullPods, 1)
+
+		assert.Empty(t, suggestions)
+	})
+
+	t.Run("check suggestions are limited by maxToRemove", func(t *testing.T) {
+		suggestions := SuggestNodesToRemoveForDefragmentation(nodes, pods, 0)
+
+		assert.Empty(t, suggestions)
+	})
+}
+
+func TestClient_GetNodeFragmentationScore(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "pool"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Node{{ID: "id-a", Name: "node-a"}, {ID: "id-b", Name: "node-b"}})
+	})
+	client := newTestClient(t, mux)
+
+	nodeA := newTestK8sNode("node-a", "4")
+	nodeB := newTestK8sNode("node-b", "4")
+	podA := newTestPod("pod-a", "node-a", "2")
+	k8sClient := fake.NewSimpleClientset(&nodeA, &nodeB, &podA)
+
+	score, err := client.GetNodeFragmentationScore(context.Background(), "projectID", "clusterID", "poolID", k8sClient)
+	assert.NoError(t, err)
+
+	// node-a is half used and node-b is empty: average utilisation is 0.25
+	assert.InDelta(t, 0.75, score, 0.001)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// gpuDriverVersionPattern matches NVIDIA driver versions such as 535.104.05
+var gpuDriverVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// GPUDriverConfig defines the NVIDIA driver installed on the nodes of a GPU node pool
+type GPUDriverConfig struct {
+	Version     string `json:"version"`
+	AutoUpgrade bool   `json:"autoUpgrade"`
+}
+
+// Validate checks that the driver version is a semantic version
+func (cfg *GPUDriverConfig) Validate() error {
+	if !gpuDriverVersionPattern.MatchString(cfg.Version) {
+		return fmt.Errorf("invalid GPU driver version %q: expected a semantic version (e.g. 535.104.05)", cfg.Version)
+	}
+
+	return nil
+}
+
+// ListSupportedGPUDriverVersions allows to list the GPU driver versions available in a cluster
+func (c *Client) ListSupportedGPUDriverVersions(ctx context.Context, projectID string, clusterID string) ([]string, error) {
+	versions := make([]string, 0)
+
+	return versions, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/gpuDriver/versions", projectID, clusterID),
+		nil,
+		&versions,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// GetGPUDriverConfig allows to display the GPU driver configuration of a specific node pool
+func (c *Client) GetGPUDriverConfig(ctx context.Context, projectID string, clusterID string, poolID string) (*GPUDriverConfig, error) {
+	cfg := &GPUDriverConfig{}
+
+	return cfg, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/gpuDriver", projectID, clusterID, poolID),
+		nil,
+		&cfg,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// SetGPUDriverConfig allows to update the GPU driver configuration of a specific node pool.
+// The requested version must be part of the versions supported by the cluster.
+func (c *Client) SetGPUDriverConfig(ctx context.Context, projectID string, clusterID string, poolID string, cfg *GPUDriverConfig) (*GPUDriverConfig, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	versions, err := c.ListSupportedGPUDriverVersions(ctx, projectID, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supported GPU driver versions: %w", err)
+	}
+
+	supported := false
+	for _, version := range versions {
+		if version == cfg.Version {
+			supported = true
+			break
+		}
+	}
+
+	if !supported {
+		return nil, fmt.Errorf("GPU driver version %s is not supported by cluster %s (supported: %v)", cfg.Version, clusterID, versions)
+	}
+
+	updated := &GPUDriverConfig{}
+
+	return updated, c.CallAPIWithContext(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/gpuDriver", projectID, clusterID, poolID),
+		cfg,
+		&updated,
+		nil,
+		nil,
+		true,
+	)
+}
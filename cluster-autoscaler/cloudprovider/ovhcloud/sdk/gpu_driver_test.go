@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGPUDriverTestClient(t *testing.T, updates *int) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/gpuDriver/versions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]string{"525.125.06", "535.104.05"})
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/gpuDriver", func(w http.ResponseWriter, r *http.Request) {
+		cfg := GPUDriverConfig{Version: "525.125.06", AutoUpgrade: false}
+		if r.Method == "PUT" {
+			*updates++
+			_ = json.NewDecoder(r.Body).Decode(&cfg)
+		}
+		_ = json.NewEncoder(w).Encode(cfg)
+	})
+
+	return newTestClient(t, mux)
+}
+
+func TestGPUDriverConfig_Validate(t *testing.T) {
+	t.Run("check valid semantic version", func(t *testing.T) {
+		assert.NoError(t, (&GPUDriverConfig{Version: "535.104.05"}).Validate())
+	})
+
+	t.Run("check invalid versions", func(t *testing.T) {
+		for _, version := range []string{"", "535", "535.104", "v535.104.05", "latest"} {
+			assert.Error(t, (&GPUDriverConfig{Version: version}).Validate(), version)
+		}
+	})
+}
+
+func TestClient_GetGPUDriverConfig(t *testing.T) {
+	updates := 0
+	client := newGPUDriverTestClient(t, &updates)
+
+	cfg, err := client.GetGPUDriverConfig(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Equal(t, "525.125.06", cfg.Version)
+	assert.False(t, cfg.AutoUpgrade)
+}
+
+func TestClient_SetGPUDriverConfig(t *testing.T) {
+	t.Run("check supported version is applied", func(t *testing.T) {
+		updates := 0
+		client := newGPUDriverTestClient(t, &updates)
+
+		cfg, err := client.SetGPUDriverConfig(context.Background(), "projectID", "clusterID", "poolID", &GPUDriverConfig{Version: "535.104.05", AutoUpgrade: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "535.104.05", cfg.Version)
+		assert.True(t, cfg.AutoUpgrade)
+		assert.Equal(t, 1, updates)
+	})
+
+	t.Run("check unsupported version is rejected before update", func(t *testing.T) {
+		updates := 0
+		client := newGPUDriverTestClient(t, &updates)
+
+		_, err := client.SetGPUDriverConfig(context.Background(), "projectID", "clusterID", "poolID", &GPUDriverConfig{Version: "550.54.14"})
+		assert.Error(t, err)
+		assert.Equal(t, 0, updates)
+	})
+
+	t.Run("check malformed version is rejected before update", func(t *testing.T) {
+		updates := 0
+		client := newGPUDriverTestClient(t, &updates)
+
+		_, err := client.SetGPUDriverConfig(context.Background(), "projectID", "clusterID", "poolID", &GPUDriverConfig{Version: "latest"})
+		assert.Error(t, err)
+		assert.Equal(t, 0, updates)
+	})
+}
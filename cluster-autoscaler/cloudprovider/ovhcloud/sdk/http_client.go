@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the number of idle connections kept open to the API, across all hosts
+	DefaultMaxIdleConns = 100
+	// DefaultMaxIdleConnsPerHost is the number of idle connections kept open to each host of the API
+	DefaultMaxIdleConnsPerHost = 10
+	// DefaultIdleConnTimeout is how long an idle connection is kept open before being closed
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// NewHTTPClient creates an HTTP client keeping idle connections open for reuse, instead of establishing
+// a new connection to the API on every refresh
+func NewHTTPClient(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
+	transport.DisableCompression = false
+	transport.ForceAttemptHTTP2 = true
+
+	return &http.Client{Transport: transport}
+}
+
+// WithHTTPClient makes the client send its requests with the given HTTP client.
+// It should be given before the options changing the transport, like WithHTTPProxy or WithSSHTunnel.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) error {
+		if httpClient == nil {
+			return errors.New("HTTP client should not be nil")
+		}
+
+		client.Client = httpClient
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("check connection pool is tuned", func(t *testing.T) {
+		httpClient := NewHTTPClient(20, 5, time.Minute)
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		assert.True(t, ok)
+		assert.Equal(t, 20, transport.MaxIdleConns)
+		assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+		assert.True(t, transport.ForceAttemptHTTP2)
+		assert.False(t, transport.DisableCompression)
+	})
+
+	t.Run("check clients use a tuned transport by default", func(t *testing.T) {
+		client, err := NewClient("ovh-eu", "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+
+		transport, ok := client.Client.Transport.(*http.Transport)
+		assert.True(t, ok)
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	})
+
+	t.Run("check custom HTTP client is used", func(t *testing.T) {
+		httpClient := &http.Client{}
+
+		client, err := NewClient("ovh-eu", "key", "secret", "consumer_key", WithHTTPClient(httpClient))
+		assert.NoError(t, err)
+		assert.Same(t, httpClient, client.Client)
+
+		_, err = NewClient("ovh-eu", "key", "secret", "consumer_key", WithHTTPClient(nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("check proxy keeps the tuned transport", func(t *testing.T) {
+		client, err := NewClient("ovh-eu", "key", "secret", "consumer_key",
+			WithHTTPClient(NewHTTPClient(20, 5, time.Minute)),
+			WithHTTPProxy("http://proxy.example.com:3128"),
+		)
+		assert.NoError(t, err)
+
+		transport := client.Client.Transport.(*http.Transport)
+		assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+		assert.NotNil(t, transport.Proxy)
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrNotLeader is returned when a replica which is not the leader tries to modify resources through the API
+var ErrNotLeader = errors.New("autoscaler replica is not the leader")
+
+// WithLeaderElection makes the client refuse mutating calls (anything but GET) with ErrNotLeader
+// while isLeader returns false, so only the leader replica of a HA deployment modifies the node pools.
+// Read-only calls are always performed.
+func WithLeaderElection(isLeader func() bool) ClientOption {
+	return func(client *Client) error {
+		client.isLeader = isLeader
+		return nil
+	}
+}
+
+// checkLeader returns ErrNotLeader if the client is not allowed to perform a call with the given method
+func (c *Client) checkLeader(method string) error {
+	if c.isLeader == nil || method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+
+	if !c.isLeader() {
+		return ErrNotLeader
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_WithLeaderElection(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"id": "poolID"}`))
+	})
+	client := newTestClient(t, mux)
+
+	var leader atomic.Bool
+	assert.NoError(t, WithLeaderElection(leader.Load)(client))
+
+	desired := uint32(3)
+	opts := &UpdateNodePoolOpts{DesiredNodes: &desired}
+
+	t.Run("check non-leader cannot update a pool", func(t *testing.T) {
+		_, err := client.UpdateNodePool(context.Background(), "projectID", "clusterID", "poolID", opts)
+		assert.ErrorIs(t, err, ErrNotLeader)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("check non-leader can read a pool", func(t *testing.T) {
+		pool, err := client.GetNodePool(context.Background(), "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, "poolID", pool.ID)
+	})
+
+	t.Run("check leader can update a pool", func(t *testing.T) {
+		leader.Store(true)
+
+		_, err := client.UpdateNodePool(context.Background(), "projectID", "clusterID", "poolID", opts)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
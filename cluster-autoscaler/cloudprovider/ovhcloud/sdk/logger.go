@@ -17,15 +17,154 @@ limitations under the License.
 package sdk
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
 )
 
+// RedactedValue replaces the value of the redacted headers in the logs
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedHeaders are the headers holding credentials, never logged by the loggers of the package
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"X-Auth-Token",
+	"X-VKE-Signature",
+	"X-Ovh-Signature",
+	"X-Ovh-Consumer",
+}
+
 // Logger is the interface that should be implemented for loggers that wish to
 // log HTTP requests and HTTP responses.
 type Logger interface {
 	// LogRequest logs an HTTP request.
 	LogRequest(*http.Request)
 
-	// LogResponse logs an HTTP response.
-	LogResponse(*http.Response)
+	// LogResponse logs an HTTP response, received duration after its request was sent.
+	LogResponse(*http.Response, time.Duration)
+
+	// Redact tells whether the value of a header must be hidden from the logs.
+	Redact(key string) bool
+}
+
+// StandardLogger logs the HTTP requests and responses with klog, at its LogLevel verbosity
+type StandardLogger struct {
+	LogLevel klog.Level
+}
+
+// LogRequest logs an HTTP request
+func (l *StandardLogger) LogRequest(req *http.Request) {
+	klog.V(l.LogLevel).Infof("API request: %s %s, headers: %v", req.Method, req.URL, redactHeaders(req.Header, l.Redact))
+}
+
+// LogResponse logs an HTTP response
+func (l *StandardLogger) LogResponse(resp *http.Response, duration time.Duration) {
+	method, url := requestOf(resp)
+	klog.V(l.LogLevel).Infof("API response: %s %s returned %d in %s, headers: %v", method, url, resp.StatusCode, duration, redactHeaders(resp.Header, l.Redact))
+}
+
+// Redact hides the DefaultRedactedHeaders
+func (l *StandardLogger) Redact(key string) bool {
+	return isDefaultRedactedHeader(key)
+}
+
+// JSONLogger writes one JSON line per HTTP request and response
+type JSONLogger struct {
+	Writer io.Writer
+
+	mutex sync.Mutex
+}
+
+// jsonLogLine defines a line written by JSONLogger
+type jsonLogLine struct {
+	Type       string              `json:"type"`
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	StatusCode int                 `json:"status_code,omitempty"`
+	DurationMs *int64              `json:"duration_ms,omitempty"`
+	RequestID  string              `json:"request_id,omitempty"`
+	Headers    map[string][]string `json:"headers"`
+}
+
+// LogRequest writes a request line
+func (l *JSONLogger) LogRequest(req *http.Request) {
+	l.write(jsonLogLine{
+		Type:    "request",
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: redactHeaders(req.Header, l.Redact),
+	})
+}
+
+// LogResponse writes a response line, identified by the query ID given by the API
+func (l *JSONLogger) LogResponse(resp *http.Response, duration time.Duration) {
+	method, url := requestOf(resp)
+	durationMs := duration.Milliseconds()
+
+	requestID := resp.Header.Get("X-VKE-QueryID")
+	if requestID == "" {
+		requestID = resp.Header.Get("X-Ovh-QueryID")
+	}
+
+	l.write(jsonLogLine{
+		Type:       "response",
+		Method:     method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		DurationMs: &durationMs,
+		RequestID:  requestID,
+		Headers:    redactHeaders(resp.Header, l.Redact),
+	})
+}
+
+// Redact hides the DefaultRedactedHeaders
+func (l *JSONLogger) Redact(key string) bool {
+	return isDefaultRedactedHeader(key)
+}
+
+func (l *JSONLogger) write(line jsonLogLine) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := json.NewEncoder(l.Writer).Encode(line); err != nil {
+		klog.Warningf("Failed to write API log line: %v", err)
+	}
+}
+
+func isDefaultRedactedHeader(key string) bool {
+	for _, header := range DefaultRedactedHeaders {
+		if http.CanonicalHeaderKey(header) == http.CanonicalHeaderKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactHeaders returns a copy of the headers where the values of the redacted ones are replaced by RedactedValue
+func redactHeaders(headers http.Header, redact func(key string) bool) http.Header {
+	redacted := make(http.Header, len(headers))
+	for key, values := range headers {
+		if redact(key) {
+			redacted[key] = []string{RedactedValue}
+			continue
+		}
+
+		redacted[key] = append([]string{}, values...)
+	}
+
+	return redacted
+}
+
+// requestOf returns the method and the URL of the request of a response, if known
+func requestOf(resp *http.Response) (string, string) {
+	if resp.Request == nil {
+		return "", ""
+	}
+
+	return resp.Request.Method, resp.Request.URL.String()
 }
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{
+		"Authorization":   []string{"Bearer OpenStack/token"},
+		"X-Auth-Token":    []string{"token"},
+		"X-Vke-Signature": []string{"sha256=abc"},
+		"X-Ovh-Signature": []string{"$1$abc"},
+		"Accept":          []string{"application/json"},
+	}
+
+	redacted := redactHeaders(headers, (&StandardLogger{}).Redact)
+	assert.Equal(t, http.Header{
+		"Authorization":   []string{RedactedValue},
+		"X-Auth-Token":    []string{RedactedValue},
+		"X-Vke-Signature": []string{RedactedValue},
+		"X-Ovh-Signature": []string{RedactedValue},
+		"Accept":          []string{"application/json"},
+	}, redacted)
+
+	// The original headers are left untouched
+	assert.Equal(t, "token", headers.Get("X-Auth-Token"))
+	assert.True(t, (&JSONLogger{}).Redact("x-auth-token"))
+	assert.False(t, (&JSONLogger{}).Redact("Content-Type"))
+}
+
+func TestJSONLogger(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ovh-QueryID", "EU.ext-1.1234")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	output := &bytes.Buffer{}
+	client.Logger = &JSONLogger{Writer: output}
+
+	err := client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	request := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	assert.Equal(t, "request", request["type"])
+	assert.Equal(t, "GET", request["method"])
+	assert.Equal(t, client.endpoint+"/pool", request["url"])
+	assert.NotContains(t, request, "duration_ms")
+	assert.Equal(t, []interface{}{RedactedValue}, request["headers"].(map[string]interface{})["Authorization"])
+	assert.NotContains(t, lines[0], "token")
+
+	response := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+	assert.Equal(t, "response", response["type"])
+	assert.Equal(t, float64(http.StatusOK), response["status_code"])
+	assert.Equal(t, "EU.ext-1.1234", response["request_id"])
+	assert.Contains(t, response, "duration_ms")
+}
+
+// durationLogger records the durations of the responses
+type durationLogger struct {
+	StandardLogger
+	durations []time.Duration
+}
+
+func (l *durationLogger) LogResponse(resp *http.Response, duration time.Duration) {
+	l.durations = append(l.durations, duration)
+}
+
+func TestClient_LogResponseDuration(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(1500 * time.Millisecond)
+	}))
+	client.clock = clock
+
+	logger := &durationLogger{}
+	client.Logger = logger
+
+	err := client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{1500 * time.Millisecond}, logger.durations)
+}
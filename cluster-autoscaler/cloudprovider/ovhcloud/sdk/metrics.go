@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultMetricsNamespace is the namespace of the API metrics when none is given
+const DefaultMetricsNamespace = "vke_autoscaler"
+
+// Metrics records the API calls performed by a client
+type Metrics interface {
+	// ObserveAPICall records an attempt of an API call, its status code being 0 when no response was received
+	ObserveAPICall(method, path string, authenticated bool, statusCode int, duration time.Duration)
+	// IncrementRetry records an API call being attempted again
+	IncrementRetry(method, path string)
+}
+
+// routeParameterSegments are the path segments followed by the ID of one of their resources
+var routeParameterSegments = map[string]bool{
+	"project":             true,
+	"kube":                true,
+	"nodepool":            true,
+	"node":                true,
+	"flavors":             true,
+	"capacityReservation": true,
+	"snapshot":            true,
+}
+
+// routeTemplate returns the route of an API path, its resource IDs being replaced with {id} and its query dropped,
+// e.g. /cloud/project/{id}/kube/{id}/nodepool/{id}, so that the calls to the same route are recorded together
+func routeTemplate(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] != "" && routeParameterSegments[segments[i-1]] {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// WithMetrics makes the client record its API calls
+func WithMetrics(m Metrics) ClientOption {
+	return func(client *Client) error {
+		client.metrics = m
+		return nil
+	}
+}
+
+// PrometheusMetrics records the API calls as Prometheus metrics
+type PrometheusMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates the API metrics under the given namespace, DefaultMetricsNamespace when empty,
+// and registers them
+func NewPrometheusMetrics(reg prometheus.Registerer, namespace string) (*PrometheusMetrics, error) {
+	if namespace == "" {
+		namespace = DefaultMetricsNamespace
+	}
+
+	m := &PrometheusMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of the OVHcloud API requests, by method, path, status code and authentication",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "path", "status_code", "authenticated"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_request_errors_total",
+			Help:      "Number of OVHcloud API requests failing without response or with an error status",
+		}, []string{"method", "path", "status_code", "authenticated"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_request_retries_total",
+			Help:      "Number of OVHcloud API requests attempted again after a transient error",
+		}, []string{"method", "path"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.duration, m.errors, m.retries} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveAPICall implements Metrics, labelling the calls with their route rather than their path
+func (m *PrometheusMetrics) ObserveAPICall(method, path string, authenticated bool, statusCode int, duration time.Duration) {
+	labels := prometheus.Labels{
+		"method":        method,
+		"path":          routeTemplate(path),
+		"status_code":   strconv.Itoa(statusCode),
+		"authenticated": strconv.FormatBool(authenticated),
+	}
+
+	m.duration.With(labels).Observe(duration.Seconds())
+	if statusCode == 0 || statusCode >= 400 {
+		m.errors.With(labels).Inc()
+	}
+}
+
+// IncrementRetry implements Metrics, labelling the calls with their route rather than their path
+func (m *PrometheusMetrics) IncrementRetry(method, path string) {
+	m.retries.WithLabelValues(method, routeTemplate(path)).Inc()
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CallAPIWithMetrics(t *testing.T) {
+	client, _ := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	reg := prometheus.NewRegistry()
+	metrics, err := NewPrometheusMetrics(reg, "")
+	assert.NoError(t, err)
+	client.metrics = metrics
+
+	err = client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, &NodePool{}, nil, nil, true)
+	assert.NoError(t, err)
+
+	t.Run("check every attempt is observed", func(t *testing.T) {
+		assert.Equal(t, 3, testutil.CollectAndCount(metrics.duration, "vke_autoscaler_api_request_duration_seconds"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.errors.WithLabelValues("GET", "/pool", "503", "true")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.errors.WithLabelValues("GET", "/pool", "429", "true")))
+		assert.Equal(t, 2, testutil.CollectAndCount(metrics.errors))
+	})
+
+	t.Run("check retries are counted", func(t *testing.T) {
+		assert.Equal(t, float64(2), testutil.ToFloat64(metrics.retries.WithLabelValues("GET", "/pool")))
+	})
+
+	t.Run("check calls are labelled with their route", func(t *testing.T) {
+		metrics.ObserveAPICall("GET", "/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes?page_token=next", true, http.StatusInternalServerError, 0)
+		metrics.ObserveAPICall("GET", "/cloud/project/projectID/kube/clusterID/nodepool/otherPoolID/nodes", true, http.StatusInternalServerError, 0)
+
+		route := "/cloud/project/{id}/kube/{id}/nodepool/{id}/nodes"
+		assert.Equal(t, float64(2), testutil.ToFloat64(metrics.errors.WithLabelValues("GET", route, "500", "true")))
+	})
+
+	t.Run("check metrics are registered under the namespace", func(t *testing.T) {
+		_, err := NewPrometheusMetrics(reg, DefaultMetricsNamespace)
+		assert.Error(t, err)
+
+		_, err = NewPrometheusMetrics(reg, "other")
+		assert.NoError(t, err)
+	})
+}
+
+func TestRouteTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/auth/time", "/auth/time"},
+		{"/cloud/project/projectID/quota", "/cloud/project/{id}/quota"},
+		{"/cloud/project/projectID/kube/clusterID/nodepool", "/cloud/project/{id}/kube/{id}/nodepool"},
+		{"/cloud/project/projectID/kube/clusterID/nodepool/poolID", "/cloud/project/{id}/kube/{id}/nodepool/{id}"},
+		{"/cloud/project/projectID/kube/clusterID/node/nodeID", "/cloud/project/{id}/kube/{id}/node/{id}"},
+		{"/cloud/project/projectID/kube/clusterID/flavors/b2-7/pricing", "/cloud/project/{id}/kube/{id}/flavors/{id}/pricing"},
+		{"/cloud/project/projectID/kube/clusterID/nodepool/poolID/snapshot/snapshotID/restore", "/cloud/project/{id}/kube/{id}/nodepool/{id}/snapshot/{id}/restore"},
+		{"/cloud/project/kube/kube/clusterID", "/cloud/project/{id}/kube/{id}"},
+		{"/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes?fields=id", "/cloud/project/{id}/kube/{id}/nodepool/{id}/nodes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, routeTemplate(tt.path))
+		})
+	}
+}
@@ -18,6 +18,7 @@ package sdk
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -55,6 +56,20 @@ func (m *ClientMock) UpdateNodePool(ctx context.Context, projectID string, clust
 	return args.Get(0).(*NodePool), args.Error(1)
 }
 
+// DryRunUpdateNodePool mocks API call to validate an update of a pool without executing it
+func (m *ClientMock) DryRunUpdateNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *UpdateNodePoolOpts) (*DryRunResult, error) {
+	args := m.Called(ctx, projectID, clusterID, poolID, opts)
+
+	return args.Get(0).(*DryRunResult), args.Error(1)
+}
+
+// ListSpotInterruptions mocks API call for listing the interruption notices of the spot nodes of a pool
+func (m *ClientMock) ListSpotInterruptions(ctx context.Context, projectID string, clusterID string, poolID string) ([]SpotInterruption, error) {
+	args := m.Called(ctx, projectID, clusterID, poolID)
+
+	return args.Get(0).([]SpotInterruption), args.Error(1)
+}
+
 // DeleteNodePool mocks API call to delete an existing pool
 func (m *ClientMock) DeleteNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
 	args := m.Called(ctx, projectID, clusterID, poolID)
@@ -68,3 +83,66 @@ func (m *ClientMock) ListClusterFlavors(ctx context.Context, projectID string, c
 
 	return args.Get(0).([]Flavor), args.Error(1)
 }
+
+// GetFlavorPricing mocks API call for getting the price of a flavor
+func (m *ClientMock) GetFlavorPricing(ctx context.Context, projectID string, clusterID string, flavorName string) (*FlavorPricing, error) {
+	args := m.Called(ctx, projectID, clusterID, flavorName)
+
+	return args.Get(0).(*FlavorPricing), args.Error(1)
+}
+
+// GetCluster mocks API call for getting cluster information
+func (m *ClientMock) GetCluster(ctx context.Context, projectID string, clusterID string) (*Cluster, error) {
+	args := m.Called(ctx, projectID, clusterID)
+
+	return args.Get(0).(*Cluster), args.Error(1)
+}
+
+// ListQuotas mocks API call for listing project quotas
+func (m *ClientMock) ListQuotas(ctx context.Context, projectID string) ([]Quota, error) {
+	args := m.Called(ctx, projectID)
+
+	return args.Get(0).([]Quota), args.Error(1)
+}
+
+// InitialiseNodePool mocks API calls applying standard metadata to a pool
+func (m *ClientMock) InitialiseNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *InitialiseOpts) error {
+	args := m.Called(ctx, projectID, clusterID, poolID, opts)
+
+	return args.Error(0)
+}
+
+// CheckNodePoolQuota mocks API call checking a pool quota
+func (m *ClientMock) CheckNodePoolQuota(ctx context.Context, projectID string, clusterID string, poolID string, requested int) error {
+	args := m.Called(ctx, projectID, clusterID, poolID, requested)
+
+	return args.Error(0)
+}
+
+// GetNodePool mocks API call for getting a pool
+func (m *ClientMock) GetNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
+	args := m.Called(ctx, projectID, clusterID, poolID)
+
+	return args.Get(0).(*NodePool), args.Error(1)
+}
+
+// ListCapacityReservations mocks API call for listing the capacity reservations of a pool
+func (m *ClientMock) ListCapacityReservations(ctx context.Context, projectID string, clusterID string, poolID string) ([]CapacityReservation, error) {
+	args := m.Called(ctx, projectID, clusterID, poolID)
+
+	return args.Get(0).([]CapacityReservation), args.Error(1)
+}
+
+// GetScalingEvents mocks API call for listing the scale actions of a pool
+func (m *ClientMock) GetScalingEvents(ctx context.Context, projectID string, clusterID string, poolID string, since time.Time) ([]ScalingEvent, error) {
+	args := m.Called(ctx, projectID, clusterID, poolID, since)
+
+	return args.Get(0).([]ScalingEvent), args.Error(1)
+}
+
+// Shutdown mocks the wait for the in-flight API calls
+func (m *ClientMock) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ClientConfig defines the endpoint, credentials and options of a client of a MultiRegionClient
+type ClientConfig struct {
+	Endpoint          string
+	ApplicationKey    string
+	ApplicationSecret string
+	ConsumerKey       string
+
+	Options []ClientOption
+}
+
+// MultiRegionClient calls the API through a prioritized list of clients, failing over to the next
+// client when a call fails because the tenant or the cluster is not yet synchronized on a region
+type MultiRegionClient struct {
+	clients []*Client
+}
+
+// NewMultiRegionClient creates a client for every configuration, the first one being tried first
+func NewMultiRegionClient(configs []ClientConfig) (*MultiRegionClient, error) {
+	if len(configs) == 0 {
+		return nil, errors.New("at least one client configuration is required")
+	}
+
+	clients := make([]*Client, 0, len(configs))
+	for _, config := range configs {
+		client, err := NewClient(config.Endpoint, config.ApplicationKey, config.ApplicationSecret, config.ConsumerKey, config.Options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for endpoint %s: %w", config.Endpoint, err)
+		}
+
+		clients = append(clients, client)
+	}
+
+	m := &MultiRegionClient{clients: clients}
+	clients[0].regions = m
+
+	return m, nil
+}
+
+// Clients returns the clients in the order they are tried
+func (m *MultiRegionClient) Clients() []*Client {
+	return m.clients
+}
+
+// Primary returns the client of the first configuration, whose calls fail over to the other clients
+func (m *MultiRegionClient) Primary() *Client {
+	return m.clients[0]
+}
+
+// Get is a wrapper for the GET method
+func (m *MultiRegionClient) Get(url string, result interface{}, queryParams url.Values) error {
+	return m.CallAPIWithContext(context.Background(), "GET", url, nil, result, queryParams, nil, true)
+}
+
+// Post is a wrapper for the POST method
+func (m *MultiRegionClient) Post(url string, reqBody, result interface{}, queryParams url.Values) error {
+	return m.CallAPIWithContext(context.Background(), "POST", url, reqBody, result, queryParams, nil, true)
+}
+
+// Put is a wrapper for the PUT method
+func (m *MultiRegionClient) Put(url string, reqBody, result interface{}, queryParams url.Values) error {
+	return m.CallAPIWithContext(context.Background(), "PUT", url, reqBody, result, queryParams, nil, true)
+}
+
+// Delete is a wrapper for the DELETE method
+func (m *MultiRegionClient) Delete(url string, result interface{}, queryParams url.Values) error {
+	return m.CallAPIWithContext(context.Background(), "DELETE", url, nil, result, queryParams, nil, true)
+}
+
+// CallAPIWithContext performs the call with every client in turn, until one succeeds or fails with an error
+// which is not worth trying on another region. The error of the first client is returned when all fail.
+func (m *MultiRegionClient) CallAPIWithContext(ctx context.Context, method, path string, reqBody, result interface{}, queryParams url.Values, headers map[string]interface{}, needAuth bool) error {
+	var firstErr error
+	for _, client := range m.clients {
+		err := client.callAPIWithContext(ctx, method, path, reqBody, result, queryParams, headers, needAuth)
+		if err == nil {
+			return nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		if !isRegionFailoverError(err, client.endpoint+path) {
+			break
+		}
+	}
+
+	return firstErr
+}
+
+// isRegionFailoverError tells whether a call failing with err on the given URL should be tried on the next region
+func isRegionFailoverError(err error, url string) bool {
+	return IsPossiblyCanadianTenantSyncError(err, url) || IsRegionSyncError(err, url)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// regionRecorder serves the endpoints of the regions, recording the order in which they are called
+type regionRecorder struct {
+	calls   []string
+	servers map[string]*url.URL
+	mutex   sync.Mutex
+}
+
+// server answers the calls of the endpoint with the given status, the body being the region name when it succeeds
+func (r *regionRecorder) server(t *testing.T, endpoint string, name string, status int, message string) ClientConfig {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mutex.Lock()
+		r.calls = append(r.calls, name)
+		r.mutex.Unlock()
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"id": "` + name + `"}`))
+		} else {
+			_, _ = w.Write([]byte(`{"message": "` + message + `"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	endpointURL, _ := url.Parse(endpoint)
+	serverURL, _ := url.Parse(server.URL)
+	if r.servers == nil {
+		r.servers = make(map[string]*url.URL)
+	}
+	r.servers[endpointURL.Host] = serverURL
+
+	return ClientConfig{Endpoint: endpoint, ApplicationKey: "key", ApplicationSecret: "secret", ConsumerKey: "consumer_key"}
+}
+
+// RoundTrip sends the requests of an endpoint to its test server
+func (r *regionRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	server, ok := r.servers[req.URL.Host]
+	if !ok {
+		return nil, errors.New("no server for " + req.URL.Host)
+	}
+
+	req.URL.Scheme = server.Scheme
+	req.URL.Host = server.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestMultiRegionClient(t *testing.T, recorder *regionRecorder, configs ...ClientConfig) *MultiRegionClient {
+	client, err := NewMultiRegionClient(configs)
+	if err != nil {
+		assert.FailNow(t, "failed to create multi region client", err)
+	}
+
+	for _, c := range client.Clients() {
+		c.openStackToken = "token"
+		c.Client = &http.Client{Transport: recorder}
+	}
+
+	return client
+}
+
+func TestMultiRegionClient_CallAPIWithContext(t *testing.T) {
+	ctx := context.Background()
+	clusterPath := "/cloud/project/projectID/kube/clusterID/nodepool/poolID"
+
+	t.Run("check tenant sync errors fail over in order", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusInternalServerError, canadianTenantSyncErrorMessage),
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+		)
+
+		pool := &NodePool{}
+		err := client.CallAPIWithContext(ctx, "GET", clusterPath, nil, pool, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "ca", pool.ID)
+		assert.Equal(t, []string{"eu", "ca"}, recorder.calls)
+	})
+
+	t.Run("check clusters not found fail over", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusNotFound, "eu"),
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+		)
+
+		pool := &NodePool{}
+		err := client.CallAPIWithContext(ctx, "GET", clusterPath, nil, pool, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "ca", pool.ID)
+		assert.Equal(t, []string{"eu", "ca"}, recorder.calls)
+	})
+
+	t.Run("check the not found error is kept when the fallback fails", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusNotFound, "eu"),
+			recorder.server(t, OvhCA, "ca", http.StatusForbidden, "ca"),
+		)
+
+		err := client.Delete(clusterPath, nil, nil)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, []string{"eu", "ca"}, recorder.calls)
+	})
+
+	t.Run("check other resources not found do not fail over", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusNotFound, "eu"),
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+		)
+
+		err := client.Get("/cloud/project/projectID/quota", nil, nil)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, []string{"eu"}, recorder.calls)
+	})
+
+	t.Run("check other errors do not fail over", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusBadRequest, "eu"),
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+		)
+
+		err := client.Put(clusterPath, nil, nil, nil)
+		assert.ErrorContains(t, err, "eu")
+		assert.Equal(t, []string{"eu"}, recorder.calls)
+	})
+
+	t.Run("check first error is returned when the fallback fails", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusInternalServerError, canadianTenantSyncErrorMessage),
+			recorder.server(t, OvhCA, "ca", http.StatusForbidden, "ca"),
+		)
+
+		err := client.Get(clusterPath, nil, nil)
+		assert.True(t, IsPossiblyCanadianTenantSyncError(err, OvhEU+clusterPath))
+		assert.Equal(t, []string{"eu", "ca"}, recorder.calls)
+	})
+
+	t.Run("check calls of the primary client fail over", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhEU, "eu", http.StatusInternalServerError, canadianTenantSyncErrorMessage),
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+		)
+
+		pool, err := client.Primary().GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, "ca", pool.ID)
+		assert.Equal(t, []string{"eu", "ca"}, recorder.calls)
+	})
+
+	t.Run("check calls of the fallback clients do not fail over", func(t *testing.T) {
+		recorder := &regionRecorder{}
+		client := newTestMultiRegionClient(t, recorder,
+			recorder.server(t, OvhCA, "ca", http.StatusOK, ""),
+			recorder.server(t, OvhEU, "eu", http.StatusInternalServerError, canadianTenantSyncErrorMessage),
+		)
+
+		err := client.Clients()[1].Get(clusterPath, nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"eu"}, recorder.calls)
+	})
+
+	t.Run("check a configuration is required", func(t *testing.T) {
+		_, err := NewMultiRegionClient(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestIsPossiblyCanadianTenantSyncError(t *testing.T) {
+	syncError := &APIError{Code: http.StatusInternalServerError, Message: canadianTenantSyncErrorMessage}
+
+	tests := []struct {
+		name     string
+		err      error
+		url      string
+		expected bool
+	}{
+		{name: "sync error on the EU endpoint", err: syncError, url: OvhEU + "/pool", expected: true},
+		{name: "sync error on the legacy endpoint", err: syncError, url: "https://api.ovh.com/1.0/pool", expected: true},
+		{name: "sync error on the CA endpoint", err: syncError, url: OvhCA + "/pool", expected: false},
+		{name: "other error message", err: &APIError{Code: http.StatusInternalServerError, Message: "boom"}, url: OvhEU + "/pool", expected: false},
+		{name: "not an API error", err: errors.New(canadianTenantSyncErrorMessage), url: OvhEU + "/pool", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsPossiblyCanadianTenantSyncError(test.err, test.url))
+		})
+	}
+}
@@ -16,7 +16,24 @@ limitations under the License.
 
 package sdk
 
-import "time"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// NodeGroupIDAnnotation is the annotation of a Kubernetes node giving the ID of its node pool
+	NodeGroupIDAnnotation = "vke.cloud.io/node-group-id"
+
+	// ClusterIDAnnotation is the annotation of a Kubernetes node giving the ID of its cluster
+	ClusterIDAnnotation = "vke.cloud.io/cluster-id"
+)
 
 // Node defines the instance deployed on OVHcloud
 type Node struct {
@@ -25,6 +42,9 @@ type Node struct {
 	NodePoolID string `json:"nodePoolId"`
 	ProjectID  string `json:"projectId"`
 
+	// NodePoolName is the name of the node pool of the node, filled by ListNodePoolNodes
+	NodePoolName string `json:"nodePoolName,omitempty"`
+
 	Name     string `json:"name"`
 	Flavor   string `json:"flavor"`
 	Version  string `json:"version"`
@@ -38,3 +58,50 @@ type Node struct {
 	DeployedAt time.Time `json:"deployedAt"`
 	UpdatedAt  time.Time `json:"updatedAt"`
 }
+
+// DeleteNode allows to delete a specific node of a cluster.
+// The node pool of the node being unknown, every node pool of the cluster and their nodes are removed from the caches.
+func (c *Client) DeleteNode(ctx context.Context, projectID string, clusterID string, nodeID string) error {
+	path := fmt.Sprintf("/cloud/project/%s/kube/%s/node/%s", projectID, clusterID, nodeID)
+	if c.DryRun {
+		c.recordDryRun("DELETE", path, nil)
+		return nil
+	}
+
+	defer c.NodePoolCache.InvalidateCluster(clusterID)
+	defer c.NodeCache.InvalidateCluster(clusterID)
+
+	return c.CallAPIWithContext(
+		ctx,
+		"DELETE",
+		path,
+		nil,
+		nil,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// AnnotateNodeWithPoolID annotates a Kubernetes node with the IDs of its cluster and node pool,
+// for its node group to be found without listing the nodes of every node pool
+func AnnotateNodeWithPoolID(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, clusterID string, poolID string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				NodeGroupIDAnnotation: poolID,
+				ClusterIDAnnotation:   clusterID,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build annotations patch of node %s: %w", nodeName, err)
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to annotate node %s with node pool %s: %w", nodeName, poolID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GetOldestNodes returns up to count nodes of a pool, oldest first
+func (c *Client) GetOldestNodes(ctx context.Context, projectID string, clusterID string, poolID string, count int) ([]Node, error) {
+	nodes, err := c.listNodePoolNodesByAge(ctx, projectID, clusterID, poolID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes[:min(count, len(nodes))], nil
+}
+
+// GetNewestNodes returns up to count nodes of a pool, newest first
+func (c *Client) GetNewestNodes(ctx context.Context, projectID string, clusterID string, poolID string, count int) ([]Node, error) {
+	nodes, err := c.listNodePoolNodesByAge(ctx, projectID, clusterID, poolID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+
+	return nodes[:min(count, len(nodes))], nil
+}
+
+// listNodePoolNodesByAge lists the nodes of a pool sorted by creation date, oldest first
+func (c *Client) listNodePoolNodesByAge(ctx context.Context, projectID string, clusterID string, poolID string, count int) ([]Node, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("node count should not be negative, got %d", count)
+	}
+
+	nodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].CreatedAt.Before(nodes[j].CreatedAt)
+	})
+
+	return nodes, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newNodeAgeTestClient(t *testing.T) *Client {
+	now := time.Now().Truncate(time.Second).UTC()
+
+	// Nodes are voluntarily shuffled
+	nodes := []Node{
+		{ID: "node-3", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "node-1", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "node-4", CreatedAt: now},
+		{ID: "node-2", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "pool"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(nodes)
+	})
+
+	return newTestClient(t, mux)
+}
+
+func nodeIDs(nodes []Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+
+	return ids
+}
+
+func TestClient_GetOldestNodes(t *testing.T) {
+	client := newNodeAgeTestClient(t)
+	ctx := context.Background()
+
+	t.Run("check oldest nodes come first", func(t *testing.T) {
+		nodes, err := client.GetOldestNodes(ctx, "projectID", "clusterID", "poolID", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-1", "node-2"}, nodeIDs(nodes))
+	})
+
+	t.Run("check requesting more nodes than existing returns all of them", func(t *testing.T) {
+		nodes, err := client.GetOldestNodes(ctx, "projectID", "clusterID", "poolID", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-1", "node-2", "node-3", "node-4"}, nodeIDs(nodes))
+	})
+
+	t.Run("check negative count is rejected", func(t *testing.T) {
+		_, err := client.GetOldestNodes(ctx, "projectID", "clusterID", "poolID", -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_GetNewestNodes(t *testing.T) {
+	client := newNodeAgeTestClient(t)
+	ctx := context.Background()
+
+	t.Run("check newest nodes come first", func(t *testing.T) {
+		nodes, err := client.GetNewestNodes(ctx, "projectID", "clusterID", "poolID", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-4", "node-3"}, nodeIDs(nodes))
+	})
+
+	t.Run("check requesting more nodes than existing returns all of them", func(t *testing.T) {
+		nodes, err := client.GetNewestNodes(ctx, "projectID", "clusterID", "poolID", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-4", "node-3", "node-2", "node-1"}, nodeIDs(nodes))
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultBatchDeleteConcurrency is the number of nodes deleted at the same time when none is given
+const DefaultBatchDeleteConcurrency = 5
+
+// BatchDeleteResult tells which nodes of a batch were deleted and which failed to be
+type BatchDeleteResult struct {
+	Deleted []string
+	Failed  map[string]error
+}
+
+// BatchDeleteNodes deletes nodes of a cluster, at most concurrency at the same time (DefaultBatchDeleteConcurrency
+// when not positive), as the API has no bulk deletion of nodes. Every node is attempted: the returned error
+// joins the errors of all the nodes which failed to be deleted, and the result tells them apart.
+func (c *Client) BatchDeleteNodes(ctx context.Context, projectID string, clusterID string, nodeIDs []string, concurrency int) (*BatchDeleteResult, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchDeleteConcurrency
+	}
+
+	result := &BatchDeleteResult{
+		Deleted: make([]string, 0, len(nodeIDs)),
+		Failed:  make(map[string]error),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+
+			var err error
+			select {
+			case semaphore <- struct{}{}:
+				err = c.DeleteNode(ctx, projectID, clusterID, nodeID)
+				<-semaphore
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				result.Failed[nodeID] = err
+			} else {
+				result.Deleted = append(result.Deleted, nodeID)
+			}
+		}(nodeID)
+	}
+	wg.Wait()
+
+	if len(result.Failed) == 0 {
+		return result, nil
+	}
+
+	// Errors are joined in a stable order
+	failed := make([]string, 0, len(result.Failed))
+	for nodeID := range result.Failed {
+		failed = append(failed, nodeID)
+	}
+	sort.Strings(failed)
+
+	errs := make([]error, 0, len(failed))
+	for _, nodeID := range failed {
+		errs = append(errs, fmt.Errorf("failed to delete node %s: %w", nodeID, result.Failed[nodeID]))
+	}
+
+	return result, errors.Join(errs...)
+}
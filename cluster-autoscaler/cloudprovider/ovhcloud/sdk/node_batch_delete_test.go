@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_BatchDeleteNodes(t *testing.T) {
+	var running, maxRunning int32
+	var mutex sync.Mutex
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/node/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		mutex.Lock()
+		if current > maxRunning {
+			maxRunning = current
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		if strings.HasPrefix(r.URL.Path, "/cloud/project/projectID/kube/clusterID/node/broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "node is locked"}`))
+		}
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check nodes are deleted with bounded concurrency", func(t *testing.T) {
+		nodeIDs := []string{"node-1", "node-2", "node-3", "node-4", "node-5", "node-6"}
+
+		result, err := client.BatchDeleteNodes(context.Background(), "projectID", "clusterID", nodeIDs, 2)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, nodeIDs, result.Deleted)
+		assert.Empty(t, result.Failed)
+		assert.LessOrEqual(t, maxRunning, int32(2))
+	})
+
+	t.Run("check failures are reported per node", func(t *testing.T) {
+		result, err := client.BatchDeleteNodes(context.Background(), "projectID", "clusterID", []string{"node-1", "broken-1", "broken-2"}, 0)
+		assert.ErrorContains(t, err, "failed to delete node broken-1")
+		assert.ErrorContains(t, err, "failed to delete node broken-2")
+		assert.Equal(t, []string{"node-1"}, result.Deleted)
+		assert.Len(t, result.Failed, 2)
+		assert.Contains(t, result.Failed, "broken-1")
+	})
+
+	t.Run("check cancelled context stops the deletions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		result, err := client.BatchDeleteNodes(ctx, "projectID", "clusterID", []string{"node-1"}, 1)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Contains(t, result.Failed, "node-1")
+	})
+}
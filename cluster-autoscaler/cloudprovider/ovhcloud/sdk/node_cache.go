@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultNodeCacheTTL is how long the nodes of a node pool are served from the cache
+const DefaultNodeCacheTTL = 15 * time.Second
+
+// NodeCacheWarmupInterval is the delay between two refreshes of the node cache by StartCacheWarmup,
+// shorter than the default TTL for the nodes to stay cached
+var NodeCacheWarmupInterval = 10 * time.Second
+
+type nodeCacheEntry struct {
+	nodes     []Node
+	expiresAt time.Time
+}
+
+// NodeCache keeps the nodes of the node pools read from the API for a TTL.
+// It is safe for concurrent use, and caches nothing when its TTL is not positive.
+type NodeCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	entries map[nodePoolCacheKey]nodeCacheEntry
+	mutex   sync.RWMutex
+}
+
+// NewNodeCache creates a node cache keeping entries for ttl
+func NewNodeCache(ttl time.Duration, clock Clock) *NodeCache {
+	return &NodeCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[nodePoolCacheKey]nodeCacheEntry),
+	}
+}
+
+// Get returns a copy of the cached nodes of the node pool, if any and not expired
+func (c *NodeCache) Get(clusterID string, poolID string) ([]Node, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, ok := c.entries[nodePoolCacheKey{clusterID, poolID}]
+	if !ok || !c.clock.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	return append([]Node{}, entry.nodes...), true
+}
+
+// Set caches a copy of the nodes of the node pool
+func (c *NodeCache) Set(clusterID string, poolID string, nodes []Node) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[nodePoolCacheKey{clusterID, poolID}] = nodeCacheEntry{
+		nodes:     append([]Node{}, nodes...),
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+}
+
+// Invalidate removes the nodes of the node pool from the cache
+func (c *NodeCache) Invalidate(clusterID string, poolID string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, nodePoolCacheKey{clusterID, poolID})
+}
+
+// InvalidateCluster removes the nodes of every node pool of the cluster from the cache
+func (c *NodeCache) InvalidateCluster(clusterID string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.entries {
+		if key.clusterID == clusterID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// WithNodeCacheTTL sets how long the nodes of the node pools are cached, a zero TTL disabling the cache
+func WithNodeCacheTTL(ttl time.Duration) ClientOption {
+	return func(client *Client) error {
+		client.nodeCacheTTL = ttl
+		return nil
+	}
+}
+
+// InvalidateNodeCache removes the cached nodes of the node pool, for the next listing to read them from the API
+func (c *Client) InvalidateNodeCache(clusterID string, poolID string) {
+	c.NodeCache.Invalidate(clusterID, poolID)
+}
+
+// StartCacheWarmup refreshes the cached nodes of every node pool of the cluster,
+// then keeps refreshing them every NodeCacheWarmupInterval in the background until the context is done.
+// The first refresh is synchronous, its error being returned, while the later errors are only logged.
+func (c *Client) StartCacheWarmup(ctx context.Context, projectID string, clusterID string) error {
+	if c.NodeCache == nil || c.NodeCache.ttl <= 0 {
+		return errors.New("node cache is disabled")
+	}
+
+	if err := c.warmupNodeCache(ctx, projectID, clusterID); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.clock.After(NodeCacheWarmupInterval):
+			}
+
+			if err := c.warmupNodeCache(ctx, projectID, clusterID); err != nil && ctx.Err() == nil {
+				klog.Warningf("Failed to refresh node cache of cluster %s: %v", clusterID, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// warmupNodeCache reads the nodes of every node pool of the cluster from the API into the cache
+func (c *Client) warmupNodeCache(ctx context.Context, projectID string, clusterID string) error {
+	pools, err := c.ListNodePools(ctx, projectID, clusterID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pool := range pools {
+		nodes := make([]Node, 0)
+
+		err := c.fetchAll(ctx, fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/nodes", projectID, clusterID, pool.ID), nil, &nodes)
+		if err != nil {
+			if !IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		c.NodeCache.Set(clusterID, pool.ID, nodes)
+	}
+
+	return errors.Join(errs...)
+}
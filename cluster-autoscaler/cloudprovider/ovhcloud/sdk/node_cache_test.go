@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCache(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	cache := NewNodeCache(15*time.Second, clock)
+
+	nodes := []Node{{ID: "node-1"}, {ID: "node-2"}}
+
+	t.Run("check cached nodes are copies", func(t *testing.T) {
+		cache.Set("clusterID", "poolID", nodes)
+		nodes[0].Status = "DELETING"
+
+		cached, ok := cache.Get("clusterID", "poolID")
+		assert.True(t, ok)
+		assert.Empty(t, cached[0].Status)
+
+		cached[1].Status = "DELETING"
+		cached, _ = cache.Get("clusterID", "poolID")
+		assert.Empty(t, cached[1].Status)
+	})
+
+	t.Run("check entries expire after the TTL", func(t *testing.T) {
+		clock.Advance(14 * time.Second)
+		_, ok := cache.Get("clusterID", "poolID")
+		assert.True(t, ok)
+
+		clock.Advance(time.Second)
+		_, ok = cache.Get("clusterID", "poolID")
+		assert.False(t, ok)
+	})
+
+	t.Run("check entries are invalidated", func(t *testing.T) {
+		cache.Set("clusterID", "pool-1", nodes)
+		cache.Set("clusterID", "pool-2", nodes)
+		cache.Set("otherClusterID", "pool-1", nodes)
+
+		cache.Invalidate("clusterID", "pool-1")
+		_, ok := cache.Get("clusterID", "pool-1")
+		assert.False(t, ok)
+		_, ok = cache.Get("clusterID", "pool-2")
+		assert.True(t, ok)
+
+		cache.InvalidateCluster("clusterID")
+		_, ok = cache.Get("clusterID", "pool-2")
+		assert.False(t, ok)
+		_, ok = cache.Get("otherClusterID", "pool-1")
+		assert.True(t, ok)
+	})
+
+	t.Run("check nothing is cached without TTL", func(t *testing.T) {
+		disabled := NewNodeCache(0, clock)
+		disabled.Set("clusterID", "poolID", nodes)
+
+		_, ok := disabled.Get("clusterID", "poolID")
+		assert.False(t, ok)
+	})
+}
+
+func TestClient_ListNodePoolNodesCached(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "pool"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprintf(w, `[{"id": "node-%d"}]`, calls)
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/node/nodeID", func(w http.ResponseWriter, r *http.Request) {})
+
+	client := newTestClient(t, mux)
+
+	t.Run("check nodes are read once within the TTL", func(t *testing.T) {
+		_, err := client.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "poolID")
+			assert.NoError(t, err)
+			assert.Equal(t, []Node{{ID: "node-1", NodePoolName: "pool"}}, nodes)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("check filtered listings are not cached", func(t *testing.T) {
+		_, err := client.ListNodePoolNodesWithOpts(ctx, "projectID", "clusterID", "poolID", &NodeListOpts{StatusFilter: "READY"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, "node-1", nodes[0].ID)
+	})
+
+	t.Run("check the nodes are invalidated", func(t *testing.T) {
+		client.InvalidateNodeCache("clusterID", "poolID")
+
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, "node-3", nodes[0].ID)
+	})
+
+	t.Run("check node deletions invalidate the cluster nodes", func(t *testing.T) {
+		err := client.DeleteNode(ctx, "projectID", "clusterID", "nodeID")
+		assert.NoError(t, err)
+
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, "node-4", nodes[0].ID)
+	})
+}
+
+func TestClient_StartCacheWarmup(t *testing.T) {
+	var calls int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "pool-1"}, {"id": "pool-2"}]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		_, _ = fmt.Fprintf(w, `[{"id": "node-%d"}]`, call)
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-2/nodes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "node pool not found"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(StaticTokenProvider("token")), WithClock(clock))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	t.Run("check the nodes are cached before returning", func(t *testing.T) {
+		err := client.StartCacheWarmup(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+
+		nodes, ok := client.NodeCache.Get("clusterID", "pool-1")
+		assert.True(t, ok)
+		assert.Equal(t, "node-1", nodes[0].ID)
+
+		_, ok = client.NodeCache.Get("clusterID", "pool-2")
+		assert.False(t, ok)
+	})
+
+	t.Run("check the nodes are refreshed in the background", func(t *testing.T) {
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		clock.Advance(NodeCacheWarmupInterval)
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 && atomic.LoadInt32(&calls) == 2 }, time.Second, time.Millisecond)
+
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "pool-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "node-2", nodes[0].ID)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("check the warmup fails without cache", func(t *testing.T) {
+		disabled, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(StaticTokenProvider("token")), WithNodeCacheTTL(0))
+		assert.NoError(t, err)
+
+		err = disabled.StartCacheWarmup(ctx, "projectID", "clusterID")
+		assert.Error(t, err)
+	})
+}
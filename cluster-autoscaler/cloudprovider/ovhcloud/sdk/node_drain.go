@@ -0,0 +1,210 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+)
+
+// DrainWaitSeconds is the maximum time waited for the evicted pods of a drained node to be gone
+var DrainWaitSeconds = 300
+
+// DrainNode cordons a node and evicts its pods, except the DaemonSet and mirror ones, then waits
+// for them to be gone, for at most DrainWaitSeconds. It returns the names of the evicted pods.
+// Pods annotated as not safe to evict, or using local storage not annotated as safe to evict, prevent the drain.
+// Evictions blocked by a PodDisruptionBudget are retried every retryInterval until the context is done.
+func DrainNode(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, gracePeriod int64, retryInterval time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(DrainWaitSeconds)*time.Second)
+	defer cancel()
+
+	if err := cordonNode(ctx, k8sClient, nodeName); err != nil {
+		return nil, err
+	}
+
+	pods, err := listNodePods(ctx, k8sClient, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	toEvict := make([]*v1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != nodeName || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || isDaemonSetPod(pod) || pod_util.IsMirrorPod(pod) {
+			continue
+		}
+
+		if err := checkSafeToEvict(pod); err != nil {
+			return nil, fmt.Errorf("failed to drain node %s: %w", nodeName, err)
+		}
+
+		toEvict = append(toEvict, pod)
+	}
+
+	evicted := make([]string, 0, len(toEvict))
+	for _, pod := range toEvict {
+		err := EvictPodWithRetry(ctx, k8sClient, pod, gracePeriod, retryInterval)
+		if err != nil {
+			return evicted, fmt.Errorf("failed to drain node %s: %w", nodeName, err)
+		}
+
+		evicted = append(evicted, pod.Name)
+	}
+
+	if err := waitForPodsGone(ctx, k8sClient, toEvict, retryInterval); err != nil {
+		return evicted, fmt.Errorf("failed to drain node %s: %w", nodeName, err)
+	}
+
+	return evicted, nil
+}
+
+// cordonNode marks a node as unschedulable
+func cordonNode(ctx context.Context, k8sClient kubernetes.Interface, nodeName string) error {
+	_, err := k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, []byte(`{"spec":{"unschedulable":true}}`), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// checkSafeToEvict returns an error if the annotations of a pod prevent its eviction
+func checkSafeToEvict(pod *v1.Pod) error {
+	if drain.HasNotSafeToEvictAnnotation(pod) {
+		return fmt.Errorf("pod %s/%s is annotated with %s=false", pod.Namespace, pod.Name, drain.PodSafeToEvictKey)
+	}
+
+	if drain.HasBlockingLocalStorage(pod) && !drain.HasSafeToEvictAnnotation(pod) {
+		return fmt.Errorf("pod %s/%s uses local storage not listed in %s", pod.Namespace, pod.Name, drain.SafeToEvictLocalVolumesKey)
+	}
+
+	return nil
+}
+
+// waitForPodsGone waits until the pods are deleted, or replaced by new pods with the same name
+func waitForPodsGone(ctx context.Context, k8sClient kubernetes.Interface, pods []*v1.Pod, interval time.Duration) error {
+	remaining := pods
+
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		pending := make([]*v1.Pod, 0, len(remaining))
+		for _, pod := range remaining {
+			current, err := k8sClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to get pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+
+			pending = append(pending, pod)
+		}
+		remaining = pending
+
+		return len(remaining) == 0, nil
+	})
+	if err != nil && ctx.Err() != nil && len(remaining) > 0 {
+		names := make([]string, 0, len(remaining))
+		for _, pod := range remaining {
+			names = append(names, pod.Namespace+"/"+pod.Name)
+		}
+
+		return fmt.Errorf("pods %s did not terminate in time: %w", strings.Join(names, ", "), err)
+	}
+
+	return err
+}
+
+// EvictPodWithRetry evicts a pod, retrying every retryInterval while the eviction is blocked
+// by a PodDisruptionBudget, until the context is done
+func EvictPodWithRetry(ctx context.Context, k8sClient kubernetes.Interface, pod *v1.Pod, gracePeriod int64, retryInterval time.Duration) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: &gracePeriod,
+		},
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := k8sClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		if err == nil || apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		// API servers without the eviction subresource only allow to delete the pod
+		if apierrors.IsMethodNotSupported(err) {
+			err = k8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+			if err == nil || apierrors.IsNotFound(err) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to delete pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+
+		if !IsPDBBlockedEvictionError(err) {
+			return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+
+		klog.V(2).Infof("Eviction of pod %s/%s blocked by its disruption budget (attempt %d), retrying in %s", pod.Namespace, pod.Name, attempt, retryInterval)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to evict pod %s/%s before deadline: %w", pod.Namespace, pod.Name, errors.Join(ctx.Err(), err))
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// IsPDBBlockedEvictionError tells whether an eviction was refused because it would violate a PodDisruptionBudget
+func IsPDBBlockedEvictionError(err error) bool {
+	if !apierrors.IsTooManyRequests(err) {
+		return false
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type == policyv1.DisruptionBudgetCause {
+				return true
+			}
+		}
+	}
+
+	// API servers not reporting the cause only describe it in the message
+	return strings.Contains(status.Status().Message, "disruption budget")
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+)
+
+func newPDBBlockedEvictionError() error {
+	err := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+	err.ErrStatus.Details.Causes = []metav1.StatusCause{{Type: policyv1.DisruptionBudgetCause, Message: "The disruption budget web-pdb needs 2 healthy pods"}}
+
+	return err
+}
+
+// blockEvictions makes the first blocked evictions fail because of a disruption budget, and returns the evictions count
+func blockEvictions(k8sClient *fake.Clientset, blocked int) *int {
+	evictions := 0
+	k8sClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		evictions++
+		if evictions <= blocked {
+			return true, nil, newPDBBlockedEvictionError()
+		}
+
+		// Evicted pods terminate right away
+		eviction := action.(core.CreateAction).GetObject().(*policyv1.Eviction)
+		_ = k8sClient.Tracker().Delete(v1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+
+		return true, nil, nil
+	})
+
+	return &evictions
+}
+
+func TestEvictPodWithRetry(t *testing.T) {
+	pod := newTestPod("web", "node-1", "1")
+
+	t.Run("check blocked eviction is retried", func(t *testing.T) {
+		k8sClient := fake.NewSimpleClientset(&pod)
+		evictions := blockEvictions(k8sClient, 2)
+
+		err := EvictPodWithRetry(context.Background(), k8sClient, &pod, 30, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *evictions)
+	})
+
+	t.Run("check blocked eviction gives up at the context deadline", func(t *testing.T) {
+		k8sClient := fake.NewSimpleClientset(&pod)
+		blockEvictions(k8sClient, 1000)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := EvictPodWithRetry(ctx, k8sClient, &pod, 30, time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, IsPDBBlockedEvictionError(err))
+	})
+
+	t.Run("check other errors are not retried", func(t *testing.T) {
+		k8sClient := fake.NewSimpleClientset(&pod)
+		k8sClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(v1.Resource("pods"), "web", errors.New("denied"))
+		})
+
+		err := EvictPodWithRetry(context.Background(), k8sClient, &pod, 30, time.Millisecond)
+		assert.Error(t, err)
+		assert.False(t, IsPDBBlockedEvictionError(err))
+	})
+}
+
+func TestIsPDBBlockedEvictionError(t *testing.T) {
+	assert.True(t, IsPDBBlockedEvictionError(newPDBBlockedEvictionError()))
+	assert.True(t, IsPDBBlockedEvictionError(apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)))
+	assert.False(t, IsPDBBlockedEvictionError(apierrors.NewTooManyRequests("too many requests", 1)))
+	assert.False(t, IsPDBBlockedEvictionError(errors.New("disruption budget")))
+	assert.False(t, IsPDBBlockedEvictionError(nil))
+}
+
+func TestDrainNode(t *testing.T) {
+	web := newTestPod("web", "node-1", "1")
+	done := newTestPod("done", "node-1", "1")
+	done.Status.Phase = v1.PodSucceeded
+
+	controller := true
+	agent := newTestPod("agent", "node-1", "1")
+	agent.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+
+	other := newTestPod("other", "node-2", "1")
+
+	mirror := newTestPod("static", "node-1", "1")
+	mirror.Annotations = map[string]string{"kubernetes.io/config.mirror": "mirror"}
+
+	cache := newTestPod("cache", "node-1", "1")
+	cache.Annotations = map[string]string{drain.SafeToEvictLocalVolumesKey: "scratch"}
+	cache.Spec.Volumes = []v1.Volume{{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+
+	node := newTestK8sNode("node-1", "4")
+
+	t.Run("check node is cordoned and drained", func(t *testing.T) {
+		k8sClient := fake.NewSimpleClientset(&node, &web, &done, &agent, &mirror, &cache, &other)
+		evictions := blockEvictions(k8sClient, 2)
+
+		evicted, err := DrainNode(context.Background(), k8sClient, "node-1", 30, time.Millisecond)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"web", "cache"}, evicted)
+		assert.Equal(t, 4, *evictions)
+
+		drained, err := k8sClient.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.True(t, drained.Spec.Unschedulable)
+	})
+
+	t.Run("check pods not safe to evict prevent the drain", func(t *testing.T) {
+		pinned := newTestPod("pinned", "node-1", "1")
+		pinned.Annotations = map[string]string{drain.PodSafeToEvictKey: "false"}
+
+		local := newTestPod("local", "node-1", "1")
+		local.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/data"}}}}
+
+		for _, pod := range []v1.Pod{pinned, local} {
+			k8sClient := fake.NewSimpleClientset(&node, &web, &pod)
+			evictions := blockEvictions(k8sClient, 0)
+
+			_, err := DrainNode(context.Background(), k8sClient, "node-1", 30, time.Millisecond)
+			assert.ErrorContains(t, err, pod.Name)
+			assert.Equal(t, 0, *evictions)
+		}
+
+		local.Annotations = map[string]string{drain.PodSafeToEvictKey: "true"}
+		k8sClient := fake.NewSimpleClientset(&node, &local)
+		blockEvictions(k8sClient, 0)
+
+		evicted, err := DrainNode(context.Background(), k8sClient, "node-1", 30, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"local"}, evicted)
+	})
+
+	t.Run("check pods are deleted without eviction API", func(t *testing.T) {
+		k8sClient := fake.NewSimpleClientset(&node, &web)
+		k8sClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+
+			return true, nil, apierrors.NewMethodNotSupported(v1.Resource("pods/eviction"), "create")
+		})
+
+		evicted, err := DrainNode(context.Background(), k8sClient, "node-1", 30, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"web"}, evicted)
+
+		_, err = k8sClient.CoreV1().Pods("default").Get(context.Background(), "web", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("check pods not terminating in time fail the drain", func(t *testing.T) {
+		defer func(wait int) { DrainWaitSeconds = wait }(DrainWaitSeconds)
+		DrainWaitSeconds = 1
+
+		k8sClient := fake.NewSimpleClientset(&node, &web)
+		k8sClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			return action.GetSubresource() == "eviction", nil, nil
+		})
+
+		evicted, err := DrainNode(context.Background(), k8sClient, "node-1", 30, 100*time.Millisecond)
+		assert.Equal(t, []string{"web"}, evicted)
+		assert.ErrorContains(t, err, "pods default/web did not terminate in time")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("check unknown node", func(t *testing.T) {
+		_, err := DrainNode(context.Background(), fake.NewSimpleClientset(), "node-1", 30, time.Millisecond)
+		assert.Error(t, err)
+	})
+}
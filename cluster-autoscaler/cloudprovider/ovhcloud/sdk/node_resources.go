@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeResourceRequests holds the resources requested by the pods of a node, and the node allocatable resources
+type NodeResourceRequests struct {
+	CPURequested    resource.Quantity
+	MemoryRequested resource.Quantity
+
+	CPUAllocatable    resource.Quantity
+	MemoryAllocatable resource.Quantity
+}
+
+// CPURatio returns the CPU requests utilisation of the node, from 0 to 1 (or more if overcommitted).
+// A node without allocatable CPU is considered fully used.
+func (r *NodeResourceRequests) CPURatio() float64 {
+	return requestsRatio(r.CPURequested, r.CPUAllocatable)
+}
+
+// MemoryRatio returns the memory requests utilisation of the node, from 0 to 1 (or more if overcommitted).
+// A node without allocatable memory is considered fully used.
+func (r *NodeResourceRequests) MemoryRatio() float64 {
+	return requestsRatio(r.MemoryRequested, r.MemoryAllocatable)
+}
+
+func requestsRatio(requested resource.Quantity, allocatable resource.Quantity) float64 {
+	if allocatable.MilliValue() <= 0 {
+		return 1
+	}
+
+	return float64(requested.MilliValue()) / float64(allocatable.MilliValue())
+}
+
+// GetNodeResourceRequests sums the container requests of the running pods of a node.
+// DaemonSet pods are ignored as they would not be rescheduled if the node were removed.
+func GetNodeResourceRequests(ctx context.Context, nodeName string, k8sClient kubernetes.Interface) (*NodeResourceRequests, error) {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	pods, err := listNodePods(ctx, k8sClient, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := &NodeResourceRequests{
+		CPURequested:      *resource.NewMilliQuantity(0, resource.DecimalSI),
+		MemoryRequested:   *resource.NewQuantity(0, resource.BinarySI),
+		CPUAllocatable:    node.Status.Allocatable.Cpu().DeepCopy(),
+		MemoryAllocatable: node.Status.Allocatable.Memory().DeepCopy(),
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != nodeName || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || isDaemonSetPod(pod) {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			requests.CPURequested.Add(*container.Resources.Requests.Cpu())
+			requests.MemoryRequested.Add(*container.Resources.Requests.Memory())
+		}
+	}
+
+	return requests, nil
+}
+
+// isDaemonSetPod tells whether a pod is controlled by a DaemonSet
+func isDaemonSetPod(pod *v1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+
+	return owner != nil && owner.Kind == "DaemonSet"
+}
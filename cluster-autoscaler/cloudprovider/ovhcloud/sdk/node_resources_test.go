@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNodeResourceRequests(t *testing.T) {
+	ctx := context.Background()
+
+	node := newTestK8sNode("node-1", "4")
+	other := newTestK8sNode("node-2", "4")
+
+	web := newTestPod("web", "node-1", "1")
+	web.Spec.Containers[0].Resources.Requests[v1.ResourceMemory] = resource.MustParse("1Gi")
+	web.Spec.Containers = append(web.Spec.Containers, v1.Container{
+		Name: "sidecar",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("500m"),
+				v1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	})
+
+	api := newTestPod("api", "node-1", "500m")
+	api.Spec.Containers[0].Resources.Requests[v1.ResourceMemory] = resource.MustParse("2Gi")
+
+	controller := true
+	agent := newTestPod("agent", "node-1", "1")
+	agent.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+
+	elsewhere := newTestPod("elsewhere", "node-2", "2")
+
+	k8sClient := fake.NewSimpleClientset(&node, &other, &web, &api, &agent, &elsewhere)
+
+	gibibytes := func(count int64) int64 {
+		return count << 30
+	}
+
+	t.Run("check requests of non-DaemonSet pods are summed", func(t *testing.T) {
+		requests, err := GetNodeResourceRequests(ctx, "node-1", k8sClient)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(2000), requests.CPURequested.MilliValue())
+		assert.Equal(t, gibibytes(4), requests.MemoryRequested.Value())
+		assert.Equal(t, int64(4000), requests.CPUAllocatable.MilliValue())
+		assert.Equal(t, gibibytes(8), requests.MemoryAllocatable.Value())
+
+		assert.Equal(t, 0.5, requests.CPURatio())
+		assert.Equal(t, 0.5, requests.MemoryRatio())
+	})
+
+	t.Run("check unknown node", func(t *testing.T) {
+		_, err := GetNodeResourceRequests(ctx, "missing", k8sClient)
+		assert.Error(t, err)
+	})
+
+	t.Run("check node without allocatable resources is fully used", func(t *testing.T) {
+		requests := &NodeResourceRequests{}
+		assert.Equal(t, 1.0, requests.CPURatio())
+		assert.Equal(t, 1.0, requests.MemoryRatio())
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TaintNode applies a taint to a Kubernetes node, replacing any taint with the same key
+func (c *Client) TaintNode(ctx context.Context, nodeName string, key string, value string, effect string, k8sClient kubernetes.Interface) error {
+	taintEffect := v1.TaintEffect(effect)
+	switch taintEffect {
+	case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("invalid taint effect %q", effect)
+	}
+
+	return patchNodeTaints(ctx, nodeName, k8sClient, func(taints []v1.Taint) []v1.Taint {
+		taints = withoutTaint(taints, key)
+
+		return append(taints, v1.Taint{Key: key, Value: value, Effect: taintEffect})
+	})
+}
+
+// RemoveTaint removes the taints with the given key from a Kubernetes node
+func (c *Client) RemoveTaint(ctx context.Context, nodeName string, key string, k8sClient kubernetes.Interface) error {
+	return patchNodeTaints(ctx, nodeName, k8sClient, func(taints []v1.Taint) []v1.Taint {
+		return withoutTaint(taints, key)
+	})
+}
+
+// HasTaint checks whether a node holds a taint with the given key
+func HasTaint(node *v1.Node, key string) bool {
+	_, ok := GetTaintValue(node, key)
+
+	return ok
+}
+
+// GetTaintValue returns the value of the node taint with the given key
+func GetTaintValue(node *v1.Node, key string) (string, bool) {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return taint.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// patchNodeTaints replaces the taints of a node with the updated ones using a strategic merge patch
+func patchNodeTaints(ctx context.Context, nodeName string, k8sClient kubernetes.Interface, update func(taints []v1.Taint) []v1.Taint) error {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"taints": update(node.Spec.Taints),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal taints patch: %w", err)
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch node %s taints: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+func withoutTaint(taints []v1.Taint, key string) []v1.Taint {
+	filtered := make([]v1.Taint, 0, len(taints))
+	for _, taint := range taints {
+		if taint.Key != key {
+			filtered = append(filtered, taint)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_TaintNode(t *testing.T) {
+	node := newTestK8sNode("node-1", "2")
+	node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectNoSchedule}}
+	k8sClient := fake.NewSimpleClientset(&node)
+
+	client := &Client{}
+	ctx := context.Background()
+
+	getNode := func() *v1.Node {
+		n, err := k8sClient.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		return n
+	}
+
+	t.Run("check taint is applied", func(t *testing.T) {
+		err := client.TaintNode(ctx, "node-1", "to-be-deleted", "autoscaler", "NoSchedule", k8sClient)
+		assert.NoError(t, err)
+
+		n := getNode()
+		assert.True(t, HasTaint(n, "dedicated"))
+		value, ok := GetTaintValue(n, "to-be-deleted")
+		assert.True(t, ok)
+		assert.Equal(t, "autoscaler", value)
+	})
+
+	t.Run("check taint with same key is replaced", func(t *testing.T) {
+		err := client.TaintNode(ctx, "node-1", "to-be-deleted", "operator", "NoExecute", k8sClient)
+		assert.NoError(t, err)
+
+		n := getNode()
+		assert.Len(t, n.Spec.Taints, 2)
+		value, _ := GetTaintValue(n, "to-be-deleted")
+		assert.Equal(t, "operator", value)
+	})
+
+	t.Run("check invalid effect is rejected", func(t *testing.T) {
+		err := client.TaintNode(ctx, "node-1", "key", "value", "NoWay", k8sClient)
+		assert.Error(t, err)
+	})
+
+	t.Run("check removal only removes the target key", func(t *testing.T) {
+		err := client.RemoveTaint(ctx, "node-1", "to-be-deleted", k8sClient)
+		assert.NoError(t, err)
+
+		n := getNode()
+		assert.False(t, HasTaint(n, "to-be-deleted"))
+		assert.True(t, HasTaint(n, "dedicated"))
+	})
+
+	t.Run("check unknown node", func(t *testing.T) {
+		err := client.TaintNode(ctx, "unknown", "key", "value", "NoSchedule", k8sClient)
+		assert.Error(t, err)
+	})
+}
@@ -19,9 +19,14 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
 )
 
 // NodePool defines the nodes group deployed on OVHcloud
@@ -47,21 +52,44 @@ type NodePool struct {
 
 	Autoscaling *NodePoolAutoscaling `json:"autoscaling,omitempty"`
 
-	Template struct {
-		Metadata struct {
-			Labels      map[string]string `json:"labels"`
-			Annotations map[string]string `json:"annotations"`
-			Finalizers  []string          `json:"finalizers"`
-		} `json:"metadata"`
+	// SSHKeyIDs are the SSH keys installed on the nodes, nil when not returned by the API
+	SSHKeyIDs []string `json:"sshKeyIds"`
 
-		Spec struct {
-			Unschedulable bool       `json:"unschedulable"`
-			Taints        []v1.Taint `json:"taints"`
-		} `json:"spec"`
-	} `json:"template"`
+	Template NodePoolTemplate `json:"template"`
 
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// ETag identifies the version of the node pool returned by GetNodePool, to update it with UpdateNodePoolOpts.IfMatch
+	ETag string `json:"-"`
+
+	// Resources of every node of the pool, set from its flavor with SetFlavorResources
+	CPUCount        int `json:"-"`
+	GPUCount        int `json:"-"`
+	MemoryMB        int `json:"-"`
+	EphemeralDiskGB int `json:"-"`
+}
+
+// SetFlavorResources sets the resources of the nodes of the pool from its flavor
+func (p *NodePool) SetFlavorResources(flavor Flavor) {
+	p.CPUCount = flavor.VCPUs
+	p.GPUCount = flavor.GPUs
+	p.MemoryMB = flavor.RAM * 1024
+	p.EphemeralDiskGB = flavor.Disk
+}
+
+// NodePoolTemplate defines the metadata and spec applied to every node of a node pool
+type NodePoolTemplate struct {
+	Metadata struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		Finalizers  []string          `json:"finalizers"`
+	} `json:"metadata"`
+
+	Spec struct {
+		Unschedulable bool       `json:"unschedulable"`
+		Taints        []v1.Taint `json:"taints"`
+	} `json:"spec"`
 }
 
 // NodePoolAutoscaling defines the node group autoscaling options from OVHcloud API
@@ -78,28 +106,36 @@ type NodePoolAutoscaling struct {
 	ScaleDownUnreadyTimeSeconds  int32 `json:"scaleDownUnreadyTimeSeconds"`
 }
 
-// ListNodePools allows to list all node pools available in a cluster
+// ListNodePools allows to list all node pools available in a cluster, requesting every page of the list.
+// The listed node pools are cached for the following calls to GetNodePool.
 func (c *Client) ListNodePools(ctx context.Context, projectID, clusterID string) ([]NodePool, error) {
 	nodepools := make([]NodePool, 0)
 
-	return nodepools, c.CallAPIWithContext(
-		ctx,
-		"GET",
-		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool", projectID, clusterID),
-		nil,
-		&nodepools,
-		nil,
-		nil,
-		true,
-	)
+	err := c.fetchAll(ctx, fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool", projectID, clusterID), nil, &nodepools)
+	if err != nil {
+		return nodepools, err
+	}
+
+	for i := range nodepools {
+		c.NodePoolCache.Set(clusterID, nodepools[i].ID, &nodepools[i])
+	}
+
+	return nodepools, nil
 }
 
-// GetNodePool allows to display information for a specific node pool
+// GetNodePool allows to display information for a specific node pool, along with its ETag.
+// The node pool is served from the client NodePoolCache when it was read recently.
+// An ErrNodeGroupNotFound is returned when the node pool does not exist.
 func (c *Client) GetNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
+	if nodepool, ok := c.NodePoolCache.Get(clusterID, poolID); ok {
+		return nodepool, nil
+	}
+
 	nodepool := &NodePool{}
+	header := http.Header{}
 
-	return nodepool, c.CallAPIWithContext(
-		ctx,
+	err := c.CallAPIWithContext(
+		withResponseHeader(ctx, &header),
 		"GET",
 		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID),
 		nil,
@@ -108,22 +144,107 @@ func (c *Client) GetNodePool(ctx context.Context, projectID string, clusterID st
 		nil,
 		true,
 	)
+	if err != nil {
+		return nil, nodeGroupNotFound(poolID, err)
+	}
+	nodepool.ETag = header.Get("ETag")
+
+	c.NodePoolCache.Set(clusterID, poolID, nodepool)
+
+	return nodepool, nil
+}
+
+// NodeListOpts defines the nodes listed and the fields returned for each of them
+type NodeListOpts struct {
+	// Fields are the fields of the nodes returned by the API, all of them when empty
+	Fields []string
+	// StatusFilter restricts the list to the nodes with this status, when set
+	StatusFilter string
 }
 
-// ListNodePoolNodes allows to display nodes contained in a parent node pool
+// query returns the query parameters of the options
+func (opts *NodeListOpts) query() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+
+	if len(opts.Fields) > 0 {
+		fields := opts.Fields
+
+		// The status is needed to filter the nodes on the client side
+		if opts.StatusFilter != "" && !slices.Contains(fields, "status") {
+			fields = append(append([]string(nil), fields...), "status")
+		}
+
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if opts.StatusFilter != "" {
+		query.Set("status", opts.StatusFilter)
+	}
+
+	return query
+}
+
+// ListNodePoolNodes allows to display nodes contained in a parent node pool, requesting every page of the list.
+// The name of the node pool is set on the nodes, the node pool being read when it is not cached.
 func (c *Client) ListNodePoolNodes(ctx context.Context, projectID string, clusterID string, poolID string) ([]Node, error) {
+	return c.ListNodePoolNodesWithOpts(ctx, projectID, clusterID, poolID, nil)
+}
+
+// ListNodePoolNodesWithOpts allows to display nodes contained in a parent node pool, restricted by the options.
+// Nil options list every field of every node, served from the NodeCache when cached. The status filter
+// is also applied on the client side, as the API may ignore it. An ErrNodeGroupNotFound is returned when
+// the node pool does not exist.
+func (c *Client) ListNodePoolNodesWithOpts(ctx context.Context, projectID string, clusterID string, poolID string, opts *NodeListOpts) ([]Node, error) {
+	if opts == nil {
+		if nodes, ok := c.NodeCache.Get(clusterID, poolID); ok {
+			return c.withNodePoolName(ctx, projectID, clusterID, poolID, nodes), nil
+		}
+	}
+
 	nodes := make([]Node, 0)
 
-	return nodes, c.CallAPIWithContext(
-		ctx,
-		"GET",
-		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/nodes", projectID, clusterID, poolID),
-		nil,
-		&nodes,
-		nil,
-		nil,
-		true,
-	)
+	err := c.fetchAll(ctx, fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/nodes", projectID, clusterID, poolID), opts.query(), &nodes)
+	if err != nil {
+		return nodes, nodeGroupNotFound(poolID, err)
+	}
+
+	if opts == nil {
+		c.NodeCache.Set(clusterID, poolID, nodes)
+	}
+
+	if opts != nil && opts.StatusFilter != "" {
+		filtered := make([]Node, 0, len(nodes))
+		for _, node := range nodes {
+			if node.Status == opts.StatusFilter {
+				filtered = append(filtered, node)
+			}
+		}
+		nodes = filtered
+	}
+
+	return c.withNodePoolName(ctx, projectID, clusterID, poolID, nodes), nil
+}
+
+// withNodePoolName fills the node pool name of the nodes, reading the node pool when it is not cached.
+// The name is only used for display, the nodes are returned without it when the node pool cannot be read.
+func (c *Client) withNodePoolName(ctx context.Context, projectID string, clusterID string, poolID string, nodes []Node) []Node {
+	if len(nodes) == 0 {
+		return nodes
+	}
+
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		klog.Warningf("failed to get name of node pool %s: %v", poolID, err)
+		return nodes
+	}
+
+	for i := range nodes {
+		nodes[i].NodePoolName = pool.Name
+	}
+
+	return nodes
 }
 
 // CreateNodePoolOpts defines required fields to create a node pool
@@ -138,6 +259,13 @@ type CreateNodePoolOpts struct {
 	DesiredNodes *uint32 `json:"desiredNodes,omitempty"`
 	MinNodes     *uint32 `json:"minNodes,omitempty"`
 	MaxNodes     *uint32 `json:"maxNodes,omitempty"`
+
+	SSHKeyIDs []string `json:"sshKeyIds,omitempty"`
+
+	// Private network of the nodes, the public network being used when not set
+	NetworkID        string `json:"networkId,omitempty"`
+	SubnetID         string `json:"subnetId,omitempty"`
+	AssignFloatingIP bool   `json:"assignFloatingIp,omitempty"`
 }
 
 // CreateNodePool allows to creates a node pool in a cluster
@@ -165,36 +293,137 @@ type UpdateNodePoolOpts struct {
 	Autoscale *bool `json:"autoscale,omitempty"`
 
 	NodesToRemove []string `json:"nodesToRemove,omitempty"`
+
+	// SSHKeyIDs replaces the SSH keys of the nodes when set
+	SSHKeyIDs []string `json:"sshKeyIds,omitempty"`
+
+	Template *NodePoolTemplate `json:"template,omitempty"`
+
+	// IfMatch makes the update fail with ErrConflict when the node pool does not match this ETag anymore
+	IfMatch string `json:"-"`
 }
 
-// UpdateNodePool allows to update a specific node pool properties (this call is used for resize)
+// UpdateNodePool allows to update a specific node pool properties (this call is used for resize).
+// An ErrCooldownActive is returned when the node pool was mutated less than the client cooldown period ago.
+// The requested sizes are checked first, a ValidationError being returned when they break the invariants of the node pool.
 func (c *Client) UpdateNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *UpdateNodePoolOpts) (*NodePool, error) {
+	path := fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID)
+	if c.DryRun {
+		c.recordDryRun("PUT", path, opts)
+		return c.dryRunNodePool(clusterID, poolID, opts), nil
+	}
+
+	release, err := c.Cooldown.reserve(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateNodePoolUpdate(ctx, projectID, clusterID, poolID, opts); err != nil {
+		release()
+		return nil, err
+	}
+
+	defer c.NodePoolCache.Invalidate(clusterID, poolID)
+	defer c.InvalidateNodeCache(clusterID, poolID)
+
+	// The size before the update is known from the cache, as the node pools are listed on every refresh
+	previous, cached := c.NodePoolCache.Get(clusterID, poolID)
+
+	var headers map[string]interface{}
+	if opts != nil {
+		headers = ifMatchHeaders(opts.IfMatch)
+	}
+
 	nodepool := &NodePool{}
 
-	return nodepool, c.CallAPIWithContext(
+	err = c.CallAPIWithContext(
 		ctx,
 		"PUT",
-		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID),
+		path,
 		opts,
 		&nodepool,
 		nil,
+		headers,
+		true,
+	)
+	if err != nil {
+		release()
+		return nodepool, conflictError(poolID, err)
+	}
+
+	c.Cooldown.Record(poolID)
+
+	if opts != nil && opts.DesiredNodes != nil {
+		oldSize := nodepool.CurrentNodes
+		if cached {
+			oldSize = previous.DesiredNodes
+		}
+
+		c.notifyScale(clusterID, poolID, oldSize, *opts.DesiredNodes)
+	}
+
+	return nodepool, nil
+}
+
+// PatchNodePoolOpts defines the fields of a node pool to modify, the fields left nil being kept as they are
+type PatchNodePoolOpts struct {
+	DesiredNodes *uint32 `json:"desiredNodes,omitempty"`
+	MinNodes     *uint32 `json:"minNodes,omitempty"`
+	MaxNodes     *uint32 `json:"maxNodes,omitempty"`
+
+	Autoscale *bool `json:"autoscale,omitempty"`
+
+	// IfMatch makes the patch fail with ErrConflict when the node pool does not match this ETag anymore,
+	// e.g. to compare and set its minimum and maximum sizes
+	IfMatch string `json:"-"`
+}
+
+// PatchNodePool allows to modify some properties of a specific node pool, without reading it first
+func (c *Client) PatchNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *PatchNodePoolOpts) (*NodePool, error) {
+	release, err := c.Cooldown.reserve(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.NodePoolCache.Invalidate(clusterID, poolID)
+
+	var headers map[string]interface{}
+	if opts != nil {
+		headers = ifMatchHeaders(opts.IfMatch)
+	}
+
+	nodepool := &NodePool{}
+
+	err = c.CallAPIWithContext(
+		ctx,
+		"PATCH",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID),
+		opts,
+		&nodepool,
 		nil,
+		headers,
 		true,
 	)
+	if err != nil {
+		release()
+		return nodepool, conflictError(poolID, err)
+	}
+
+	c.Cooldown.Record(poolID)
+
+	return nodepool, nil
 }
 
 // DeleteNodePool allows to delete a specific node pool
 func (c *Client) DeleteNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
+	defer c.NodePoolCache.Invalidate(clusterID, poolID)
+
 	nodepool := &NodePool{}
 
-	return nodepool, c.CallAPIWithContext(
+	return nodepool, c.DeleteWithContext(
 		ctx,
-		"DELETE",
 		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID),
-		nil,
 		&nodepool,
 		nil,
-		nil,
-		true,
 	)
 }
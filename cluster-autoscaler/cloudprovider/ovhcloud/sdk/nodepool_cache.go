@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultNodePoolCacheTTL is how long a node pool is served from the cache
+const DefaultNodePoolCacheTTL = 30 * time.Second
+
+type nodePoolCacheKey struct {
+	clusterID string
+	poolID    string
+}
+
+type nodePoolCacheEntry struct {
+	pool      NodePool
+	expiresAt time.Time
+}
+
+// NodePoolCache keeps the node pools read from the API for a TTL.
+// It is safe for concurrent use, and caches nothing when its TTL is not positive.
+type NodePoolCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	entries map[nodePoolCacheKey]nodePoolCacheEntry
+	mutex   sync.RWMutex
+}
+
+// NewNodePoolCache creates a node pool cache keeping entries for ttl
+func NewNodePoolCache(ttl time.Duration, clock Clock) *NodePoolCache {
+	return &NodePoolCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[nodePoolCacheKey]nodePoolCacheEntry),
+	}
+}
+
+// Get returns a copy of the cached node pool, if any and not expired
+func (c *NodePoolCache) Get(clusterID string, poolID string) (*NodePool, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, ok := c.entries[nodePoolCacheKey{clusterID, poolID}]
+	if !ok || !c.clock.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	return copyNodePool(&entry.pool), true
+}
+
+// Set caches a copy of the node pool
+func (c *NodePoolCache) Set(clusterID string, poolID string, pool *NodePool) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[nodePoolCacheKey{clusterID, poolID}] = nodePoolCacheEntry{
+		pool:      *copyNodePool(pool),
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+}
+
+// Invalidate removes the node pool from the cache
+func (c *NodePoolCache) Invalidate(clusterID string, poolID string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, nodePoolCacheKey{clusterID, poolID})
+}
+
+// InvalidateCluster removes every node pool of the cluster from the cache
+func (c *NodePoolCache) InvalidateCluster(clusterID string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.entries {
+		if key.clusterID == clusterID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// WithNodePoolCacheTTL sets how long the node pools are cached, a zero TTL disabling the cache
+func WithNodePoolCacheTTL(ttl time.Duration) ClientOption {
+	return func(client *Client) error {
+		client.nodePoolCacheTTL = ttl
+		return nil
+	}
+}
+
+// copyNodePool returns a copy of the node pool not sharing its template maps and slices,
+// the callers being free to modify the node pools they get
+func copyNodePool(pool *NodePool) *NodePool {
+	copied := *pool
+
+	if pool.Autoscaling != nil {
+		autoscaling := *pool.Autoscaling
+		copied.Autoscaling = &autoscaling
+	}
+	if pool.SSHKeyIDs != nil {
+		copied.SSHKeyIDs = append([]string{}, pool.SSHKeyIDs...)
+	}
+
+	metadata := &copied.Template.Metadata
+	if pool.Template.Metadata.Labels != nil {
+		metadata.Labels = copyStringMap(pool.Template.Metadata.Labels)
+	}
+	if pool.Template.Metadata.Annotations != nil {
+		metadata.Annotations = copyStringMap(pool.Template.Metadata.Annotations)
+	}
+	if pool.Template.Metadata.Finalizers != nil {
+		metadata.Finalizers = append([]string{}, pool.Template.Metadata.Finalizers...)
+	}
+	if pool.Template.Spec.Taints != nil {
+		copied.Template.Spec.Taints = append([]v1.Taint{}, pool.Template.Spec.Taints...)
+	}
+
+	return &copied
+}
+
+// getFreshNodePool reads a node pool from the API, for the checks and updates which must not rely on a cached state
+func (c *Client) getFreshNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
+	c.NodePoolCache.Invalidate(clusterID, poolID)
+
+	return c.GetNodePool(ctx, projectID, clusterID, poolID)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodePoolCache(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	cache := NewNodePoolCache(30*time.Second, clock)
+
+	pool := &NodePool{ID: "poolID", DesiredNodes: 2}
+	pool.Template.Metadata.Labels = map[string]string{"role": "worker"}
+
+	t.Run("check cached node pools are copies", func(t *testing.T) {
+		cache.Set("clusterID", "poolID", pool)
+		pool.Template.Metadata.Labels["role"] = "modified"
+
+		cached, ok := cache.Get("clusterID", "poolID")
+		assert.True(t, ok)
+		assert.Equal(t, "worker", cached.Template.Metadata.Labels["role"])
+
+		cached.DesiredNodes = 5
+		cached, _ = cache.Get("clusterID", "poolID")
+		assert.Equal(t, uint32(2), cached.DesiredNodes)
+
+		_, ok = cache.Get("otherClusterID", "poolID")
+		assert.False(t, ok)
+	})
+
+	t.Run("check entries expire after the TTL", func(t *testing.T) {
+		clock.Advance(29 * time.Second)
+		_, ok := cache.Get("clusterID", "poolID")
+		assert.True(t, ok)
+
+		clock.Advance(time.Second)
+		_, ok = cache.Get("clusterID", "poolID")
+		assert.False(t, ok)
+	})
+
+	t.Run("check entries are invalidated", func(t *testing.T) {
+		cache.Set("clusterID", "pool-1", pool)
+		cache.Set("clusterID", "pool-2", pool)
+		cache.Set("otherClusterID", "pool-1", pool)
+
+		cache.Invalidate("clusterID", "pool-1")
+		_, ok := cache.Get("clusterID", "pool-1")
+		assert.False(t, ok)
+		_, ok = cache.Get("clusterID", "pool-2")
+		assert.True(t, ok)
+
+		cache.InvalidateCluster("clusterID")
+		_, ok = cache.Get("clusterID", "pool-2")
+		assert.False(t, ok)
+		_, ok = cache.Get("otherClusterID", "pool-1")
+		assert.True(t, ok)
+	})
+
+	t.Run("check nothing is cached without TTL", func(t *testing.T) {
+		disabled := NewNodePoolCache(0, clock)
+		disabled.Set("clusterID", "poolID", pool)
+
+		_, ok := disabled.Get("clusterID", "poolID")
+		assert.False(t, ok)
+	})
+
+	t.Run("check concurrent accesses", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				poolID := fmt.Sprintf("pool-%d", i%3)
+				cache.Set("clusterID", poolID, pool)
+				cache.Get("clusterID", poolID)
+				cache.Invalidate("clusterID", poolID)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func TestClient_GetNodePoolCached(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "pool-1", "desiredNodes": 2}]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			calls++
+		}
+		_, _ = fmt.Fprintf(w, `{"id": "pool-2", "desiredNodes": %d}`, calls)
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/node/nodeID", func(w http.ResponseWriter, r *http.Request) {})
+
+	client := newTestClient(t, mux)
+
+	t.Run("check node pools are read once within the TTL", func(t *testing.T) {
+		pool, err := client.GetNodePool(ctx, "projectID", "clusterID", "pool-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), pool.DesiredNodes)
+
+		pool, err = client.GetNodePool(ctx, "projectID", "clusterID", "pool-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), pool.DesiredNodes)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("check listed node pools are cached", func(t *testing.T) {
+		_, err := client.ListNodePools(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+
+		pool, err := client.GetNodePool(ctx, "projectID", "clusterID", "pool-1")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), pool.DesiredNodes)
+	})
+
+	t.Run("check updates invalidate the node pool", func(t *testing.T) {
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "pool-2", &UpdateNodePoolOpts{})
+		assert.NoError(t, err)
+
+		pool, err := client.GetNodePool(ctx, "projectID", "clusterID", "pool-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), pool.DesiredNodes)
+	})
+
+	t.Run("check node deletions invalidate the cluster node pools", func(t *testing.T) {
+		err := client.DeleteNode(ctx, "projectID", "clusterID", "nodeID")
+		assert.NoError(t, err)
+
+		pool, err := client.GetNodePool(ctx, "projectID", "clusterID", "pool-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(3), pool.DesiredNodes)
+	})
+}
+
+func TestWithNodePoolCacheTTL(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"id": "poolID"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(StaticTokenProvider("token")), WithNodePoolCacheTTL(0))
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetNodePool(context.Background(), "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CordonConcurrency is the number of nodes patched at the same time when cordoning or uncordoning a node pool
+const CordonConcurrency = 10
+
+// CordonNodePool marks every node of a node pool as unschedulable, so no new pod is scheduled on them
+// while the pool is drained before a scale down. Nodes not registered in Kubernetes yet are skipped.
+// Every node is attempted: the returned error joins the errors of all the nodes which failed to be cordoned.
+func (c *Client) CordonNodePool(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) error {
+	return c.patchNodePoolUnschedulable(ctx, projectID, clusterID, poolID, true, k8sClient)
+}
+
+// UncordonNodePool marks every node of a node pool as schedulable again, like CordonNodePool
+func (c *Client) UncordonNodePool(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) error {
+	return c.patchNodePoolUnschedulable(ctx, projectID, clusterID, poolID, false, k8sClient)
+}
+
+// patchNodePoolUnschedulable patches the nodes of a node pool concurrently, at most CordonConcurrency at the same time
+func (c *Client) patchNodePoolUnschedulable(ctx context.Context, projectID string, clusterID string, poolID string, unschedulable bool, k8sClient kubernetes.Interface) error {
+	poolNodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+
+	failed := make(map[string]error)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, CordonConcurrency)
+
+	for _, poolNode := range poolNodes {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			var err error
+			select {
+			case semaphore <- struct{}{}:
+				_, err = k8sClient.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+				<-semaphore
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			if err == nil || apierrors.IsNotFound(err) {
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			failed[name] = err
+		}(poolNode.Name)
+	}
+	wg.Wait()
+
+	// Errors are joined in a stable order
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("failed to patch node %s: %w", name, failed[name]))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClient_CordonNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check pool nodes are cordoned then uncordoned", func(t *testing.T) {
+		client, _ := newTemplateTestClient(t, maintenanceTestHandlers)
+
+		node1 := newTestK8sNode("node-1", "2")
+		node2 := newTestK8sNode("node-2", "2")
+		other := newTestK8sNode("other", "2")
+		k8sClient := fake.NewSimpleClientset(&node1, &node2, &other)
+
+		isUnschedulable := func(name string) bool {
+			node, err := k8sClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+			assert.NoError(t, err)
+
+			return node.Spec.Unschedulable
+		}
+
+		err := client.CordonNodePool(ctx, "projectID", "clusterID", "poolID", k8sClient)
+		assert.NoError(t, err)
+		assert.True(t, isUnschedulable("node-1"))
+		assert.True(t, isUnschedulable("node-2"))
+		assert.False(t, isUnschedulable("other"))
+
+		err = client.UncordonNodePool(ctx, "projectID", "clusterID", "poolID", k8sClient)
+		assert.NoError(t, err)
+		assert.False(t, isUnschedulable("node-1"))
+		assert.False(t, isUnschedulable("node-2"))
+	})
+
+	t.Run("check errors of every node are returned", func(t *testing.T) {
+		client, _ := newTemplateTestClient(t, maintenanceTestHandlers)
+
+		node1 := newTestK8sNode("node-1", "2")
+		node2 := newTestK8sNode("node-2", "2")
+		k8sClient := fake.NewSimpleClientset(&node1, &node2)
+		k8sClient.PrependReactor("patch", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+
+		err := client.CordonNodePool(ctx, "projectID", "clusterID", "poolID", k8sClient)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to patch node node-1")
+		assert.Contains(t, err.Error(), "failed to patch node node-2")
+	})
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DiffActionAdd marks a field set in the new node pool only
+	DiffActionAdd = "add"
+
+	// DiffActionRemove marks a field set in the old node pool only
+	DiffActionRemove = "remove"
+
+	// DiffActionChange marks a field whose value differs between the old and new node pools
+	DiffActionChange = "change"
+)
+
+// NodePoolFieldChange describes the change of one node pool field, named after its JSON path
+// (e.g. "template.metadata.labels.team" or "template.spec.taints[0].effect")
+type NodePoolFieldChange struct {
+	Field  string `json:"field"`
+	Action string `json:"action"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// DiffNodePools lists the changes of every field between two node pools, sorted by field
+func DiffNodePools(before, after *NodePool) ([]NodePoolFieldChange, error) {
+	oldFields, err := flattenNodePool(before)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := flattenNodePool(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]NodePoolFieldChange, 0)
+	for field, oldValue := range oldFields {
+		newValue, ok := newFields[field]
+		switch {
+		case !ok:
+			changes = append(changes, NodePoolFieldChange{Field: field, Action: DiffActionRemove, Old: oldValue})
+		case newValue != oldValue:
+			changes = append(changes, NodePoolFieldChange{Field: field, Action: DiffActionChange, Old: oldValue, New: newValue})
+		}
+	}
+
+	for field, newValue := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, NodePoolFieldChange{Field: field, Action: DiffActionAdd, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
+
+// FormatNodePoolDiff describes the changes between two node pools like a Terraform plan,
+// one field per line: "~" for changed fields, "+" for added ones and "-" for removed ones
+func FormatNodePoolDiff(before, after *NodePool) string {
+	changes, err := DiffNodePools(before, after)
+	if err != nil {
+		return fmt.Sprintf("failed to compute node pool diff: %v\n", err)
+	}
+
+	var b strings.Builder
+	for _, change := range changes {
+		switch change.Action {
+		case DiffActionAdd:
+			fmt.Fprintf(&b, "+ %s: %q\n", change.Field, change.New)
+		case DiffActionRemove:
+			fmt.Fprintf(&b, "- %s: %q\n", change.Field, change.Old)
+		default:
+			fmt.Fprintf(&b, "~ %s: %q → %q\n", change.Field, change.Old, change.New)
+		}
+	}
+
+	return b.String()
+}
+
+// FormatNodePoolDiffJSON returns the changes between two node pools as a JSON list of NodePoolFieldChange
+func FormatNodePoolDiffJSON(before, after *NodePool) ([]byte, error) {
+	changes, err := DiffNodePools(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(changes)
+}
+
+// flattenNodePool maps the JSON path of every scalar field of a node pool to its value.
+// Null fields and empty maps or lists are left out.
+func flattenNodePool(pool *NodePool) (map[string]string, error) {
+	fields := make(map[string]string)
+	if pool == nil {
+		return fields, nil
+	}
+
+	raw, err := json.Marshal(pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node pool: %w", err)
+	}
+
+	// Keep numbers as written, float64 would round large integers
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node pool: %w", err)
+	}
+
+	flattenValue("", value, fields)
+
+	return fields, nil
+}
+
+func flattenValue(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, child := range v {
+			if path == "" {
+				flattenValue(key, child, fields)
+			} else {
+				flattenValue(path+"."+key, child, fields)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	default:
+		fields[path] = fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func newDiffTestNodePools() (*NodePool, *NodePool) {
+	createdAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	before := &NodePool{
+		ID:           "poolID",
+		Name:         "pool-b2-7",
+		Flavor:       "b2-7",
+		Status:       "READY",
+		Autoscale:    true,
+		DesiredNodes: 3,
+		MinNodes:     1,
+		MaxNodes:     5,
+		CreatedAt:    createdAt,
+		UpdatedAt:    createdAt,
+	}
+	before.Template.Metadata.Labels = map[string]string{"team": "data", "tier": "backend"}
+	before.Template.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "data", Effect: v1.TaintEffectNoSchedule}}
+
+	after := *before
+	after.Flavor = "b2-15"
+	after.MaxNodes = 10
+	after.Autoscaling = &NodePoolAutoscaling{ScaleDownUtilizationThreshold: 0.5}
+	after.Template.Metadata.Labels = map[string]string{"team": "ml", "gpu": "false"}
+	after.Template.Spec.Taints = nil
+
+	return before, &after
+}
+
+func TestFormatNodePoolDiff(t *testing.T) {
+	before, after := newDiffTestNodePools()
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "nodepool_diff.golden"))
+	assert.NoError(t, err)
+
+	t.Run("check diff matches the golden file", func(t *testing.T) {
+		assert.Equal(t, string(golden), FormatNodePoolDiff(before, after))
+	})
+
+	t.Run("check identical pools have no diff", func(t *testing.T) {
+		assert.Empty(t, FormatNodePoolDiff(before, before))
+	})
+}
+
+func TestFormatNodePoolDiffJSON(t *testing.T) {
+	before, after := newDiffTestNodePools()
+
+	raw, err := FormatNodePoolDiffJSON(before, after)
+	assert.NoError(t, err)
+
+	var changes []NodePoolFieldChange
+	assert.NoError(t, json.Unmarshal(raw, &changes))
+
+	assert.Contains(t, changes, NodePoolFieldChange{Field: "maxNodes", Action: DiffActionChange, Old: "5", New: "10"})
+	assert.Contains(t, changes, NodePoolFieldChange{Field: "template.metadata.labels.gpu", Action: DiffActionAdd, New: "false"})
+	assert.Contains(t, changes, NodePoolFieldChange{Field: "template.spec.taints[0].key", Action: DiffActionRemove, Old: "dedicated"})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// FieldDiff describes a node pool field whose actual value differs from the desired one
+type FieldDiff struct {
+	Field   string `json:"field"`
+	Desired string `json:"desired"`
+	Actual  string `json:"actual"`
+}
+
+// DriftReport lists the differences between the desired and actual configuration of a node pool
+type DriftReport struct {
+	HasDrift bool        `json:"hasDrift"`
+	Diffs    []FieldDiff `json:"diffs"`
+}
+
+// DetectNodePoolConfigDrift fetches a node pool and compares its configurable fields against the desired configuration
+func (c *Client) DetectNodePoolConfigDrift(ctx context.Context, projectID string, clusterID string, poolID string, desired *NodePool) (*DriftReport, error) {
+	actual, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	return DiffNodePoolConfig(desired, actual), nil
+}
+
+// DiffNodePoolConfig compares the configurable fields (min/max size, labels, taints, flavor) of two node pools
+func DiffNodePoolConfig(desired *NodePool, actual *NodePool) *DriftReport {
+	report := &DriftReport{
+		Diffs: make([]FieldDiff, 0),
+	}
+
+	add := func(field string, desired, actual interface{}) {
+		report.Diffs = append(report.Diffs, FieldDiff{
+			Field:   field,
+			Desired: fmt.Sprintf("%v", desired),
+			Actual:  fmt.Sprintf("%v", actual),
+		})
+	}
+
+	if desired.MinNodes != actual.MinNodes {
+		add("minNodes", desired.MinNodes, actual.MinNodes)
+	}
+
+	if desired.MaxNodes != actual.MaxNodes {
+		add("maxNodes", desired.MaxNodes, actual.MaxNodes)
+	}
+
+	if desired.Flavor != actual.Flavor {
+		add("flavor", desired.Flavor, actual.Flavor)
+	}
+
+	desiredLabels, actualLabels := desired.Template.Metadata.Labels, actual.Template.Metadata.Labels
+	if (len(desiredLabels) != 0 || len(actualLabels) != 0) && !reflect.DeepEqual(desiredLabels, actualLabels) {
+		add("labels", desiredLabels, actualLabels)
+	}
+
+	if !equalTaints(desired.Template.Spec.Taints, actual.Template.Spec.Taints) {
+		add("taints", desired.Template.Spec.Taints, actual.Template.Spec.Taints)
+	}
+
+	report.HasDrift = len(report.Diffs) > 0
+
+	return report
+}
+
+// equalTaints checks that two taint lists hold the same taints, regardless of their order
+func equalTaints(a []v1.Taint, b []v1.Taint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, taint := range a {
+		found := false
+		for _, other := range b {
+			if taint.MatchTaint(&other) && taint.Value == other.Value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func newDriftTestNodePool() *NodePool {
+	pool := &NodePool{
+		ID:       "poolID",
+		Flavor:   "b2-7",
+		MinNodes: 1,
+		MaxNodes: 5,
+	}
+	pool.Template.Metadata.Labels = map[string]string{"role": "worker"}
+	pool.Template.Spec.Taints = []v1.Taint{
+		{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectNoSchedule},
+		{Key: "gpu", Effect: v1.TaintEffectNoExecute},
+	}
+
+	return pool
+}
+
+func TestDiffNodePoolConfig(t *testing.T) {
+	t.Run("check no drift is reported for identical pools", func(t *testing.T) {
+		report := DiffNodePoolConfig(newDriftTestNodePool(), newDriftTestNodePool())
+		assert.False(t, report.HasDrift)
+		assert.Empty(t, report.Diffs)
+	})
+
+	t.Run("check taint order and empty labels are not drift", func(t *testing.T) {
+		desired, actual := newDriftTestNodePool(), newDriftTestNodePool()
+		actual.Template.Spec.Taints[0], actual.Template.Spec.Taints[1] = actual.Template.Spec.Taints[1], actual.Template.Spec.Taints[0]
+		desired.Template.Metadata.Labels = nil
+		actual.Template.Metadata.Labels = map[string]string{}
+
+		assert.False(t, DiffNodePoolConfig(desired, actual).HasDrift)
+	})
+
+	tests := map[string]struct {
+		change func(pool *NodePool)
+		field  string
+	}{
+		"min size": {func(pool *NodePool) { pool.MinNodes = 2 }, "minNodes"},
+		"max size": {func(pool *NodePool) { pool.MaxNodes = 10 }, "maxNodes"},
+		"flavor":   {func(pool *NodePool) { pool.Flavor = "b2-15" }, "flavor"},
+		"labels":   {func(pool *NodePool) { pool.Template.Metadata.Labels["role"] = "system" }, "labels"},
+		"taints":   {func(pool *NodePool) { pool.Template.Spec.Taints[0].Value = "web" }, "taints"},
+	}
+
+	for name, test := range tests {
+		t.Run("check drift of "+name, func(t *testing.T) {
+			actual := newDriftTestNodePool()
+			test.change(actual)
+
+			report := DiffNodePoolConfig(newDriftTestNodePool(), actual)
+			assert.True(t, report.HasDrift)
+			assert.Len(t, report.Diffs, 1)
+			assert.Equal(t, test.field, report.Diffs[0].Field)
+		})
+	}
+}
+
+func TestClient_DetectNodePoolConfigDrift(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		pool := newDriftTestNodePool()
+		pool.MaxNodes = 10
+		_ = json.NewEncoder(w).Encode(pool)
+	})
+	client := newTestClient(t, mux)
+
+	report, err := client.DetectNodePoolConfigDrift(context.Background(), "projectID", "clusterID", "poolID", newDriftTestNodePool())
+	assert.NoError(t, err)
+	assert.True(t, report.HasDrift)
+	assert.Equal(t, []FieldDiff{{Field: "maxNodes", Desired: "5", Actual: "10"}}, report.Diffs)
+
+	_, err = client.DetectNodePoolConfigDrift(context.Background(), "projectID", "clusterID", "unknown", newDriftTestNodePool())
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// DryRunUnsupportedWarning is reported when the API does not support dry-run and only client-side checks were performed
+const DryRunUnsupportedWarning = "dry-run is not supported by the API, only client-side checks were performed"
+
+// DryRunResult defines the outcome of a node pool update validated without being executed
+type DryRunResult struct {
+	Valid                    bool     `json:"valid"`
+	Warnings                 []string `json:"warnings"`
+	EstimatedDurationSeconds int      `json:"estimatedDurationSeconds"`
+}
+
+// Validate checks the consistency of the requested node pool sizes
+func (opts *UpdateNodePoolOpts) Validate() error {
+	if opts.MinNodes != nil && opts.MaxNodes != nil && *opts.MinNodes > *opts.MaxNodes {
+		return fmt.Errorf("min nodes %d is above max nodes %d", *opts.MinNodes, *opts.MaxNodes)
+	}
+
+	if opts.DesiredNodes == nil {
+		return nil
+	}
+
+	if opts.MinNodes != nil && *opts.DesiredNodes < *opts.MinNodes {
+		return fmt.Errorf("desired nodes %d is below min nodes %d", *opts.DesiredNodes, *opts.MinNodes)
+	}
+
+	if opts.MaxNodes != nil && *opts.DesiredNodes > *opts.MaxNodes {
+		return fmt.Errorf("desired nodes %d is above max nodes %d", *opts.DesiredNodes, *opts.MaxNodes)
+	}
+
+	return nil
+}
+
+// DryRunUpdateNodePool allows to validate a node pool update without executing it.
+// When the API does not support dry-run, the result only reflects the client-side validation of the options.
+func (c *Client) DryRunUpdateNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *UpdateNodePoolOpts) (*DryRunResult, error) {
+	result := &DryRunResult{}
+
+	err := c.CallAPIWithContext(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s", projectID, clusterID, poolID),
+		opts,
+		result,
+		url.Values{
+			"dryRun": []string{"true"},
+		},
+		nil,
+		true,
+	)
+
+	if IsNotFound(err) {
+		result = &DryRunResult{
+			Valid:    true,
+			Warnings: []string{DryRunUnsupportedWarning},
+		}
+
+		if err := opts.Validate(); err != nil {
+			result.Valid = false
+			result.Warnings = append(result.Warnings, err.Error())
+		}
+
+		return result, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry-run node pool %s update: %w", poolID, err)
+	}
+
+	return result, nil
+}
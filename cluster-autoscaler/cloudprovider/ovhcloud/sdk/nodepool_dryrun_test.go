@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateNodePoolOpts_Validate(t *testing.T) {
+	min, max, below, above := uint32(1), uint32(5), uint32(0), uint32(6)
+
+	assert.NoError(t, (&UpdateNodePoolOpts{DesiredNodes: &max}).Validate())
+	assert.NoError(t, (&UpdateNodePoolOpts{DesiredNodes: &min, MinNodes: &min, MaxNodes: &max}).Validate())
+	assert.Error(t, (&UpdateNodePoolOpts{DesiredNodes: &below, MinNodes: &min, MaxNodes: &max}).Validate())
+	assert.Error(t, (&UpdateNodePoolOpts{DesiredNodes: &above, MinNodes: &min, MaxNodes: &max}).Validate())
+	assert.Error(t, (&UpdateNodePoolOpts{MinNodes: &max, MaxNodes: &min}).Validate())
+}
+
+func TestClient_DryRunUpdateNodePool(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+
+		_ = json.NewEncoder(w).Encode(DryRunResult{Valid: true, Warnings: []string{"flavor is deprecated"}, EstimatedDurationSeconds: 300})
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/legacy", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	min, max, desired := uint32(1), uint32(5), uint32(6)
+
+	t.Run("check API dry-run result is returned", func(t *testing.T) {
+		result, err := client.DryRunUpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{DesiredNodes: &max})
+		assert.NoError(t, err)
+		assert.Equal(t, &DryRunResult{Valid: true, Warnings: []string{"flavor is deprecated"}, EstimatedDurationSeconds: 300}, result)
+	})
+
+	t.Run("check client-side validation when dry-run is not supported", func(t *testing.T) {
+		result, err := client.DryRunUpdateNodePool(ctx, "projectID", "clusterID", "legacy", &UpdateNodePoolOpts{DesiredNodes: &max, MinNodes: &min, MaxNodes: &max})
+		assert.NoError(t, err)
+		assert.Equal(t, &DryRunResult{Valid: true, Warnings: []string{DryRunUnsupportedWarning}}, result)
+
+		result, err = client.DryRunUpdateNodePool(ctx, "projectID", "clusterID", "legacy", &UpdateNodePoolOpts{DesiredNodes: &desired, MinNodes: &min, MaxNodes: &max})
+		assert.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, []string{DryRunUnsupportedWarning, "desired nodes 6 is above max nodes 5"}, result.Warnings)
+	})
+
+	t.Run("check API errors are returned", func(t *testing.T) {
+		_, err := client.DryRunUpdateNodePool(ctx, "projectID", "clusterID", "broken", &UpdateNodePoolOpts{DesiredNodes: &max})
+		assert.Error(t, err)
+	})
+}
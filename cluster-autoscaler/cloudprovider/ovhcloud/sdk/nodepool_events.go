@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ScalingEventType tells whether a scaling event added or removed nodes
+type ScalingEventType string
+
+const (
+	// ScalingEventScaleUp is the type of the events adding nodes to a node pool
+	ScalingEventScaleUp ScalingEventType = "scale-up"
+	// ScalingEventScaleDown is the type of the events removing nodes from a node pool
+	ScalingEventScaleDown ScalingEventType = "scale-down"
+)
+
+// ScalingEvent defines a scale action of a node pool, as accepted and tracked by the API
+type ScalingEvent struct {
+	ID     string           `json:"id"`
+	PoolID string           `json:"nodePoolId"`
+	Type   ScalingEventType `json:"type"`
+	Status string           `json:"status"`
+
+	RequestedSize uint32 `json:"requestedSize"`
+	ActualSize    uint32 `json:"actualSize"`
+	Reason        string `json:"reason"`
+
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// GetScalingEvents allows to display the scale actions of a node pool which occurred since the given time
+func (c *Client) GetScalingEvents(ctx context.Context, projectID string, clusterID string, poolID string, since time.Time) ([]ScalingEvent, error) {
+	events := make([]ScalingEvent, 0)
+
+	return events, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/events", projectID, clusterID, poolID),
+		nil,
+		&events,
+		url.Values{"from": []string{since.UTC().Format(time.RFC3339)}},
+		nil,
+		true,
+	)
+}
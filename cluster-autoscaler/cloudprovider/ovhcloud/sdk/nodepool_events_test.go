@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetScalingEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2023-03-01T08:00:00Z", r.URL.Query().Get("from"))
+
+		_, _ = w.Write([]byte(`[{"id": "event-1", "nodePoolId": "poolID", "type": "scale-up", "status": "DONE", "requestedSize": 3, "actualSize": 3, "reason": "pending pods", "occurredAt": "2023-03-01T09:00:00Z"}]`))
+	})
+	client := newTestClient(t, mux)
+
+	since := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	events, err := client.GetScalingEvents(context.Background(), "projectID", "clusterID", "poolID", since)
+	assert.NoError(t, err)
+	assert.Equal(t, []ScalingEvent{{
+		ID:            "event-1",
+		PoolID:        "poolID",
+		Type:          ScalingEventScaleUp,
+		Status:        "DONE",
+		RequestedSize: 3,
+		ActualSize:    3,
+		Reason:        "pending pods",
+		OccurredAt:    time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC),
+	}}, events)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// AutoscalingEnabledLabel tells whether the autoscaler manages the nodes of the pool
+	AutoscalingEnabledLabel = "cluster-autoscaler.kubernetes.io/autoscaling-enabled"
+
+	// ScaleDownDisabledAnnotation prevents the autoscaler from removing the nodes of the pool
+	ScaleDownDisabledAnnotation = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+
+	// OwnerAnnotation is the name of the annotation holding the email of the node pool owner
+	OwnerAnnotation = "owner"
+)
+
+// InitialiseOpts defines the standard metadata applied to a node pool
+type InitialiseOpts struct {
+	AutoscalerEnabled bool
+	ScaleDownEnabled  bool
+	OwnerEmail        string
+}
+
+// InitialiseNodePool applies the standard labels and annotations expected by the autoscaler to a node pool.
+// It can safely be called several times on the same node pool.
+func (c *Client) InitialiseNodePool(ctx context.Context, projectID string, clusterID string, poolID string, opts *InitialiseOpts) error {
+	annotations := map[string]string{
+		ScaleDownDisabledAnnotation: strconv.FormatBool(!opts.ScaleDownEnabled),
+	}
+	if opts.OwnerEmail != "" {
+		annotations[c.AnnotationKey(OwnerAnnotation)] = opts.OwnerEmail
+	}
+
+	if err := c.UpdateNodePoolAnnotations(ctx, projectID, clusterID, poolID, annotations); err != nil {
+		return fmt.Errorf("failed to initialise node pool annotations: %w", err)
+	}
+
+	labels := map[string]string{
+		AutoscalingEnabledLabel: strconv.FormatBool(opts.AutoscalerEnabled),
+	}
+
+	if err := c.UpdateNodePoolLabels(ctx, projectID, clusterID, poolID, labels); err != nil {
+		return fmt.Errorf("failed to initialise node pool labels: %w", err)
+	}
+
+	return nil
+}
+
+// IsNodePoolInitialised checks whether the standard labels have been applied to a node pool
+func IsNodePoolInitialised(pool *NodePool) bool {
+	_, ok := pool.Template.Metadata.Labels[AutoscalingEnabledLabel]
+
+	return ok
+}
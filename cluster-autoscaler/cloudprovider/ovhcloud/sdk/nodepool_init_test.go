@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_InitialiseNodePool(t *testing.T) {
+	client, pool := newTemplateTestClient(t, nil)
+	assert.False(t, IsNodePoolInitialised(pool))
+
+	opts := &InitialiseOpts{
+		AutoscalerEnabled: true,
+		ScaleDownEnabled:  false,
+		OwnerEmail:        "owner@example.com",
+	}
+
+	err := client.InitialiseNodePool(context.Background(), "projectID", "clusterID", "poolID", opts)
+	assert.NoError(t, err)
+	assert.True(t, IsNodePoolInitialised(pool))
+
+	expectedLabels := map[string]string{
+		"role":                  "worker",
+		AutoscalingEnabledLabel: "true",
+	}
+	expectedAnnotations := map[string]string{
+		"team":                      "data",
+		ScaleDownDisabledAnnotation: "true",
+		"vke.autoscaler/owner":      "owner@example.com",
+	}
+	assert.Equal(t, expectedLabels, pool.Template.Metadata.Labels)
+	assert.Equal(t, expectedAnnotations, pool.Template.Metadata.Annotations)
+
+	// Initialising again leaves the node pool unchanged
+	err = client.InitialiseNodePool(context.Background(), "projectID", "clusterID", "poolID", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedLabels, pool.Template.Metadata.Labels)
+	assert.Equal(t, expectedAnnotations, pool.Template.Metadata.Annotations)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTemplateTestClient serves a single node pool whose template is replaced on every update,
+// along with the extra handlers given by path
+func newTemplateTestClient(t *testing.T, handlers map[string]http.HandlerFunc) (*Client, *NodePool) {
+	pool := &NodePool{ID: "poolID"}
+	pool.Template.Metadata.Labels = map[string]string{"role": "worker"}
+	pool.Template.Metadata.Annotations = map[string]string{"team": "data"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			opts := UpdateNodePoolOpts{}
+			_ = json.NewDecoder(r.Body).Decode(&opts)
+			if opts.Template != nil {
+				pool.Template = *opts.Template
+			}
+		}
+		_ = json.NewEncoder(w).Encode(pool)
+	})
+	for path, handler := range handlers {
+		mux.HandleFunc(path, handler)
+	}
+
+	return newTestClient(t, mux), pool
+}
+
+func TestClient_LockNodePool(t *testing.T) {
+	t.Run("check lock with ttl", func(t *testing.T) {
+		client, pool := newTemplateTestClient(t, nil)
+		clock := NewFakeClock(time.Now())
+		client.clock = clock
+
+		err := client.LockNodePool(context.Background(), "projectID", "clusterID", "poolID", "batch job", time.Hour)
+		assert.NoError(t, err)
+
+		locked, reason, err := IsNodePoolLocked(pool, "")
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.Equal(t, "batch job", reason)
+		assert.Equal(t, strconv.FormatInt(clock.Now().Add(time.Hour).Unix(), 10), pool.Template.Metadata.Annotations["vke.autoscaler/lock-expires"])
+
+		// Other template fields are kept
+		assert.Equal(t, "data", pool.Template.Metadata.Annotations["team"])
+		assert.Equal(t, "worker", pool.Template.Metadata.Labels["role"])
+	})
+
+	t.Run("check lock without ttl", func(t *testing.T) {
+		client, pool := newTemplateTestClient(t, nil)
+
+		err := client.LockNodePool(context.Background(), "projectID", "clusterID", "poolID", "maintenance", 0)
+		assert.NoError(t, err)
+		assert.NotContains(t, pool.Template.Metadata.Annotations, "vke.autoscaler/lock-expires")
+
+		locked, _, err := IsNodePoolLocked(pool, "")
+		assert.NoError(t, err)
+		assert.True(t, locked)
+	})
+
+	t.Run("check negative ttl is rejected", func(t *testing.T) {
+		client, _ := newTemplateTestClient(t, nil)
+
+		err := client.LockNodePool(context.Background(), "projectID", "clusterID", "poolID", "", -time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_UnlockNodePool(t *testing.T) {
+	client, pool := newTemplateTestClient(t, nil)
+
+	err := client.LockNodePool(context.Background(), "projectID", "clusterID", "poolID", "batch job", time.Hour)
+	assert.NoError(t, err)
+
+	err = client.UnlockNodePool(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+
+	locked, _, err := IsNodePoolLocked(pool, "")
+	assert.NoError(t, err)
+	assert.False(t, locked)
+	assert.Equal(t, map[string]string{"team": "data"}, pool.Template.Metadata.Annotations)
+}
+
+func TestIsNodePoolLocked(t *testing.T) {
+	newPool := func(annotations map[string]string) *NodePool {
+		pool := &NodePool{}
+		pool.Template.Metadata.Annotations = annotations
+
+		return pool
+	}
+
+	t.Run("check pool without annotations is not locked", func(t *testing.T) {
+		locked, _, err := IsNodePoolLocked(newPool(nil), "")
+		assert.NoError(t, err)
+		assert.False(t, locked)
+	})
+
+	t.Run("check expired lock is ignored", func(t *testing.T) {
+		locked, _, err := IsNodePoolLocked(newPool(map[string]string{
+			"vke.autoscaler/locked":       "true",
+			"vke.autoscaler/lock-expires": strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+		}), "")
+		assert.NoError(t, err)
+		assert.False(t, locked)
+	})
+
+	t.Run("check malformed expiry is an error", func(t *testing.T) {
+		_, _, err := IsNodePoolLocked(newPool(map[string]string{
+			"vke.autoscaler/locked":       "true",
+			"vke.autoscaler/lock-expires": "tomorrow",
+		}), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("check lock is read with the given prefix", func(t *testing.T) {
+		pool := newPool(map[string]string{
+			"example.com/locked":      "true",
+			"example.com/lock-reason": "maintenance",
+		})
+
+		locked, reason, err := IsNodePoolLocked(pool, "example.com/")
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.Equal(t, "maintenance", reason)
+
+		locked, _, err = IsNodePoolLocked(pool, "")
+		assert.NoError(t, err)
+		assert.False(t, locked)
+	})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// MaintenanceModeAnnotation is the name of the annotation set to "active" while a node pool is in maintenance
+	MaintenanceModeAnnotation = "maintenance-mode"
+
+	// MaintenanceModeActive is the maintenance mode annotation value of a node pool in maintenance
+	MaintenanceModeActive = "active"
+)
+
+// ErrPoolInMaintenanceMode is returned when trying to scale a node pool in maintenance
+var ErrPoolInMaintenanceMode = errors.New("node pool is in maintenance mode")
+
+// MaintenanceModeStatus describes the maintenance mode of a node pool
+type MaintenanceModeStatus struct {
+	Active bool
+
+	// Nodes holds the names of the nodes cordoned, or uncordoned, when changing the maintenance mode
+	Nodes []string
+}
+
+// EnableMaintenanceMode puts a node pool in maintenance: its nodes are cordoned but kept running,
+// and the autoscaler stops scaling it
+func (c *Client) EnableMaintenanceMode(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) (*MaintenanceModeStatus, error) {
+	// Annotate first so the autoscaler does not scale the pool while its nodes are being cordoned
+	err := c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		template.Metadata.Annotations[c.AnnotationKey(MaintenanceModeAnnotation)] = MaintenanceModeActive
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to annotate node pool %s: %w", poolID, err)
+	}
+
+	nodes, err := c.setNodePoolUnschedulable(ctx, projectID, clusterID, poolID, true, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MaintenanceModeStatus{Active: true, Nodes: nodes}, nil
+}
+
+// DisableMaintenanceMode uncordons the nodes of a node pool in maintenance, then lets the autoscaler scale it again
+func (c *Client) DisableMaintenanceMode(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) (*MaintenanceModeStatus, error) {
+	nodes, err := c.setNodePoolUnschedulable(ctx, projectID, clusterID, poolID, false, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		delete(template.Metadata.Annotations, c.AnnotationKey(MaintenanceModeAnnotation))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to annotate node pool %s: %w", poolID, err)
+	}
+
+	return &MaintenanceModeStatus{Active: false, Nodes: nodes}, nil
+}
+
+// GetMaintenanceModeStatus reads the maintenance mode of a node pool from its annotations,
+// using the given label prefix
+func GetMaintenanceModeStatus(pool *NodePool, prefix string) MaintenanceModeStatus {
+	return MaintenanceModeStatus{
+		Active: pool.Template.Metadata.Annotations[AnnotationKey(prefix, MaintenanceModeAnnotation)] == MaintenanceModeActive,
+	}
+}
+
+// setNodePoolUnschedulable cordons or uncordons the nodes of a node pool, and returns their names.
+// Nodes not registered in Kubernetes yet are skipped.
+func (c *Client) setNodePoolUnschedulable(ctx context.Context, projectID string, clusterID string, poolID string, unschedulable bool, k8sClient kubernetes.Interface) ([]string, error) {
+	poolNodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+
+	names := make([]string, 0, len(poolNodes))
+	for _, poolNode := range poolNodes {
+		_, err := k8sClient.CoreV1().Nodes().Patch(ctx, poolNode.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to patch node %s: %w", poolNode.Name, err)
+		}
+
+		names = append(names, poolNode.Name)
+	}
+
+	return names, nil
+}
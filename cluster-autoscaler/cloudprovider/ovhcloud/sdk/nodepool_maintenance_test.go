@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// maintenanceTestHandlers serve the nodes of the node pool of newTemplateTestClient
+var maintenanceTestHandlers = map[string]http.HandlerFunc{
+	"/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes": func(w http.ResponseWriter, r *http.Request) {
+		// node-3 is not registered in Kubernetes yet
+		_ = json.NewEncoder(w).Encode([]Node{{Name: "node-1"}, {Name: "node-2"}, {Name: "node-3"}})
+	},
+}
+
+func TestClient_MaintenanceMode(t *testing.T) {
+	client, pool := newTemplateTestClient(t, maintenanceTestHandlers)
+	ctx := context.Background()
+
+	node1 := newTestK8sNode("node-1", "2")
+	node2 := newTestK8sNode("node-2", "2")
+	other := newTestK8sNode("other", "2")
+	k8sClient := fake.NewSimpleClientset(&node1, &node2, &other)
+
+	isUnschedulable := func(name string) bool {
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		return node.Spec.Unschedulable
+	}
+
+	t.Run("check pool is not in maintenance by default", func(t *testing.T) {
+		assert.False(t, GetMaintenanceModeStatus(pool, "").Active)
+	})
+
+	t.Run("check enabling maintenance cordons the pool nodes", func(t *testing.T) {
+		status, err := client.EnableMaintenanceMode(ctx, "projectID", "clusterID", "poolID", k8sClient)
+		assert.NoError(t, err)
+		assert.Equal(t, &MaintenanceModeStatus{Active: true, Nodes: []string{"node-1", "node-2"}}, status)
+
+		assert.True(t, isUnschedulable("node-1"))
+		assert.True(t, isUnschedulable("node-2"))
+		assert.False(t, isUnschedulable("other"))
+
+		assert.True(t, GetMaintenanceModeStatus(pool, "").Active)
+		assert.Equal(t, "active", pool.Template.Metadata.Annotations["vke.autoscaler/maintenance-mode"])
+		assert.Equal(t, "data", pool.Template.Metadata.Annotations["team"])
+	})
+
+	t.Run("check disabling maintenance uncordons the pool nodes", func(t *testing.T) {
+		status, err := client.DisableMaintenanceMode(ctx, "projectID", "clusterID", "poolID", k8sClient)
+		assert.NoError(t, err)
+		assert.Equal(t, &MaintenanceModeStatus{Active: false, Nodes: []string{"node-1", "node-2"}}, status)
+
+		assert.False(t, isUnschedulable("node-1"))
+		assert.False(t, isUnschedulable("node-2"))
+
+		assert.False(t, GetMaintenanceModeStatus(pool, "").Active)
+		assert.Equal(t, map[string]string{"team": "data"}, pool.Template.Metadata.Annotations)
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// NodePoolNetworkInfo defines the network the nodes of a node pool are attached to
+type NodePoolNetworkInfo struct {
+	NetworkID        string `json:"networkId"`
+	SubnetID         string `json:"subnetId"`
+	AssignFloatingIP bool   `json:"assignFloatingIp"`
+}
+
+// Private tells whether the nodes are attached to a private network
+func (n *NodePoolNetworkInfo) Private() bool {
+	return n.NetworkID != ""
+}
+
+// GetNodePoolNetwork allows to display the network of the nodes of a node pool,
+// e.g. to create a node pool replacing it in the same subnet
+func (c *Client) GetNodePoolNetwork(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePoolNetworkInfo, error) {
+	network := &NodePoolNetworkInfo{}
+
+	return network, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/network", projectID, clusterID, poolID),
+		nil,
+		&network,
+		nil,
+		nil,
+		true,
+	)
+}
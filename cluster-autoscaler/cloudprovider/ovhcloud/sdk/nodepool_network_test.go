@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_NodePoolNetwork(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		body = map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id": "replacement"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/network", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"networkId": "net-1", "subnetId": "subnet-1", "assignFloatingIp": true}`))
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check network of a node pool is read", func(t *testing.T) {
+		network, err := client.GetNodePoolNetwork(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, &NodePoolNetworkInfo{NetworkID: "net-1", SubnetID: "subnet-1", AssignFloatingIP: true}, network)
+		assert.True(t, network.Private())
+	})
+
+	t.Run("check node pool is created in the same network", func(t *testing.T) {
+		network, err := client.GetNodePoolNetwork(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		_, err = client.CreateNodePool(ctx, "projectID", "clusterID", &CreateNodePoolOpts{
+			FlavorName:       "b2-7",
+			NetworkID:        network.NetworkID,
+			SubnetID:         network.SubnetID,
+			AssignFloatingIP: network.AssignFloatingIP,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "net-1", body["networkId"])
+		assert.Equal(t, "subnet-1", body["subnetId"])
+		assert.Equal(t, true, body["assignFloatingIp"])
+	})
+
+	t.Run("check public node pools have no network fields", func(t *testing.T) {
+		_, err := client.CreateNodePool(ctx, "projectID", "clusterID", &CreateNodePoolOpts{FlavorName: "b2-7"})
+		assert.NoError(t, err)
+		assert.NotContains(t, body, "networkId")
+		assert.NotContains(t, body, "subnetId")
+		assert.NotContains(t, body, "assignFloatingIp")
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// ProvisionTimeAnnotation is the name of the annotation holding the maximum time, in seconds,
+// given to the nodes of a pool to be provisioned
+const ProvisionTimeAnnotation = "max-provision-time-seconds"
+
+// ParseProvisionTimeAnnotation reads the provision time of a node pool from its annotations, using the given
+// label prefix. The fallback is returned when the annotation is absent, not a positive integer or too large.
+func ParseProvisionTimeAnnotation(pool *NodePool, prefix string, fallback time.Duration) time.Duration {
+	value, ok := pool.Template.Metadata.Annotations[AnnotationKey(prefix, ProvisionTimeAnnotation)]
+	if !ok {
+		return fallback
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return fallback
+	}
+
+	// Converting to a duration must not overflow
+	if seconds > math.MaxInt64/int64(time.Second) {
+		return fallback
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProvisionTimeAnnotation(t *testing.T) {
+	fallback := 15 * time.Minute
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		prefix      string
+		expected    time.Duration
+	}{
+		{name: "absent", expected: fallback},
+		{name: "seconds", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "1800"}, expected: 30 * time.Minute},
+		{name: "custom prefix", annotations: map[string]string{"example.com/max-provision-time-seconds": "60"}, prefix: "example.com/", expected: time.Minute},
+		{name: "other prefix", annotations: map[string]string{"example.com/max-provision-time-seconds": "60"}, expected: fallback},
+		{name: "malformed", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "30m"}, expected: fallback},
+		{name: "zero", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "0"}, expected: fallback},
+		{name: "negative", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "-60"}, expected: fallback},
+		{name: "duration overflow", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "9223372036854775807"}, expected: fallback},
+		{name: "integer overflow", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "92233720368547758070"}, expected: fallback},
+		{name: "largest duration", annotations: map[string]string{"vke.autoscaler/max-provision-time-seconds": "9223372036"}, expected: 9223372036 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &NodePool{}
+			pool.Template.Metadata.Annotations = test.annotations
+
+			assert.Equal(t, test.expected, ParseProvisionTimeAnnotation(pool, test.prefix, fallback))
+		})
+	}
+}
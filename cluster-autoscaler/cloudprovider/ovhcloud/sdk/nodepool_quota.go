@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaExceeded is returned when a scale-up would exceed the node pool quota
+var ErrQuotaExceeded = errors.New("node pool quota exceeded")
+
+// NodePoolQuota defines the node count limit of a node pool, independent of the project quota
+type NodePoolQuota struct {
+	MaxNodes     int `json:"maxNodes"`
+	CurrentNodes int `json:"currentNodes"`
+	Remaining    int `json:"remaining"`
+}
+
+// GetNodePoolQuota allows to display the node count limit of a specific node pool
+func (c *Client) GetNodePoolQuota(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePoolQuota, error) {
+	quota := &NodePoolQuota{}
+
+	return quota, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/quota", projectID, clusterID, poolID),
+		nil,
+		&quota,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// CheckNodePoolQuota returns ErrQuotaExceeded if adding requested nodes to the node pool exceeds its quota.
+// Node pools without a dedicated quota are not limited.
+func (c *Client) CheckNodePoolQuota(ctx context.Context, projectID string, clusterID string, poolID string, requested int) error {
+	quota, err := c.GetNodePoolQuota(ctx, projectID, clusterID, poolID)
+
+	if IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s quota: %w", poolID, err)
+	}
+
+	if quota.CurrentNodes+requested > quota.MaxNodes {
+		return fmt.Errorf("%w: requested %d node(s) with %d/%d used", ErrQuotaExceeded, requested, quota.CurrentNodes, quota.MaxNodes)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CheckNodePoolQuota(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/quota", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(NodePoolQuota{MaxNodes: 10, CurrentNodes: 7, Remaining: 3})
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/broken/quota", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	t.Run("check quota is returned", func(t *testing.T) {
+		quota, err := client.GetNodePoolQuota(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, &NodePoolQuota{MaxNodes: 10, CurrentNodes: 7, Remaining: 3}, quota)
+	})
+
+	t.Run("check request within quota passes", func(t *testing.T) {
+		assert.NoError(t, client.CheckNodePoolQuota(ctx, "projectID", "clusterID", "poolID", 3))
+	})
+
+	t.Run("check request above quota fails", func(t *testing.T) {
+		err := client.CheckNodePoolQuota(ctx, "projectID", "clusterID", "poolID", 4)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	t.Run("check node pool without quota is not limited", func(t *testing.T) {
+		assert.NoError(t, client.CheckNodePoolQuota(ctx, "projectID", "clusterID", "unlimited", 100))
+	})
+
+	t.Run("check API errors are returned", func(t *testing.T) {
+		err := client.CheckNodePoolQuota(ctx, "projectID", "clusterID", "broken", 1)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrQuotaExceeded)
+	})
+}
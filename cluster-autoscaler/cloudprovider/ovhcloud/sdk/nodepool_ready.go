@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// ReadyNodesPollInterval is the interval between two checks of the readiness of the nodes of a pool
+var ReadyNodesPollInterval = 10 * time.Second
+
+// DefaultNodePoolStablePollInterval is the default interval between two checks of the status of a node pool
+const DefaultNodePoolStablePollInterval = 10 * time.Second
+
+// NodePoolStableStatus is the status of a node pool once its changes are applied
+var NodePoolStableStatus = "READY"
+
+// NodePoolErrorStatus is the status of a node pool whose changes failed
+var NodePoolErrorStatus = "ERROR"
+
+// ErrNodePoolInError is returned when waiting for a node pool which ends in NodePoolErrorStatus
+var ErrNodePoolInError = errors.New("node pool is in error")
+
+// WaitForNodePoolStable waits until the status of a node pool is NodePoolStableStatus, and returns it.
+// The changes of the node pool are watched when the API streams them, otherwise it is polled every pollInterval.
+// It fails at once when the status is NodePoolErrorStatus, and gives up when the context is done, returning the context error.
+func (c *Client) WaitForNodePoolStable(ctx context.Context, projectID string, clusterID string, poolID string, pollInterval time.Duration) (*NodePool, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultNodePoolStablePollInterval
+	}
+
+	pool, err := c.watchNodePoolStable(ctx, projectID, clusterID, poolID)
+	if errors.Is(err, errWatchUnsupported) {
+		pool, err = c.pollNodePoolStable(ctx, projectID, clusterID, poolID, pollInterval)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for node pool %s to be stable: %w", poolID, err)
+	}
+
+	return pool, nil
+}
+
+// watchNodePoolStable reads the node pools streamed by the API until one is stable or in error
+func (c *Client) watchNodePoolStable(ctx context.Context, projectID string, clusterID string, poolID string) (*NodePool, error) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var pool *NodePool
+	var poolErr error
+	err := c.streamNodePool(watchCtx, projectID, clusterID, poolID, func(update NodePool) {
+		switch update.Status {
+		case NodePoolErrorStatus:
+			poolErr = ErrNodePoolInError
+		case NodePoolStableStatus:
+			pool = &update
+		default:
+			return
+		}
+		cancel()
+	})
+
+	switch {
+	case poolErr != nil:
+		return nil, poolErr
+	case pool != nil:
+		return pool, nil
+	default:
+		return nil, err
+	}
+}
+
+// pollNodePoolStable reads the node pool every pollInterval until it is stable or in error
+func (c *Client) pollNodePoolStable(ctx context.Context, projectID string, clusterID string, poolID string, pollInterval time.Duration) (*NodePool, error) {
+	var pool *NodePool
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		pool, err = c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+		if err != nil {
+			return false, err
+		}
+
+		if pool.Status == NodePoolErrorStatus {
+			return false, ErrNodePoolInError
+		}
+
+		return pool.Status == NodePoolStableStatus, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// WaitForReadyNodes waits until at least count nodes of a node pool are registered and Ready in Kubernetes.
+// The registered nodes are annotated with their node pool, see AnnotateNodeWithPoolID.
+// It gives up when the context is done, returning the context error.
+func (c *Client) WaitForReadyNodes(ctx context.Context, projectID string, clusterID string, poolID string, count uint32, k8sClient kubernetes.Interface) error {
+	err := wait.PollUntilContextCancel(ctx, ReadyNodesPollInterval, true, func(ctx context.Context) (bool, error) {
+		ready, err := c.countReadyNodes(ctx, projectID, clusterID, poolID, k8sClient)
+		if err != nil {
+			return false, err
+		}
+
+		return ready >= count, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for %d ready nodes in node pool %s: %w", count, poolID, err)
+	}
+
+	return nil
+}
+
+// countReadyNodes returns the number of nodes of a node pool which are Ready in Kubernetes
+func (c *Client) countReadyNodes(ctx context.Context, projectID string, clusterID string, poolID string, k8sClient kubernetes.Interface) (uint32, error) {
+	poolNodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	var ready uint32
+	for _, poolNode := range poolNodes {
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, poolNode.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get node %s: %w", poolNode.Name, err)
+		}
+
+		// Failing to annotate only makes the node group of the node slower to find
+		if node.Annotations[NodeGroupIDAnnotation] != poolID {
+			if err := AnnotateNodeWithPoolID(ctx, k8sClient, node.Name, clusterID, poolID); err != nil {
+				klog.Warningf("Failed to annotate node %s with its node pool: %v", node.Name, err)
+			}
+		}
+
+		if isNodeReady(node) {
+			ready++
+		}
+	}
+
+	return ready, nil
+}
+
+func isNodeReady(node *apiv1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == apiv1.NodeReady {
+			return condition.Status == apiv1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newReadyTestK8sNode(name string, ready bool) *v1.Node {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	node := newTestK8sNode(name, "2")
+	node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: status}}
+
+	return &node
+}
+
+func TestClient_WaitForReadyNodes(t *testing.T) {
+	interval := ReadyNodesPollInterval
+	ReadyNodesPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { ReadyNodesPollInterval = interval })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "pool"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+		// node-4 is not registered in Kubernetes yet
+		_ = json.NewEncoder(w).Encode([]Node{{Name: "node-1"}, {Name: "node-2"}, {Name: "node-3"}, {Name: "node-4"}})
+	})
+	client := newTestClient(t, mux)
+
+	k8sClient := fake.NewSimpleClientset(
+		newReadyTestK8sNode("node-1", true),
+		newReadyTestK8sNode("node-2", true),
+		newReadyTestK8sNode("node-3", false),
+	)
+
+	t.Run("check wait returns once enough nodes are ready", func(t *testing.T) {
+		err := client.WaitForReadyNodes(context.Background(), "projectID", "clusterID", "poolID", 2, k8sClient)
+		assert.NoError(t, err)
+	})
+
+	t.Run("check registered nodes are annotated with their node pool", func(t *testing.T) {
+		for _, name := range []string{"node-1", "node-2", "node-3"} {
+			node, err := k8sClient.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, "poolID", node.Annotations[NodeGroupIDAnnotation])
+			assert.Equal(t, "clusterID", node.Annotations[ClusterIDAnnotation])
+		}
+	})
+
+	t.Run("check wait times out when nodes are not ready", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.WaitForReadyNodes(ctx, "projectID", "clusterID", "poolID", 3, k8sClient)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+// newStatusSequenceClient serves the node pool with the given statuses in order, the last one being repeated
+func newStatusSequenceClient(t *testing.T, statuses ...string) (*Client, *int) {
+	calls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+
+		assert.NoError(t, json.NewEncoder(w).Encode(NodePool{ID: "poolID", Status: status}))
+	})
+
+	return newTestClient(t, mux), &calls
+}
+
+func TestClient_WaitForNodePoolStable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check the node pool is returned once stable", func(t *testing.T) {
+		client, calls := newStatusSequenceClient(t, "RESIZING", "UPSCALING", "READY")
+
+		pool, err := client.WaitForNodePoolStable(ctx, "projectID", "clusterID", "poolID", time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, "READY", pool.Status)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("check errors are returned at once", func(t *testing.T) {
+		client, calls := newStatusSequenceClient(t, "RESIZING", "ERROR", "READY")
+
+		_, err := client.WaitForNodePoolStable(ctx, "projectID", "clusterID", "poolID", time.Millisecond)
+		assert.ErrorIs(t, err, ErrNodePoolInError)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("check context timeout is returned", func(t *testing.T) {
+		client, _ := newStatusSequenceClient(t, "RESIZING")
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := client.WaitForNodePoolStable(ctx, "projectID", "clusterID", "poolID", time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("check the stable status can be changed", func(t *testing.T) {
+		status := NodePoolStableStatus
+		NodePoolStableStatus = "ACTIVE"
+		t.Cleanup(func() { NodePoolStableStatus = status })
+
+		client, _ := newStatusSequenceClient(t, "READY", "ACTIVE")
+
+		pool, err := client.WaitForNodePoolStable(ctx, "projectID", "clusterID", "poolID", time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, "ACTIVE", pool.Status)
+	})
+}
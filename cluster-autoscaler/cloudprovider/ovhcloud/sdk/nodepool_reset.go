@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResetPlan describes the changes applied by a node pool reset
+type ResetPlan struct {
+	// NodesToDelete holds the IDs of the nodes which will be deleted
+	NodesToDelete []string `json:"nodesToDelete"`
+
+	// TargetCount is the desired nodes number the pool is set back to
+	TargetCount int `json:"targetCount"`
+}
+
+// DryRunResetNodePool returns the plan that ResetNodePool would apply, without making any change
+func (c *Client) DryRunResetNodePool(ctx context.Context, projectID string, clusterID string, poolID string) (*ResetPlan, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	nodes, err := c.ListNodePoolNodes(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of node pool %s: %w", poolID, err)
+	}
+
+	plan := &ResetPlan{
+		NodesToDelete: make([]string, 0),
+		TargetCount:   int(pool.DesiredNodes),
+	}
+
+	for _, node := range nodes {
+		// Nodes already being deleted do not need another deletion
+		if node.Status == "DELETING" {
+			continue
+		}
+
+		plan.NodesToDelete = append(plan.NodesToDelete, node.ID)
+	}
+
+	return plan, nil
+}
+
+// ResetNodePool deletes all the nodes of a node pool and sets the pool back to its
+// original desired nodes number, so they are all recreated. This is meant to recover
+// pools where every node ended up in an error state.
+//
+// The operation is idempotent: nodes that are already absent are skipped.
+func (c *Client) ResetNodePool(ctx context.Context, projectID string, clusterID string, poolID string) error {
+	plan, err := c.DryRunResetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return err
+	}
+
+	for _, nodeID := range plan.NodesToDelete {
+		err = c.DeleteNode(ctx, projectID, clusterID, nodeID)
+
+		// A node which does not exist anymore has already been deleted
+		if IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to delete node %s: %w", nodeID, err)
+		}
+	}
+
+	desired := uint32(plan.TargetCount)
+	_, err = c.UpdateNodePool(ctx, projectID, clusterID, poolID, &UpdateNodePoolOpts{
+		DesiredNodes: &desired,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore node pool %s desired size: %w", poolID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResetAPI serves a node pool whose nodes are all in error
+type fakeResetAPI struct {
+	mutex   sync.Mutex
+	nodes   []Node
+	deleted []string
+	desired []uint32
+}
+
+func (api *fakeResetAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	switch {
+	case r.URL.Path == "/cloud/project/projectID/kube/clusterID/nodepool/poolID" && r.Method == "GET":
+		_ = json.NewEncoder(w).Encode(NodePool{ID: "poolID", DesiredNodes: 3})
+	case r.URL.Path == "/cloud/project/projectID/kube/clusterID/nodepool/poolID" && r.Method == "PUT":
+		opts := UpdateNodePoolOpts{}
+		_ = json.NewDecoder(r.Body).Decode(&opts)
+		api.desired = append(api.desired, *opts.DesiredNodes)
+		_ = json.NewEncoder(w).Encode(NodePool{ID: "poolID", DesiredNodes: *opts.DesiredNodes})
+	case r.URL.Path == "/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes":
+		_ = json.NewEncoder(w).Encode(api.nodes)
+	case strings.HasPrefix(r.URL.Path, "/cloud/project/projectID/kube/clusterID/node/") && r.Method == "DELETE":
+		nodeID := strings.TrimPrefix(r.URL.Path, "/cloud/project/projectID/kube/clusterID/node/")
+		for i, node := range api.nodes {
+			if node.ID == nodeID {
+				api.nodes = append(api.nodes[:i], api.nodes[i+1:]...)
+				api.deleted = append(api.deleted, nodeID)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "node not found"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newFakeResetAPI() *fakeResetAPI {
+	return &fakeResetAPI{
+		nodes: []Node{
+			{ID: "node-1", Status: "ERROR"},
+			{ID: "node-2", Status: "ERROR"},
+			{ID: "node-3", Status: "DELETING"},
+		},
+	}
+}
+
+func TestClient_DryRunResetNodePool(t *testing.T) {
+	api := newFakeResetAPI()
+	client := newTestClient(t, api)
+
+	plan, err := client.DryRunResetNodePool(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Equal(t, &ResetPlan{NodesToDelete: []string{"node-1", "node-2"}, TargetCount: 3}, plan)
+
+	// Nothing has been changed
+	assert.Empty(t, api.deleted)
+	assert.Empty(t, api.desired)
+	assert.Len(t, api.nodes, 3)
+}
+
+func TestClient_ResetNodePool(t *testing.T) {
+	t.Run("check reset applies the dry-run plan", func(t *testing.T) {
+		api := newFakeResetAPI()
+		client := newTestClient(t, api)
+
+		plan, err := client.DryRunResetNodePool(context.Background(), "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		err = client.ResetNodePool(context.Background(), "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		assert.Equal(t, plan.NodesToDelete, api.deleted)
+		assert.Equal(t, []uint32{uint32(plan.TargetCount)}, api.desired)
+	})
+
+	t.Run("check reset is idempotent", func(t *testing.T) {
+		api := newFakeResetAPI()
+		client := newTestClient(t, api)
+
+		assert.NoError(t, client.ResetNodePool(context.Background(), "projectID", "clusterID", "poolID"))
+		assert.NoError(t, client.ResetNodePool(context.Background(), "projectID", "clusterID", "poolID"))
+
+		assert.Equal(t, []string{"node-1", "node-2"}, api.deleted)
+		assert.Equal(t, []uint32{3, 3}, api.desired)
+	})
+}
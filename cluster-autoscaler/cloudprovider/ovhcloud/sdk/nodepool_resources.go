@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// DefaultMaxPods is the number of pods a node can run when its node pool does not set it
+	DefaultMaxPods = 110
+
+	// MaxPodsAnnotation is the name of the annotation holding the kubelet max pods of the nodes of a pool
+	MaxPodsAnnotation = "max-pods"
+
+	// evictionThresholdMemoryMB is the memory kept free by the kubelet hard eviction threshold
+	evictionThresholdMemoryMB = 100
+)
+
+// NodeGroupResourceInfo describes the resources a node of a node pool offers to the pods,
+// so that nodes can be simulated without a live node of the pool in the cluster
+type NodeGroupResourceInfo struct {
+	// AllocatableCPU is the CPU allocatable to the pods, in millicores
+	AllocatableCPU                int64
+	AllocatableMemoryMB           int64
+	AllocatableEphemeralStorageGB int64
+	GPUCount                      int64
+	MaxPods                       int64
+
+	// SystemReservedCPUMillis and SystemReservedMemoryMB are reserved for the system and Kubernetes daemons
+	SystemReservedCPUMillis int64
+	SystemReservedMemoryMB  int64
+}
+
+// NodeGroupInfo returns the resources of a node of a node pool, computed from its flavor.
+// The max pods of the nodes is read from the pool annotations, DefaultMaxPods when not set.
+func (c *Client) NodeGroupInfo(ctx context.Context, projectID string, clusterID string, poolID string) (*NodeGroupResourceInfo, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	flavor, err := c.GetFlavorInfo(ctx, projectID, clusterID, pool.Flavor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flavor of node pool %s: %w", poolID, err)
+	}
+
+	info := NewNodeGroupResourceInfo(*flavor)
+	info.MaxPods = ParseMaxPodsAnnotation(pool, c.LabelPrefix)
+
+	return info, nil
+}
+
+// NewNodeGroupResourceInfo computes the resources of a node of the given flavor, reserving a share of its CPU
+// and memory for the system, decreasing by tiers as the node grows, and DefaultMaxPods pods
+func NewNodeGroupResourceInfo(flavor Flavor) *NodeGroupResourceInfo {
+	cpuMillis := int64(flavor.VCPUs) * 1000
+	memoryMB := int64(flavor.RAM) * 1024
+
+	reservedCPU := reservedCPUMillis(cpuMillis)
+	reservedMemory := reservedMemoryMB(memoryMB)
+
+	return &NodeGroupResourceInfo{
+		AllocatableCPU:                max(cpuMillis-reservedCPU, 0),
+		AllocatableMemoryMB:           max(memoryMB-reservedMemory-evictionThresholdMemoryMB, 0),
+		AllocatableEphemeralStorageGB: int64(flavor.Disk),
+		GPUCount:                      int64(flavor.GPUs),
+		MaxPods:                       DefaultMaxPods,
+		SystemReservedCPUMillis:       reservedCPU,
+		SystemReservedMemoryMB:        reservedMemory,
+	}
+}
+
+// ParseMaxPodsAnnotation reads the max pods of the nodes of a pool from its annotations, using the given
+// label prefix. DefaultMaxPods is returned when the annotation is absent or not a positive integer.
+func ParseMaxPodsAnnotation(pool *NodePool, prefix string) int64 {
+	maxPods, err := strconv.ParseInt(pool.Template.Metadata.Annotations[AnnotationKey(prefix, MaxPodsAnnotation)], 10, 64)
+	if err != nil || maxPods <= 0 {
+		return DefaultMaxPods
+	}
+
+	return maxPods
+}
+
+// reservationTier is the share of the resources reserved up to a given amount
+type reservationTier struct {
+	upTo  int64
+	share float64
+}
+
+// reservedCPUMillis reserves 6% of the first core, 1% of the second, 0.5% of the next two and 0.25% of the others
+func reservedCPUMillis(cpuMillis int64) int64 {
+	return reserved(cpuMillis, []reservationTier{
+		{upTo: 1000, share: 0.06},
+		{upTo: 2000, share: 0.01},
+		{upTo: 4000, share: 0.005},
+		{upTo: -1, share: 0.0025},
+	})
+}
+
+// reservedMemoryMB reserves 25% of the first 4GB, 20% of the next 4GB, 10% of the next 8GB,
+// 6% of the next 112GB and 2% of the others
+func reservedMemoryMB(memoryMB int64) int64 {
+	return reserved(memoryMB, []reservationTier{
+		{upTo: 4 * 1024, share: 0.25},
+		{upTo: 8 * 1024, share: 0.2},
+		{upTo: 16 * 1024, share: 0.1},
+		{upTo: 128 * 1024, share: 0.06},
+		{upTo: -1, share: 0.02},
+	})
+}
+
+// reserved sums the share of every tier of the amount, the last tier having no upper bound
+func reserved(amount int64, tiers []reservationTier) int64 {
+	total := 0.0
+	lower := int64(0)
+
+	for _, tier := range tiers {
+		upper := tier.upTo
+		if upper < 0 || upper > amount {
+			upper = amount
+		}
+		if upper <= lower {
+			break
+		}
+
+		total += float64(upper-lower) * tier.share
+		lower = upper
+	}
+
+	return int64(total)
+}
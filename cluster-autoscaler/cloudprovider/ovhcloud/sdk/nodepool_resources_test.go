@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNodeGroupResourceInfo(t *testing.T) {
+	t.Run("check resources are reserved by tiers", func(t *testing.T) {
+		info := NewNodeGroupResourceInfo(Flavor{Name: "b2-7", VCPUs: 2, RAM: 7, Disk: 50})
+
+		assert.Equal(t, &NodeGroupResourceInfo{
+			AllocatableCPU:                1930,
+			AllocatableMemoryMB:           5430,
+			AllocatableEphemeralStorageGB: 50,
+			MaxPods:                       DefaultMaxPods,
+			SystemReservedCPUMillis:       70,
+			SystemReservedMemoryMB:        1638,
+		}, info)
+	})
+
+	t.Run("check large flavors reserve every tier", func(t *testing.T) {
+		info := NewNodeGroupResourceInfo(Flavor{Name: "t1-180", VCPUs: 36, RAM: 180, GPUs: 4})
+
+		assert.Equal(t, int64(60+10+10+80), info.SystemReservedCPUMillis)
+		assert.Equal(t, int64(10608), info.SystemReservedMemoryMB)
+		assert.Equal(t, int64(4), info.GPUCount)
+	})
+
+	t.Run("check resources are never negative", func(t *testing.T) {
+		info := NewNodeGroupResourceInfo(Flavor{})
+
+		assert.Equal(t, int64(0), info.AllocatableCPU)
+		assert.Equal(t, int64(0), info.AllocatableMemoryMB)
+	})
+}
+
+func TestClient_NodeGroupInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "flavor": "b2-7", "template": {"metadata": {"annotations": {"vke.autoscaler/max-pods": "50"}}}}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/flavors", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "b2-7", "vCPUs": 2, "ram": 7, "disk": 50}]`))
+	})
+	client := newTestClient(t, mux)
+
+	info, err := client.NodeGroupInfo(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1930), info.AllocatableCPU)
+	assert.Equal(t, int64(50), info.MaxPods)
+}
+
+func TestParseMaxPodsAnnotation(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int64
+	}{
+		"missing":  {"", DefaultMaxPods},
+		"valid":    {"64", 64},
+		"invalid":  {"many", DefaultMaxPods},
+		"negative": {"-1", DefaultMaxPods},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool := &NodePool{}
+			pool.Template.Metadata.Annotations = map[string]string{}
+			if test.value != "" {
+				pool.Template.Metadata.Annotations["custom/max-pods"] = test.value
+			}
+
+			assert.Equal(t, test.expected, ParseMaxPodsAnnotation(pool, "custom/"))
+		})
+	}
+}
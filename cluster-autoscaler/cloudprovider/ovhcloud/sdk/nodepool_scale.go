@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultQuotaRetryAttempts is the number of sizes attempted by ScaleUpWithQuotaRetry when none is given
+const DefaultQuotaRetryAttempts = 3
+
+// ErrSizeOutOfRange is returned when scaling a node pool beyond its minimum or maximum size
+var ErrSizeOutOfRange = errors.New("size is out of the node pool range")
+
+// ScaleNodePoolToSize sets the desired nodes of a node pool, once checked that size is within its minimum and maximum sizes
+func (c *Client) ScaleNodePoolToSize(ctx context.Context, projectID string, clusterID string, poolID string, size uint32) (*NodePool, error) {
+	pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	if size < pool.MinNodes || size > pool.MaxNodes {
+		return nil, fmt.Errorf("%w: %d is not within [%d, %d]", ErrSizeOutOfRange, size, pool.MinNodes, pool.MaxNodes)
+	}
+
+	return c.UpdateNodePool(ctx, projectID, clusterID, poolID, &UpdateNodePoolOpts{
+		DesiredNodes: &size,
+	})
+}
+
+// ScaleRetryOpts defines how ScaleUpWithQuotaRetry reduces a scale up exceeding the quota
+type ScaleRetryOpts struct {
+	// Step is the number of nodes removed from the target size on every attempt, 1 when zero
+	Step uint32
+	// MaxAttempts is the number of sizes attempted, DefaultQuotaRetryAttempts when zero
+	MaxAttempts int
+}
+
+// ScaleUpWithQuotaRetry sets the desired nodes of a node pool to targetSize. When the quota is exceeded,
+// the update is attempted again with Step nodes less, down to one more node than the current size,
+// so that a partial scale up adds as many nodes as the quota allows.
+func (c *Client) ScaleUpWithQuotaRetry(ctx context.Context, projectID string, clusterID string, poolID string, targetSize uint32, opts ScaleRetryOpts) (*NodePool, error) {
+	if opts.Step == 0 {
+		opts.Step = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultQuotaRetryAttempts
+	}
+
+	pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	minSize := pool.DesiredNodes + 1
+	if targetSize < minSize {
+		return nil, fmt.Errorf("%w: %d is not above the current size %d", ErrSizeOutOfRange, targetSize, pool.DesiredNodes)
+	}
+
+	size := targetSize
+	for attempt := 1; ; attempt++ {
+		klog.V(2).Infof("Scaling up node pool %s to %d nodes (attempt %d/%d)", poolID, size, attempt, opts.MaxAttempts)
+
+		pool, err = c.UpdateNodePool(ctx, projectID, clusterID, poolID, &UpdateNodePoolOpts{
+			DesiredNodes: &size,
+		})
+		if err == nil || !IsQuotaExceeded(err) {
+			return pool, err
+		}
+
+		if attempt >= opts.MaxAttempts || size == minSize {
+			return nil, fmt.Errorf("failed to scale up node pool %s within quota after %d attempt(s): %w", poolID, attempt, err)
+		}
+
+		klog.Warningf("Scaling up node pool %s to %d nodes exceeds the quota: %v", poolID, size, err)
+
+		if size-minSize > opts.Step {
+			size -= opts.Step
+		} else {
+			size = minSize
+		}
+	}
+}
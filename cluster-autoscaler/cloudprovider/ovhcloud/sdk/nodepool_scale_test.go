@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ScaleNodePoolToSize(t *testing.T) {
+	ctx := context.Background()
+	updates := make([]string, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Len(t, body, 1)
+
+			updates = append(updates, r.Method)
+			_, _ = w.Write([]byte(`{"id": "poolID", "desiredNodes": 4, "minNodes": 1, "maxNodes": 5}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id": "poolID", "desiredNodes": 2, "minNodes": 1, "maxNodes": 5}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	t.Run("check the desired nodes are set", func(t *testing.T) {
+		pool, err := client.ScaleNodePoolToSize(ctx, "projectID", "clusterID", "poolID", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(4), pool.DesiredNodes)
+		assert.Len(t, updates, 1)
+	})
+
+	t.Run("check sizes out of range are rejected", func(t *testing.T) {
+		_, err := client.ScaleNodePoolToSize(ctx, "projectID", "clusterID", "poolID", 6)
+		assert.ErrorIs(t, err, ErrSizeOutOfRange)
+
+		_, err = client.ScaleNodePoolToSize(ctx, "projectID", "clusterID", "poolID", 0)
+		assert.ErrorIs(t, err, ErrSizeOutOfRange)
+		assert.Len(t, updates, 1)
+	})
+}
+
+func TestClient_ScaleUpWithQuotaRetry(t *testing.T) {
+	ctx := context.Background()
+
+	// newQuotaClient serves a node pool of 2 nodes which cannot grow beyond quota nodes
+	newQuotaClient := func(t *testing.T, quota uint32) (*Client, *[]uint32) {
+		attempts := make([]uint32, 0)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PUT" {
+				opts := UpdateNodePoolOpts{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+				attempts = append(attempts, *opts.DesiredNodes)
+
+				if *opts.DesiredNodes > quota {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message": "quota exceeded", "errorCode": "QUOTA_EXCEEDED"}`))
+					return
+				}
+
+				_, _ = fmt.Fprintf(w, `{"id": "poolID", "desiredNodes": %d}`, *opts.DesiredNodes)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"id": "poolID", "desiredNodes": 2, "minNodes": 1, "maxNodes": 20}`))
+		})
+
+		return newTestClient(t, mux), &attempts
+	}
+
+	t.Run("check full scale up is made within quota", func(t *testing.T) {
+		client, attempts := newQuotaClient(t, 10)
+
+		pool, err := client.ScaleUpWithQuotaRetry(ctx, "projectID", "clusterID", "poolID", 8, ScaleRetryOpts{})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(8), pool.DesiredNodes)
+		assert.Equal(t, []uint32{8}, *attempts)
+	})
+
+	t.Run("check scale up is reduced by step until within quota", func(t *testing.T) {
+		client, attempts := newQuotaClient(t, 5)
+
+		pool, err := client.ScaleUpWithQuotaRetry(ctx, "projectID", "clusterID", "poolID", 9, ScaleRetryOpts{Step: 2, MaxAttempts: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(5), pool.DesiredNodes)
+		assert.Equal(t, []uint32{9, 7, 5}, *attempts)
+	})
+
+	t.Run("check scale up never goes below one more node", func(t *testing.T) {
+		client, attempts := newQuotaClient(t, 2)
+
+		_, err := client.ScaleUpWithQuotaRetry(ctx, "projectID", "clusterID", "poolID", 6, ScaleRetryOpts{Step: 3, MaxAttempts: 5})
+		assert.True(t, IsQuotaExceeded(err))
+		assert.Equal(t, []uint32{6, 3}, *attempts)
+	})
+
+	t.Run("check attempts are limited", func(t *testing.T) {
+		client, attempts := newQuotaClient(t, 3)
+
+		_, err := client.ScaleUpWithQuotaRetry(ctx, "projectID", "clusterID", "poolID", 10, ScaleRetryOpts{})
+		assert.True(t, IsQuotaExceeded(err))
+		assert.Equal(t, []uint32{10, 9, 8}, *attempts)
+	})
+
+	t.Run("check sizes which are not a scale up are rejected", func(t *testing.T) {
+		client, attempts := newQuotaClient(t, 10)
+
+		_, err := client.ScaleUpWithQuotaRetry(ctx, "projectID", "clusterID", "poolID", 2, ScaleRetryOpts{})
+		assert.ErrorIs(t, err, ErrSizeOutOfRange)
+		assert.Empty(t, *attempts)
+	})
+}
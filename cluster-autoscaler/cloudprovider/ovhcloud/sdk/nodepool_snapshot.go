@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxSnapshotDescriptionLength is the maximum number of characters of a snapshot description
+const MaxSnapshotDescriptionLength = 255
+
+// ErrNodePoolScaling is returned when restoring a snapshot of a node pool being resized
+var ErrNodePoolScaling = errors.New("node pool is scaling")
+
+// scalingStatuses are the node pool statuses of a resize in progress
+var scalingStatuses = map[string]bool{
+	"RESIZING":    true,
+	"UPSCALING":   true,
+	"DOWNSCALING": true,
+}
+
+// NodePoolSnapshot holds the configuration of a node pool at a point in time, for rollback
+type NodePoolSnapshot struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Description string    `json:"description"`
+	Config      *NodePool `json:"config"`
+}
+
+// CreateSnapshotOpts defines required fields to create a node pool snapshot
+type CreateSnapshotOpts struct {
+	Description string `json:"description"`
+}
+
+// CreateSnapshot allows to save the current configuration of a node pool
+func (c *Client) CreateSnapshot(ctx context.Context, projectID string, clusterID string, poolID string, description string) (*NodePoolSnapshot, error) {
+	if description == "" {
+		return nil, fmt.Errorf("snapshot description should not be empty")
+	}
+
+	if len([]rune(description)) > MaxSnapshotDescriptionLength {
+		return nil, fmt.Errorf("snapshot description should not exceed %d characters", MaxSnapshotDescriptionLength)
+	}
+
+	snapshot := &NodePoolSnapshot{}
+
+	return snapshot, c.CallAPIWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/snapshot", projectID, clusterID, poolID),
+		&CreateSnapshotOpts{Description: description},
+		&snapshot,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// ListSnapshots allows to list the snapshots of a node pool
+func (c *Client) ListSnapshots(ctx context.Context, projectID string, clusterID string, poolID string) ([]NodePoolSnapshot, error) {
+	snapshots := make([]NodePoolSnapshot, 0)
+
+	return snapshots, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/snapshot", projectID, clusterID, poolID),
+		nil,
+		&snapshots,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// DeleteSnapshot allows to delete a snapshot of a node pool
+func (c *Client) DeleteSnapshot(ctx context.Context, projectID string, clusterID string, poolID string, snapshotID string) error {
+	return c.CallAPIWithContext(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/snapshot/%s", projectID, clusterID, poolID, snapshotID),
+		nil,
+		nil,
+		nil,
+		nil,
+		true,
+	)
+}
+
+// RestoreSnapshot allows to apply back the configuration saved in a snapshot.
+// It fails with ErrNodePoolScaling while the node pool is being resized.
+func (c *Client) RestoreSnapshot(ctx context.Context, projectID string, clusterID string, poolID string, snapshotID string) (*NodePool, error) {
+	pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	if scalingStatuses[pool.Status] {
+		return nil, fmt.Errorf("%w: status is %s", ErrNodePoolScaling, pool.Status)
+	}
+
+	defer c.NodePoolCache.Invalidate(clusterID, poolID)
+
+	restored := &NodePool{}
+
+	return restored, c.CallAPIWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/snapshot/%s/restore", projectID, clusterID, poolID, snapshotID),
+		nil,
+		&restored,
+		nil,
+		nil,
+		true,
+	)
+}
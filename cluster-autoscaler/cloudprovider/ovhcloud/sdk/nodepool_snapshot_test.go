@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSnapshotTestClient(t *testing.T) (*Client, *NodePool) {
+	mutex := sync.Mutex{}
+	pool := &NodePool{ID: "poolID", Status: "READY", DesiredNodes: 3}
+	snapshots := make([]NodePoolSnapshot, 0)
+
+	path := "/cloud/project/projectID/kube/clusterID/nodepool/poolID"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		_ = json.NewEncoder(w).Encode(pool)
+	})
+	mux.HandleFunc(path+"/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.Method == "POST" {
+			opts := CreateSnapshotOpts{}
+			_ = json.NewDecoder(r.Body).Decode(&opts)
+
+			config := *pool
+			snapshot := NodePoolSnapshot{ID: fmt.Sprintf("snapshot-%d", len(snapshots)+1), Description: opts.Description, Config: &config}
+			snapshots = append(snapshots, snapshot)
+			_ = json.NewEncoder(w).Encode(snapshot)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(snapshots)
+	})
+	mux.HandleFunc(path+"/snapshot/", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, path+"/snapshot/")
+		id, restore := strings.CutSuffix(id, "/restore")
+
+		for i, snapshot := range snapshots {
+			if snapshot.ID != id {
+				continue
+			}
+
+			if restore {
+				*pool = *snapshot.Config
+				_ = json.NewEncoder(w).Encode(pool)
+				return
+			}
+
+			snapshots = append(snapshots[:i], snapshots[i+1:]...)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return newTestClient(t, mux), pool
+}
+
+func TestClient_Snapshots(t *testing.T) {
+	client, pool := newSnapshotTestClient(t)
+	ctx := context.Background()
+
+	var snapshot *NodePoolSnapshot
+
+	t.Run("check creation", func(t *testing.T) {
+		var err error
+
+		snapshot, err = client.CreateSnapshot(ctx, "projectID", "clusterID", "poolID", "before upgrade")
+		assert.NoError(t, err)
+		assert.Equal(t, "snapshot-1", snapshot.ID)
+		assert.Equal(t, "before upgrade", snapshot.Description)
+		assert.Equal(t, uint32(3), snapshot.Config.DesiredNodes)
+	})
+
+	t.Run("check description validation", func(t *testing.T) {
+		_, err := client.CreateSnapshot(ctx, "projectID", "clusterID", "poolID", "")
+		assert.Error(t, err)
+
+		_, err = client.CreateSnapshot(ctx, "projectID", "clusterID", "poolID", strings.Repeat("a", 256))
+		assert.Error(t, err)
+
+		_, err = client.CreateSnapshot(ctx, "projectID", "clusterID", "poolID", strings.Repeat("é", 255))
+		assert.NoError(t, err)
+	})
+
+	t.Run("check listing", func(t *testing.T) {
+		snapshots, err := client.ListSnapshots(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 2)
+		assert.Equal(t, "snapshot-1", snapshots[0].ID)
+	})
+
+	t.Run("check restore is refused while scaling", func(t *testing.T) {
+		pool.Status = "RESIZING"
+		defer func() { pool.Status = "READY" }()
+
+		_, err := client.RestoreSnapshot(ctx, "projectID", "clusterID", "poolID", snapshot.ID)
+		assert.ErrorIs(t, err, ErrNodePoolScaling)
+	})
+
+	t.Run("check restore", func(t *testing.T) {
+		pool.DesiredNodes = 5
+
+		restored, err := client.RestoreSnapshot(ctx, "projectID", "clusterID", "poolID", snapshot.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(3), restored.DesiredNodes)
+	})
+
+	t.Run("check deletion", func(t *testing.T) {
+		err := client.DeleteSnapshot(ctx, "projectID", "clusterID", "poolID", snapshot.ID)
+		assert.NoError(t, err)
+
+		snapshots, err := client.ListSnapshots(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 1)
+
+		_, err = client.RestoreSnapshot(ctx, "projectID", "clusterID", "poolID", snapshot.ID)
+		assert.Error(t, err)
+	})
+}
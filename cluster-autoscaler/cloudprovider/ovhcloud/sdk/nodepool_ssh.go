@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrSSHKeysUnknown is returned when adding an SSH key to a node pool whose SSH keys are not returned by the API,
+// as replacing them with the new key only would remove the access given by the others
+var ErrSSHKeysUnknown = errors.New("SSH keys of the node pool are unknown")
+
+// AddSSHKeyToNodePool installs an SSH key on the nodes of a node pool, keeping its other SSH keys.
+// The node pool is updated only if it was not modified since it was read, failing with ErrConflict otherwise.
+func (c *Client) AddSSHKeyToNodePool(ctx context.Context, projectID string, clusterID string, poolID string, sshKeyID string) error {
+	if sshKeyID == "" {
+		return errors.New("SSH key ID should not be empty")
+	}
+
+	pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	// An empty list is valid, while a missing one would be replaced by the new key only
+	if pool.SSHKeyIDs == nil {
+		return fmt.Errorf("failed to add SSH key to node pool %s: %w", poolID, ErrSSHKeysUnknown)
+	}
+
+	if slices.Contains(pool.SSHKeyIDs, sshKeyID) {
+		return nil
+	}
+
+	_, err = c.UpdateNodePool(ctx, projectID, clusterID, poolID, &UpdateNodePoolOpts{
+		SSHKeyIDs: append(pool.SSHKeyIDs, sshKeyID),
+		IfMatch:   pool.ETag,
+	})
+
+	return err
+}
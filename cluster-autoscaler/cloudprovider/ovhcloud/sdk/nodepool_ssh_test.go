@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CreateNodePool_SSHKeys(t *testing.T) {
+	var body map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		body = map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id": "poolID", "sshKeyIds": ["key-1"]}`))
+	})
+	client := newTestClient(t, mux)
+
+	pool, err := client.CreateNodePool(context.Background(), "projectID", "clusterID", &CreateNodePoolOpts{
+		FlavorName: "b2-7",
+		SSHKeyIDs:  []string{"key-1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"key-1"}, body["sshKeyIds"])
+	assert.Equal(t, []string{"key-1"}, pool.SSHKeyIDs)
+
+	_, err = client.CreateNodePool(context.Background(), "projectID", "clusterID", &CreateNodePoolOpts{FlavorName: "b2-7"})
+	assert.NoError(t, err)
+	assert.NotContains(t, body, "sshKeyIds")
+}
+
+func TestClient_AddSSHKeyToNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	newSSHClient := func(t *testing.T, pool string) (*Client, *[]UpdateNodePoolOpts) {
+		updates := make([]UpdateNodePoolOpts, 0)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PUT" {
+				opts := UpdateNodePoolOpts{IfMatch: r.Header.Get("If-Match")}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+				updates = append(updates, opts)
+			}
+
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(pool))
+		})
+
+		return newTestClient(t, mux), &updates
+	}
+
+	t.Run("check key is added to the existing ones", func(t *testing.T) {
+		client, updates := newSSHClient(t, `{"id": "poolID", "sshKeyIds": ["key-1"]}`)
+
+		err := client.AddSSHKeyToNodePool(ctx, "projectID", "clusterID", "poolID", "key-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []UpdateNodePoolOpts{{SSHKeyIDs: []string{"key-1", "key-2"}, IfMatch: `"v1"`}}, *updates)
+	})
+
+	t.Run("check key is added to a pool without keys", func(t *testing.T) {
+		client, updates := newSSHClient(t, `{"id": "poolID", "sshKeyIds": []}`)
+
+		err := client.AddSSHKeyToNodePool(ctx, "projectID", "clusterID", "poolID", "key-1")
+		assert.NoError(t, err)
+		assert.Len(t, *updates, 1)
+	})
+
+	t.Run("check installed key is not added again", func(t *testing.T) {
+		client, updates := newSSHClient(t, `{"id": "poolID", "sshKeyIds": ["key-1"]}`)
+
+		err := client.AddSSHKeyToNodePool(ctx, "projectID", "clusterID", "poolID", "key-1")
+		assert.NoError(t, err)
+		assert.Empty(t, *updates)
+	})
+
+	t.Run("check unknown keys are not replaced", func(t *testing.T) {
+		client, updates := newSSHClient(t, `{"id": "poolID"}`)
+
+		err := client.AddSSHKeyToNodePool(ctx, "projectID", "clusterID", "poolID", "key-1")
+		assert.ErrorIs(t, err, ErrSSHKeysUnknown)
+		assert.Empty(t, *updates)
+
+		err = client.AddSSHKeyToNodePool(ctx, "projectID", "clusterID", "poolID", "")
+		assert.Error(t, err)
+	})
+}
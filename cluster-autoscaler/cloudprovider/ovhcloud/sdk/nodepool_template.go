@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// UpdateNodePoolAnnotations sets the given annotations on the node pool template, keeping the other ones
+func (c *Client) UpdateNodePoolAnnotations(ctx context.Context, projectID string, clusterID string, poolID string, annotations map[string]string) error {
+	return c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		for key, value := range annotations {
+			template.Metadata.Annotations[key] = value
+		}
+	})
+}
+
+// UpdateNodePoolLabels sets the given labels on the node pool template, keeping the other ones
+func (c *Client) UpdateNodePoolLabels(ctx context.Context, projectID string, clusterID string, poolID string, labels map[string]string) error {
+	return c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		for key, value := range labels {
+			template.Metadata.Labels[key] = value
+		}
+	})
+}
+
+// UpdateNodePoolTaints sets the given taints on the node pool template, replacing the ones with the same key and effect
+func (c *Client) UpdateNodePoolTaints(ctx context.Context, projectID string, clusterID string, poolID string, taints []v1.Taint) error {
+	return c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		updated := make([]v1.Taint, 0, len(template.Spec.Taints)+len(taints))
+		for _, existing := range template.Spec.Taints {
+			replaced := false
+			for _, taint := range taints {
+				if existing.MatchTaint(&taint) {
+					replaced = true
+					break
+				}
+			}
+
+			if !replaced {
+				updated = append(updated, existing)
+			}
+		}
+
+		template.Spec.Taints = append(updated, taints...)
+	})
+}
+
+// GetNodePoolLabels returns the labels of the node pool template, applied to each of its nodes
+func (c *Client) GetNodePoolLabels(ctx context.Context, projectID string, clusterID string, poolID string) (map[string]string, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	return copyStringMap(pool.Template.Metadata.Labels), nil
+}
+
+// GetNodePoolTaints returns the taints of the node pool template, applied to each of its nodes
+func (c *Client) GetNodePoolTaints(ctx context.Context, projectID string, clusterID string, poolID string) ([]v1.Taint, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	return append([]v1.Taint{}, pool.Template.Spec.Taints...), nil
+}
+
+// updateNodePoolTemplate fetches a node pool template and updates it once modified.
+// The labels and annotations maps given to update are copies and never nil.
+func (c *Client) updateNodePoolTemplate(ctx context.Context, projectID string, clusterID string, poolID string, update func(template *NodePoolTemplate)) error {
+	pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	template := pool.Template
+	template.Metadata.Labels = copyStringMap(pool.Template.Metadata.Labels)
+	template.Metadata.Annotations = copyStringMap(pool.Template.Metadata.Annotations)
+	update(&template)
+
+	_, err = c.UpdateNodePool(ctx, projectID, clusterID, poolID, &UpdateNodePoolOpts{Template: &template})
+	if err != nil {
+		return fmt.Errorf("failed to update node pool %s template: %w", poolID, err)
+	}
+
+	return nil
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for key, value := range m {
+		c[key] = value
+	}
+
+	return c
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestClient_NodePoolTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	pool := &NodePool{ID: "poolID"}
+	pool.Template.Metadata.Labels = map[string]string{"role": "worker"}
+	pool.Template.Spec.Taints = []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			opts := &UpdateNodePoolOpts{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+			pool.Template = *opts.Template
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(pool))
+	})
+
+	client := newTestClient(t, mux)
+
+	t.Run("check labels and taints are read", func(t *testing.T) {
+		labels, err := client.GetNodePoolLabels(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"role": "worker"}, labels)
+
+		taints, err := client.GetNodePoolTaints(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Len(t, taints, 2)
+	})
+
+	t.Run("check taints with the same key and effect are replaced", func(t *testing.T) {
+		err := client.UpdateNodePoolTaints(ctx, "projectID", "clusterID", "poolID", []v1.Taint{
+			{Key: "dedicated", Value: "cpu", Effect: v1.TaintEffectNoSchedule},
+			{Key: "spot", Value: "true", Effect: v1.TaintEffectPreferNoSchedule},
+		})
+		assert.NoError(t, err)
+
+		taints, err := client.GetNodePoolTaints(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute},
+			{Key: "dedicated", Value: "cpu", Effect: v1.TaintEffectNoSchedule},
+			{Key: "spot", Value: "true", Effect: v1.TaintEffectPreferNoSchedule},
+		}, taints)
+
+		labels, err := client.GetNodePoolLabels(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"role": "worker"}, labels)
+	})
+
+	t.Run("check labels are merged with the existing ones", func(t *testing.T) {
+		err := client.UpdateNodePoolLabels(ctx, "projectID", "clusterID", "poolID", map[string]string{"zone": "a", "tier": "batch"})
+		assert.NoError(t, err)
+
+		err = client.UpdateNodePoolLabels(ctx, "projectID", "clusterID", "poolID", map[string]string{"tier": "web"})
+		assert.NoError(t, err)
+
+		labels, err := client.GetNodePoolLabels(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"role": "worker", "zone": "a", "tier": "web"}, labels)
+	})
+
+	t.Run("check errors are returned", func(t *testing.T) {
+		_, err := client.GetNodePoolTaints(ctx, "projectID", "clusterID", "unknown")
+		assert.ErrorContains(t, err, "failed to get node pool unknown")
+	})
+}
+
+func TestClient_NodePoolTemplateOnlySendsTemplate(t *testing.T) {
+	bodies := make([]map[string]json.RawMessage, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body := make(map[string]json.RawMessage)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			bodies = append(bodies, body)
+		}
+
+		_, _ = w.Write([]byte(`{"id": "poolID", "minNodes": 1, "maxNodes": 5, "desiredNodes": 2}`))
+	})
+	client := newTestClient(t, mux)
+
+	err := client.UpdateNodePoolLabels(context.Background(), "projectID", "clusterID", "poolID", map[string]string{"role": "worker"})
+	assert.NoError(t, err)
+
+	err = client.UpdateNodePoolTaints(context.Background(), "projectID", "clusterID", "poolID", []v1.Taint{{Key: "spot", Effect: v1.TaintEffectNoSchedule}})
+	assert.NoError(t, err)
+
+	// Sizes are left out of the update, so they cannot be reset
+	assert.Len(t, bodies, 2)
+	for _, body := range bodies {
+		assert.Len(t, body, 1)
+		assert.Contains(t, body, "template")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_PatchNodePool(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"minNodes": 2}`, string(body))
+
+		_, _ = w.Write([]byte(`{"id": "poolID", "minNodes": 2, "maxNodes": 5}`))
+	})
+
+	client := newTestClient(t, mux)
+	client.NodePoolCache.Set("clusterID", "poolID", &NodePool{ID: "poolID", MinNodes: 1, MaxNodes: 5})
+
+	minNodes := uint32(2)
+	pool, err := client.PatchNodePool(context.Background(), "projectID", "clusterID", "poolID", &PatchNodePoolOpts{MinNodes: &minNodes})
+	assert.NoError(t, err)
+	assert.Equal(t, &NodePool{ID: "poolID", MinNodes: 2, MaxNodes: 5}, pool)
+
+	_, ok := client.NodePoolCache.Get("clusterID", "poolID")
+	assert.False(t, ok)
+}
+
+func TestClient_DeleteNodePool(t *testing.T) {
+	deleted := make([]string, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "poolID", "status": "DELETING"}`))
+	})
+
+	client := newTestClient(t, mux)
+	client.NodePoolCache.Set("clusterID", "poolID", &NodePool{ID: "poolID", Status: "READY"})
+
+	pool, err := client.DeleteNodePool(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETING", pool.Status)
+	assert.Equal(t, []string{"DELETE /cloud/project/projectID/kube/clusterID/nodepool/poolID"}, deleted)
+
+	_, ok := client.NodePoolCache.Get("clusterID", "poolID")
+	assert.False(t, ok)
+
+	_, err = client.DeleteNodePool(context.Background(), "projectID", "clusterID", "unknown")
+	assert.Error(t, err)
+}
+
+func TestNodePool_SetFlavorResources(t *testing.T) {
+	pool := &NodePool{}
+	pool.SetFlavorResources(Flavor{Name: "t1-45", VCPUs: 8, GPUs: 1, RAM: 45, Disk: 400})
+
+	assert.Equal(t, 8, pool.CPUCount)
+	assert.Equal(t, 1, pool.GPUCount)
+	assert.Equal(t, 45*1024, pool.MemoryMB)
+	assert.Equal(t, 400, pool.EphemeralDiskGB)
+}
+
+func TestClient_GetFlavorInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/flavors", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "b2-7", "vCPUs": 2, "ram": 7, "disk": 50}, {"name": "t1-45", "vCPUs": 8, "gpus": 1, "ram": 45, "disk": 400}]`))
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check flavor is found by name", func(t *testing.T) {
+		flavor, err := client.GetFlavorInfo(context.Background(), "projectID", "clusterID", "t1-45")
+		assert.NoError(t, err)
+		assert.Equal(t, &Flavor{Name: "t1-45", VCPUs: 8, GPUs: 1, RAM: 45, Disk: 400}, flavor)
+	})
+
+	t.Run("check unknown flavor fails", func(t *testing.T) {
+		_, err := client.GetFlavorInfo(context.Background(), "projectID", "clusterID", "unknown")
+		assert.ErrorContains(t, err, "flavor unknown not found")
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError is returned when a node pool update breaks the invariants of the node pool sizes,
+// listing every broken invariant
+type ValidationError struct {
+	PoolID     string
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid update of node pool %s: %s", e.PoolID, strings.Join(e.Violations, ", "))
+}
+
+// IsValidationError returns whether the error is due to a node pool update breaking the invariants of its sizes
+func IsValidationError(err error) bool {
+	var validation *ValidationError
+	return errors.As(err, &validation)
+}
+
+// validateNodePoolUpdate checks the consistency of the sizes requested by an update. When the max nodes are
+// lowered, the node pool is read to check enough nodes to remove are given for its current nodes to fit.
+func (c *Client) validateNodePoolUpdate(ctx context.Context, projectID string, clusterID string, poolID string, opts *UpdateNodePoolOpts) error {
+	if opts == nil {
+		return nil
+	}
+
+	var pool *NodePool
+	if opts.MaxNodes != nil {
+		var err error
+		pool, err = c.GetNodePool(ctx, projectID, clusterID, poolID)
+		if err != nil {
+			return fmt.Errorf("failed to get node pool %s to validate its update: %w", poolID, err)
+		}
+	}
+
+	if violations := opts.violations(pool); len(violations) > 0 {
+		return &ValidationError{PoolID: poolID, Violations: violations}
+	}
+
+	return nil
+}
+
+// violations lists the invariants of the node pool sizes broken by the options,
+// the current nodes being only checked when the node pool is given
+func (opts *UpdateNodePoolOpts) violations(pool *NodePool) []string {
+	violations := make([]string, 0)
+
+	if opts.MinNodes != nil && opts.MaxNodes != nil && *opts.MinNodes > *opts.MaxNodes {
+		violations = append(violations, fmt.Sprintf("min nodes %d is above max nodes %d", *opts.MinNodes, *opts.MaxNodes))
+	}
+	if opts.DesiredNodes != nil && opts.MinNodes != nil && *opts.DesiredNodes < *opts.MinNodes {
+		violations = append(violations, fmt.Sprintf("desired nodes %d is below min nodes %d", *opts.DesiredNodes, *opts.MinNodes))
+	}
+	if opts.DesiredNodes != nil && opts.MaxNodes != nil && *opts.DesiredNodes > *opts.MaxNodes {
+		violations = append(violations, fmt.Sprintf("desired nodes %d is above max nodes %d", *opts.DesiredNodes, *opts.MaxNodes))
+	}
+
+	// Lowering the max nodes below the current nodes requires to choose the nodes to remove
+	if pool != nil && opts.MaxNodes != nil && *opts.MaxNodes < pool.CurrentNodes {
+		if missing := pool.CurrentNodes - *opts.MaxNodes; uint32(len(opts.NodesToRemove)) < missing {
+			violations = append(violations, fmt.Sprintf("max nodes %d is below the %d current nodes without %d nodes to remove",
+				*opts.MaxNodes, pool.CurrentNodes, missing))
+		}
+	}
+
+	return violations
+}
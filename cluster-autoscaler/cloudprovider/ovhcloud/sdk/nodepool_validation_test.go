@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_UpdateNodePoolValidation(t *testing.T) {
+	updates := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			updates++
+		}
+		_, _ = w.Write([]byte(`{"id": "poolID", "currentNodes": 4, "minNodes": 1, "maxNodes": 10}`))
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	uint32Ptr := func(value uint32) *uint32 { return &value }
+
+	t.Run("check every violated invariant is listed", func(t *testing.T) {
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{
+			MinNodes:     uint32Ptr(3),
+			MaxNodes:     uint32Ptr(2),
+			DesiredNodes: uint32Ptr(1),
+		})
+
+		var validation *ValidationError
+		assert.True(t, errors.As(err, &validation))
+		assert.True(t, IsValidationError(err))
+		assert.Equal(t, &ValidationError{
+			PoolID: "poolID",
+			Violations: []string{
+				"min nodes 3 is above max nodes 2",
+				"desired nodes 1 is below min nodes 3",
+				"max nodes 2 is below the 4 current nodes without 2 nodes to remove",
+			},
+		}, validation)
+		assert.Equal(t, 0, updates)
+	})
+
+	t.Run("check max nodes may be lowered with nodes to remove", func(t *testing.T) {
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{
+			MaxNodes:      uint32Ptr(3),
+			NodesToRemove: []string{"node-1"},
+		})
+		assert.NoError(t, err)
+
+		_, err = client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{
+			MaxNodes: uint32Ptr(3),
+		})
+		assert.ErrorContains(t, err, "max nodes 3 is below the 4 current nodes without 1 nodes to remove")
+		assert.Equal(t, 1, updates)
+	})
+
+	t.Run("check consistent updates are sent", func(t *testing.T) {
+		_, err := client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{
+			MinNodes:     uint32Ptr(1),
+			MaxNodes:     uint32Ptr(8),
+			DesiredNodes: uint32Ptr(5),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updates)
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WatchPollInitialInterval is the first interval between two reads of a node pool watched by polling
+var WatchPollInitialInterval = 2 * time.Second
+
+// WatchPollMaxInterval is the longest interval between two reads of a node pool watched by polling
+var WatchPollMaxInterval = time.Minute
+
+// errWatchUnsupported is returned when the API does not stream the changes of the node pools
+var errWatchUnsupported = errors.New("node pool watch is not supported")
+
+// WatchNodePool calls handler with the node pool every time its status changes, starting with its current status.
+// The changes are streamed by the API as Server-Sent Events when it supports it, otherwise the node pool is polled
+// with an exponential backoff, reset on every change. It returns the context error once the context is done.
+func (c *Client) WatchNodePool(ctx context.Context, projectID string, clusterID string, poolID string, handler func(NodePool)) error {
+	err := c.streamNodePool(ctx, projectID, clusterID, poolID, newStatusChangeHandler(handler))
+	if errors.Is(err, errWatchUnsupported) {
+		return c.pollNodePool(ctx, projectID, clusterID, poolID, handler)
+	}
+
+	return err
+}
+
+// streamNodePool calls handler with every node pool streamed by the API, or returns errWatchUnsupported
+func (c *Client) streamNodePool(ctx context.Context, projectID string, clusterID string, poolID string, handler func(NodePool)) error {
+	path := fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/watch", projectID, clusterID, poolID)
+
+	req, err := c.NewRequestWithContext(ctx, "GET", path, nil, nil, map[string]interface{}{"Accept": "text/event-stream"}, true)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	// The stream lasts until the context is done, the client timeout must not interrupt it
+	streamClient := *c.Client
+	streamClient.Timeout = 0
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to watch node pool %s: %w", poolID, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return errWatchUnsupported
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return c.UnmarshalResponse(resp, nil)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+	return watchEvents(ctx, body, poolID, handler)
+}
+
+// watchEvents calls handler with the node pool of every event of the stream, until it ends or the context is done
+func watchEvents(ctx context.Context, stream io.Reader, poolID string, handler func(NodePool)) error {
+	scanner := bufio.NewScanner(stream)
+	data := &bytes.Buffer{}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		switch {
+		case len(line) == 0:
+			// A blank line dispatches the event
+			if data.Len() > 0 {
+				pool := NodePool{}
+				if err := json.Unmarshal(data.Bytes(), &pool); err != nil {
+					return fmt.Errorf("failed to unmarshal node pool %s event: %w", poolID, err)
+				}
+				handler(pool)
+				data.Reset()
+			}
+		case bytes.HasPrefix(line, []byte("data:")):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.Write(bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" ")))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read node pool %s events: %w", poolID, err)
+	}
+
+	return fmt.Errorf("watch of node pool %s ended: %w", poolID, io.EOF)
+}
+
+// pollNodePool reads the node pool until the context is done, waiting longer between two reads while its status is unchanged
+func (c *Client) pollNodePool(ctx context.Context, projectID string, clusterID string, poolID string, handler func(NodePool)) error {
+	interval := WatchPollInitialInterval
+	lastStatus := ""
+
+	for first := true; ; first = false {
+		pool, err := c.getFreshNodePool(ctx, projectID, clusterID, poolID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+		}
+
+		if first || pool.Status != lastStatus {
+			lastStatus = pool.Status
+			interval = WatchPollInitialInterval
+			handler(*pool)
+		} else if interval *= 2; interval > WatchPollMaxInterval {
+			interval = WatchPollMaxInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(interval):
+		}
+	}
+}
+
+// newStatusChangeHandler wraps handler to only call it with the node pools whose status changed
+func newStatusChangeHandler(handler func(NodePool)) func(NodePool) {
+	called := false
+	lastStatus := ""
+
+	return func(pool NodePool) {
+		if called && pool.Status == lastStatus {
+			return
+		}
+
+		called = true
+		lastStatus = pool.Status
+		handler(pool)
+	}
+}
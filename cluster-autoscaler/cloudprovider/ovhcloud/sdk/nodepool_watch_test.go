@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_WatchNodePool(t *testing.T) {
+	t.Run("check node pool events are streamed", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id/watch", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, status := range []string{"RESIZING", "RESIZING", "READY"} {
+				_, _ = fmt.Fprintf(w, "event: update\ndata: {\"id\": \"pool-id\", \"status\": %q}\n\n", status)
+				w.(http.Flusher).Flush()
+			}
+			<-r.Context().Done()
+		})
+		client := newTestClient(t, mux)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		statuses := []string{}
+		err := client.WatchNodePool(ctx, "project-id", "cluster-id", "pool-id", func(pool NodePool) {
+			statuses = append(statuses, pool.Status)
+			if pool.Status == "READY" {
+				cancel()
+			}
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"RESIZING", "READY"}, statuses)
+	})
+
+	t.Run("check node pool is polled when the watch endpoint is not found", func(t *testing.T) {
+		initialInterval, maxInterval := WatchPollInitialInterval, WatchPollMaxInterval
+		WatchPollInitialInterval, WatchPollMaxInterval = time.Millisecond, 4*time.Millisecond
+		defer func() {
+			WatchPollInitialInterval, WatchPollMaxInterval = initialInterval, maxInterval
+		}()
+
+		var calls int32
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id/watch", func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		})
+		mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id", func(w http.ResponseWriter, r *http.Request) {
+			status := "RESIZING"
+			if atomic.AddInt32(&calls, 1) > 3 {
+				status = "READY"
+			}
+			_, _ = fmt.Fprintf(w, `{"id": "pool-id", "status": %q}`, status)
+		})
+		client := newTestClient(t, mux)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		statuses := []string{}
+		err := client.WatchNodePool(ctx, "project-id", "cluster-id", "pool-id", func(pool NodePool) {
+			statuses = append(statuses, pool.Status)
+			if pool.Status == "READY" {
+				cancel()
+			}
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"RESIZING", "READY"}, statuses)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("check watch fails on API errors", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id/watch", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "forbidden"}`))
+		})
+		client := newTestClient(t, mux)
+
+		err := client.WatchNodePool(context.Background(), "project-id", "cluster-id", "pool-id", func(pool NodePool) {
+			assert.Fail(t, "handler should not be called")
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "forbidden")
+	})
+}
+
+func TestClient_WaitForNodePoolStable_Watch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id/watch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, status := range []string{"RESIZING", "READY"} {
+			_, _ = fmt.Fprintf(w, "data: {\"id\": \"pool-id\", \"status\": %q}\n\n", status)
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/cloud/project/project-id/kube/cluster-id/nodepool/pool-id", func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "node pool should not be polled")
+	})
+	client := newTestClient(t, mux)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pool, err := client.WaitForNodePoolStable(ctx, "project-id", "cluster-id", "pool-id", time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "READY", pool.Status)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultWebhookTimeout is the timeout of the webhook notifications when none is set
+const DefaultWebhookTimeout = 10 * time.Second
+
+// ScaleDirection tells whether a node pool is scaled up or down
+type ScaleDirection string
+
+const (
+	// ScaleDirectionUp is the direction of an increase of the desired nodes
+	ScaleDirectionUp ScaleDirection = "up"
+	// ScaleDirectionDown is the direction of a decrease of the desired nodes
+	ScaleDirectionDown ScaleDirection = "down"
+)
+
+// ScaleEvent is the payload sent to the webhook when the desired nodes of a node pool change
+type ScaleEvent struct {
+	ClusterID string         `json:"clusterId"`
+	PoolID    string         `json:"poolId"`
+	OldSize   uint32         `json:"oldSize"`
+	NewSize   uint32         `json:"newSize"`
+	Direction ScaleDirection `json:"direction"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// WebhookNotifier posts the scale events to a webhook, signing their body with the HMAC-SHA256 of Secret
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+
+	// Timeout of a notification, DefaultWebhookTimeout when zero
+	Timeout time.Duration
+}
+
+// WithWebhookNotifier makes the client notify the webhook of every change of the desired nodes of a node pool
+func WithWebhookNotifier(notifier *WebhookNotifier) ClientOption {
+	return func(client *Client) error {
+		client.Notifier = notifier
+		return nil
+	}
+}
+
+// Notify posts the event to the webhook, with its signature in VKESignatureHeader
+func (n *WebhookNotifier) Notify(ctx context.Context, event ScaleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scale event: %w", err)
+	}
+
+	timeout := n.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(VKESignatureHeader, SignHMACSHA256(n.Secret, string(body)))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// notifyScale sends a scale event to the notifier in the background, when set and the size did change,
+// so a slow or failing webhook never delays the scaling
+func (c *Client) notifyScale(clusterID string, poolID string, oldSize uint32, newSize uint32) {
+	if c.Notifier == nil || oldSize == newSize {
+		return
+	}
+
+	direction := ScaleDirectionUp
+	if newSize < oldSize {
+		direction = ScaleDirectionDown
+	}
+
+	event := ScaleEvent{
+		ClusterID: clusterID,
+		PoolID:    poolID,
+		OldSize:   oldSize,
+		NewSize:   newSize,
+		Direction: direction,
+		Timestamp: c.now(),
+	}
+
+	go func() {
+		if err := c.Notifier.Notify(context.Background(), event); err != nil {
+			klog.Warningf("failed to notify scale of node pool %s from %d to %d nodes: %v", poolID, oldSize, newSize, err)
+		}
+	}()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWebhook(t *testing.T, secret string, status int) (*WebhookNotifier, <-chan ScaleEvent) {
+	events := make(chan ScaleEvent, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(VKESignatureHeader))
+
+		event := ScaleEvent{}
+		assert.NoError(t, json.Unmarshal(body, &event))
+		events <- event
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return &WebhookNotifier{URL: server.URL, Secret: secret}, events
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	event := ScaleEvent{
+		ClusterID: "clusterID",
+		PoolID:    "poolID",
+		OldSize:   2,
+		NewSize:   3,
+		Direction: ScaleDirectionUp,
+		Timestamp: time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("check event is posted with its signature", func(t *testing.T) {
+		notifier, events := newTestWebhook(t, "secret", http.StatusNoContent)
+
+		err := notifier.Notify(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, event, <-events)
+	})
+
+	t.Run("check error status is returned", func(t *testing.T) {
+		notifier, events := newTestWebhook(t, "secret", http.StatusInternalServerError)
+
+		err := notifier.Notify(context.Background(), event)
+		assert.ErrorContains(t, err, "status 500")
+		<-events
+	})
+}
+
+func TestClient_UpdateNodePoolNotifiesScale(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "desiredNodes": 1, "currentNodes": 3}`))
+	})
+	client := newTestClient(t, mux)
+
+	notifier, events := newTestWebhook(t, "secret", http.StatusOK)
+	client.Notifier = notifier
+
+	t.Run("check scale down is notified with the cached size", func(t *testing.T) {
+		client.NodePoolCache.Set("clusterID", "poolID", &NodePool{ID: "poolID", DesiredNodes: 2})
+
+		size := uint32(1)
+		_, err := client.UpdateNodePool(context.Background(), "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{DesiredNodes: &size})
+		assert.NoError(t, err)
+
+		event := <-events
+		assert.Equal(t, "poolID", event.PoolID)
+		assert.Equal(t, uint32(2), event.OldSize)
+		assert.Equal(t, uint32(1), event.NewSize)
+		assert.Equal(t, ScaleDirectionDown, event.Direction)
+	})
+
+	t.Run("check unchanged size is not notified", func(t *testing.T) {
+		autoscale := true
+		_, err := client.UpdateNodePool(context.Background(), "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{Autoscale: &autoscale})
+		assert.NoError(t, err)
+
+		select {
+		case event := <-events:
+			assert.Fail(t, "unexpected event", event)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -18,6 +18,7 @@ package sdk
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/magnum/gophercloud"
@@ -34,6 +35,7 @@ type OpenStackProvider struct {
 	AuthUrl             string
 	Token               string
 	tokenExpirationTime time.Time
+	tokenMutex          sync.RWMutex
 }
 
 // NewOpenStackProvider initializes a client/token pair to interact with OpenStack
@@ -58,13 +60,17 @@ func NewOpenStackProvider(authUrl string, username string, password string, doma
 	}, nil
 }
 
-// ReauthenticateToken revoke the current provider token and re-create a new one
+// ReauthenticateToken revoke the current provider token and re-create a new one.
+// The current token is still served while the new one is requested.
 func (p *OpenStackProvider) ReauthenticateToken() error {
-	err := p.provider.Reauthenticate(p.Token)
+	err := p.provider.Reauthenticate(p.GetToken())
 	if err != nil {
 		return fmt.Errorf("failed to re-auth previous openstack token: %w", err)
 	}
 
+	p.tokenMutex.Lock()
+	defer p.tokenMutex.Unlock()
+
 	p.Token = p.provider.Token()
 	p.tokenExpirationTime = time.Now().Add(DefaultExpirationTime)
 
@@ -73,5 +79,21 @@ func (p *OpenStackProvider) ReauthenticateToken() error {
 
 // IsTokenExpired checks if the current token is expired
 func (p *OpenStackProvider) IsTokenExpired() bool {
-	return p.tokenExpirationTime.Before(time.Now())
+	return p.TokenExpirationTime().Before(time.Now())
+}
+
+// GetToken returns the current token
+func (p *OpenStackProvider) GetToken() string {
+	p.tokenMutex.RLock()
+	defer p.tokenMutex.RUnlock()
+
+	return p.Token
+}
+
+// TokenExpirationTime returns when the current token expires
+func (p *OpenStackProvider) TokenExpirationTime() time.Time {
+	p.tokenMutex.RLock()
+	defer p.tokenMutex.RUnlock()
+
+	return p.tokenExpirationTime
 }
@@ -19,22 +19,26 @@ package sdk
 import (
 	"bytes"
 	"context"
-	"crypto/sha1"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"k8s.io/klog/v2"
 )
 
 // DefaultTimeout api requests after 180s
 const DefaultTimeout = 180 * time.Second
 
+// DefaultMaxResponseBodyBytes limits api response bodies to 10 MB
+const DefaultMaxResponseBodyBytes = 10 << 20
+
 // Endpoints
 const (
 	OvhEU        = "https://eu.api.ovh.com/1.0"
@@ -62,6 +66,8 @@ var Endpoints = map[string]string{
 // Errors
 var (
 	ErrAPIDown = errors.New("go-vh: the OVH API is down, it does't respond to /time anymore")
+
+	ErrResponseTooLarge = errors.New("go-vh: the OVH API response body exceeds the maximum allowed size")
 )
 
 // Client represents a client to call the OVH API
@@ -80,6 +86,9 @@ type Client struct {
 	// API endpoint
 	endpoint string
 
+	// ConfigFile is the ini file holding the client configuration, searched in the usual paths when empty
+	ConfigFile string
+
 	// Client is the underlying HTTP client used to run the requests. It may be overloaded but a default one is instanciated in ``NewClient`` by default.
 	Client *http.Client
 
@@ -94,26 +103,152 @@ type Client struct {
 	timeDelta      time.Duration
 	Timeout        time.Duration
 
+	// MaxResponseBodyBytes limits the size of the response bodies read from the API.
+	// DefaultMaxResponseBodyBytes is used when zero.
+	MaxResponseBodyBytes int64
+
+	// CompressionThreshold is the size, in bytes, above which request bodies are gzip-compressed.
+	// DefaultCompressionThreshold is used when zero.
+	CompressionThreshold int
+
+	// LabelPrefix is prepended to the labels and annotations managed by the client.
+	// DefaultLabelPrefix is used when empty.
+	LabelPrefix string
+
+	// RequestCounter counts the API requests performed by the client
+	RequestCounter *RequestCounter
+
 	// token used to generate api calls without credentials using OpenStack keystone
 	openStackToken string
+
+	// tokenProvider renews the OpenStack keystone token in the background when set
+	tokenProvider    TokenProvider
+	tokenRenewBefore time.Duration
+	stopTokenRenewal func()
+
+	// tokenSource gives the OpenStack keystone token of every request when set
+	tokenSource TokenSource
+
+	// tunnel forwards the API connections through a bastion when set
+	tunnel *sshTunnel
+
+	// proxy routes the API requests through an HTTP proxy when set
+	proxy *ProxyDialer
+
+	// regions performs the calls of the client, failing over to other regions, when set
+	regions *MultiRegionClient
+
+	// SigningVersion selects the algorithm signing the requests, SigningVersionSHA1 by default
+	SigningVersion SigningVersion
+
+	// isLeader tells whether the client may perform mutating calls, always when nil
+	isLeader func() bool
+
+	// NodePoolCache keeps the node pools read by GetNodePool and ListNodePools
+	NodePoolCache    *NodePoolCache
+	nodePoolCacheTTL time.Duration
+
+	// NodeCache keeps the nodes read by ListNodePoolNodes
+	NodeCache    *NodeCache
+	nodeCacheTTL time.Duration
+
+	// CircuitBreaker stops the calls to the API while it is down, when set
+	CircuitBreaker       *CircuitBreaker
+	circuitBreakerConfig *CircuitBreakerConfig
+
+	// Cooldown spaces out the mutations of the node pools, when set
+	Cooldown       *CooldownManager
+	cooldownPeriod time.Duration
+
+	// rateLimiter delays the requests to respect the API quotas, when set
+	rateLimiter RateLimiter
+
+	// callBudget limits the requests sent per cycle, when set
+	callBudget *CallBudget
+
+	// callSlots limits the calls running at the same time, when set
+	callSlots chan struct{}
+
+	// metrics records the API calls, when set
+	metrics Metrics
+
+	// pricingCache keeps the prices of the flavors read by EstimateNodePoolCostPerHour
+	pricingCache map[flavorPricingKey]flavorPricingEntry
+	pricingMutex sync.Mutex
+
+	// Notifier is sent the changes of the desired nodes of the node pools, when set
+	Notifier *WebhookNotifier
+
+	// DryRun makes UpdateNodePool and DeleteNode log and record their request in DryRunRecord instead of sending it
+	DryRun       bool
+	DryRunRecord []DryRunEntry
+	dryRunMutex  sync.Mutex
+
+	// operationTimeouts overrides Timeout for some kinds of operations
+	operationTimeouts OperationTimeouts
+
+	// retryPolicy attempts again the calls failing with a transient error when set
+	retryPolicy *RetryPolicy
+
+	// clock gives the current time used to sign the requests
+	clock Clock
+
+	// inFlight tracks the calls in progress, for Shutdown to wait for them
+	inFlight      sync.WaitGroup
+	inFlightCount int64
+	inFlightMutex sync.Mutex
+	shutdown      bool
 }
 
+// ClientOption allows to customize a client when creating it
+type ClientOption func(client *Client) error
+
 // NewClient represents a new client to call the API
-func NewClient(endpoint, appKey, appSecret, consumerKey string) (*Client, error) {
+func NewClient(endpoint, appKey, appSecret, consumerKey string, opts ...ClientOption) (*Client, error) {
 	client := Client{
 		AppKey:         appKey,
 		AppSecret:      appSecret,
 		ConsumerKey:    consumerKey,
-		Client:         &http.Client{},
+		Client:         NewHTTPClient(DefaultMaxIdleConns, DefaultMaxIdleConnsPerHost, DefaultIdleConnTimeout),
 		timeDeltaMutex: &sync.Mutex{},
 		timeDeltaDone:  false,
 		Timeout:        time.Duration(DefaultTimeout),
+		clock:          SystemClock{},
+		RequestCounter: NewRequestCounter(),
+
+		nodePoolCacheTTL: DefaultNodePoolCacheTTL,
+		nodeCacheTTL:     DefaultNodeCacheTTL,
 	}
 
-	// Get and check the configuration
+	for _, opt := range opts {
+		if err := opt(&client); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get and check the configuration, once the options giving the configuration file are applied
 	if err := client.loadConfig(endpoint); err != nil {
 		return nil, err
 	}
+
+	// Set once, as the HTTP client is shared by the concurrent calls: the timeout of each request is enforced by its context
+	client.Client.Timeout = client.httpClientTimeout()
+
+	// Created once every option is applied, to use the configured TTL and clock
+	client.NodePoolCache = NewNodePoolCache(client.nodePoolCacheTTL, client.clock)
+	client.NodeCache = NewNodeCache(client.nodeCacheTTL, client.clock)
+	if client.cooldownPeriod > 0 {
+		client.Cooldown = NewCooldownManager(client.cooldownPeriod, client.clock)
+	}
+	if client.circuitBreakerConfig != nil {
+		client.CircuitBreaker = NewCircuitBreaker(*client.circuitBreakerConfig, client.clock)
+	}
+
+	// Started once every option is applied, to use the configured clock
+	if client.tokenProvider != nil {
+		client.stopTokenRenewal = startTokenRenewal(context.Background(), client.clock, client.tokenProvider, client.tokenRenewBefore)
+	}
+
 	return &client, nil
 }
 
@@ -132,15 +267,24 @@ func NewDefaultClient() (*Client, error) {
 
 // NewDefaultClientWithToken will load all it's parameter from environment
 // or configuration files using an OpenStack keystone token
-func NewDefaultClientWithToken(authUrl, token string) (*Client, error) {
-	// Find endpoint given the keystone auth url
-	endpoint := OvhEU
+func NewDefaultClientWithToken(authUrl, token string, opts ...ClientOption) (*Client, error) {
+	return NewEndpointClientWithToken(EndpointFromAuthUrl(authUrl), token, opts...)
+}
+
+// EndpointFromAuthUrl finds the API endpoint given the keystone auth url
+func EndpointFromAuthUrl(authUrl string) string {
 	if strings.Contains(authUrl, "ovh.us") {
-		endpoint = OvhUS
+		return OvhUS
 	}
 
+	return OvhEU
+}
+
+// NewEndpointClientWithToken will create an API client for specified
+// endpoint using an OpenStack keystone token
+func NewEndpointClientWithToken(endpoint, token string, opts ...ClientOption) (*Client, error) {
 	// Create OVH api client
-	client, err := NewClient(endpoint, "none", "none", "none")
+	client, err := NewClient(endpoint, "none", "none", "none", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +350,16 @@ func (c *Client) PutUnAuth(url string, reqBody, result interface{}, queryParams
 	return c.CallAPI("PUT", url, reqBody, result, queryParams, false)
 }
 
+// Patch is a wrapper for the PATCH method
+func (c *Client) Patch(url string, reqBody, result interface{}, queryParams url.Values) error {
+	return c.CallAPI("PATCH", url, reqBody, result, queryParams, true)
+}
+
+// PatchUnAuth is a wrapper for the unauthenticated PATCH method
+func (c *Client) PatchUnAuth(url string, reqBody, result interface{}, queryParams url.Values) error {
+	return c.CallAPI("PATCH", url, reqBody, result, queryParams, false)
+}
+
 // Delete is a wrapper for the DELETE method
 func (c *Client) Delete(url string, result interface{}, queryParams url.Values) error {
 	return c.CallAPI("DELETE", url, nil, result, queryParams, true)
@@ -246,6 +400,16 @@ func (c *Client) PutUnAuthWithContext(ctx context.Context, url string, reqBody,
 	return c.CallAPIWithContext(ctx, "PUT", url, reqBody, result, queryParams, nil, false)
 }
 
+// PatchWithContext is a wrapper for the PATCH method
+func (c *Client) PatchWithContext(ctx context.Context, url string, reqBody, result interface{}, queryParams url.Values) error {
+	return c.CallAPIWithContext(ctx, "PATCH", url, reqBody, result, queryParams, nil, true)
+}
+
+// PatchUnAuthWithContext is a wrapper for the unauthenticated PATCH method
+func (c *Client) PatchUnAuthWithContext(ctx context.Context, url string, reqBody, result interface{}, queryParams url.Values) error {
+	return c.CallAPIWithContext(ctx, "PATCH", url, reqBody, result, queryParams, nil, false)
+}
+
 // DeleteWithContext is a wrapper for the DELETE method
 func (c *Client) DeleteWithContext(ctx context.Context, url string, result interface{}, queryParams url.Values) error {
 	return c.CallAPIWithContext(ctx, "DELETE", url, nil, result, queryParams, nil, true)
@@ -272,7 +436,7 @@ func (c *Client) getTimeDelta() (time.Duration, error) {
 				return 0, err
 			}
 
-			c.timeDelta = time.Since(*ovhTime)
+			c.timeDelta = c.now().Sub(*ovhTime)
 			c.timeDeltaDone = true
 		}
 	}
@@ -286,6 +450,9 @@ func (c *Client) getTime() (*time.Time, error) {
 
 	err := c.GetUnAuth("/auth/time", &timestamp, nil)
 	if err != nil {
+		if isCircuitFailure(err) {
+			return nil, fmt.Errorf("%w: %w", ErrAPIDown, err)
+		}
 		return nil, err
 	}
 
@@ -293,12 +460,6 @@ func (c *Client) getTime() (*time.Time, error) {
 	return &serverTime, nil
 }
 
-// getLocalTime is a function to be overwritten during the tests, it return the time
-// on the the local machine
-var getLocalTime = func() time.Time {
-	return time.Now()
-}
-
 // getEndpointForSignature is a function to be overwritten during the tests, it returns a
 // the endpoint
 var getEndpointForSignature = func(c *Client) string {
@@ -307,6 +468,11 @@ var getEndpointForSignature = func(c *Client) string {
 
 // NewRequest returns a new HTTP request
 func (c *Client) NewRequest(method, path string, reqBody interface{}, queryParams url.Values, headers map[string]interface{}, needAuth bool) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, reqBody, queryParams, headers, needAuth)
+}
+
+// NewRequestWithContext returns a new HTTP request, the context being used to get its token
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, reqBody interface{}, queryParams url.Values, headers map[string]interface{}, needAuth bool) (*http.Request, error) {
 	var body []byte
 	var err error
 
@@ -317,6 +483,24 @@ func (c *Client) NewRequest(method, path string, reqBody interface{}, queryParam
 		}
 	}
 
+	// Large bodies are compressed, and signed as sent
+	threshold := c.CompressionThreshold
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	compressed := len(body) > threshold
+	if compressed {
+		body, err = compressBody(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The query is part of the signed URL
+	if len(queryParams) > 0 {
+		path = fmt.Sprintf("%s?%s", path, queryParams.Encode())
+	}
+
 	target := fmt.Sprintf("%s%s", c.endpoint, path)
 	req, err := http.NewRequest(method, target, bytes.NewReader(body))
 	if err != nil {
@@ -327,47 +511,44 @@ func (c *Client) NewRequest(method, path string, reqBody interface{}, queryParam
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json;charset=utf-8")
 	}
+	if compressed {
+		req.Header.Add("Content-Encoding", "gzip")
+	}
 	req.Header.Add("X-Ovh-Application", c.AppKey)
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Accept-Encoding", "gzip")
+	req.Header.Set(RequestIDHeader, newRequestID())
 
 	// Bind OpenStack token to authorization bearer and custom headers
-	if c.openStackToken != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer OpenStack/%s", c.openStackToken))
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenStack token: %w", err)
+	}
+	if token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer OpenStack/%s", token))
 	}
 
 	for headerName, headerValue := range headers {
 		req.Header.Set(headerName, fmt.Sprintf("%v", headerValue))
 	}
+	klog.V(4).Infof("Calling %s %s with request ID %s", method, path, req.Header.Get(RequestIDHeader))
 
 	// Inject signature. Some methods do not need authentication, especially /time,
 	// /auth and some /order methods are actually broken if authenticated.
-	if c.openStackToken == "" {
+	if needAuth && token == "" {
 		timeDelta, err := c.TimeDelta()
 		if err != nil {
 			return nil, err
 		}
 
-		timestamp := getLocalTime().Add(-timeDelta).Unix()
+		timestamp := c.now().Add(-timeDelta).Unix()
 
 		req.Header.Add("X-Ovh-Timestamp", strconv.FormatInt(timestamp, 10))
 		req.Header.Add("X-Ovh-Consumer", c.ConsumerKey)
 
-		h := sha1.New()
-		h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s%s+%s+%d",
-			c.AppSecret,
-			c.ConsumerKey,
-			method,
-			getEndpointForSignature(c),
-			path,
-			body,
-			timestamp,
-		)))
-		req.Header.Add("X-Ovh-Signature", fmt.Sprintf("$1$%x", h.Sum(nil)))
+		req.Header.Add(c.sign(method, path, body, timestamp))
 	}
 
-	// Send the request with requested timeout
-	c.Client.Timeout = c.Timeout
-
 	return req, nil
 }
 
@@ -376,12 +557,13 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	if c.Logger != nil {
 		c.Logger.LogRequest(req)
 	}
+	start := c.now()
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	if c.Logger != nil {
-		c.Logger.LogResponse(resp)
+		c.Logger.LogResponse(resp, c.now().Sub(start))
 	}
 	return resp, nil
 }
@@ -389,7 +571,8 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 // CallAPI is the lowest level call helper. If needAuth is true,
 // inject authentication headers and sign the request.
 //
-// Request signature is a sha1 hash on following fields, joined by '+':
+// Request signature is a sha1 hash, or a HMAC-SHA256 keyed on the application
+// secret depending on the client SigningVersion, on following fields, joined by '+':
 // - applicationSecret (from Client instance)
 // - consumerKey (from Client instance)
 // - capitalized method (from arguments)
@@ -411,7 +594,8 @@ func (c *Client) CallAPI(method, path string, reqBody, result interface{}, query
 // CallAPIWithContext is the lowest level call helper. If needAuth is true,
 // inject authentication headers and sign the request.
 //
-// Request signature is a sha1 hash on following fields, joined by '+':
+// Request signature is a sha1 hash, or a HMAC-SHA256 keyed on the application
+// secret depending on the client SigningVersion, on following fields, joined by '+':
 // - applicationSecret (from Client instance)
 // - consumerKey (from Client instance)
 // - capitalized method (from arguments)
@@ -429,58 +613,122 @@ func (c *Client) CallAPI(method, path string, reqBody, result interface{}, query
 // If everything went fine, unmarshall response into result and return nil
 // otherwise, return the error
 func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, reqBody, result interface{}, queryParams url.Values, headers map[string]interface{}, needAuth bool) error {
-	req, err := c.NewRequest(method, path, reqBody, queryParams, headers, needAuth)
-	if err != nil {
+	if c.regions != nil {
+		return c.regions.CallAPIWithContext(ctx, method, path, reqBody, result, queryParams, headers, needAuth)
+	}
+
+	return c.callAPIWithContext(ctx, method, path, reqBody, result, queryParams, headers, needAuth)
+}
+
+// callAPIWithContext performs the call on the endpoint of the client only
+func (c *Client) callAPIWithContext(ctx context.Context, method, path string, reqBody, result interface{}, queryParams url.Values, headers map[string]interface{}, needAuth bool) error {
+	if err := c.checkLeader(method); err != nil {
 		return err
 	}
 
-	req = req.WithContext(ctx)
-	response, err := c.Do(req)
-	if err != nil {
+	if err := c.startCall(); err != nil {
 		return err
 	}
+	defer c.endCall()
 
-	err = c.UnmarshalResponse(response, result)
+	release, err := c.acquireCallSlot(ctx)
 	if err != nil {
-		// An error 500 on api.ovh.com could be due to the tenant being canadian and too recent, so let's retry on ca.api.ovh.
-		// This is a temporary fix until the issue is correctly handled
-		if IsPossiblyCanadianTenantSyncError(err, req.URL.String()) {
-			// Create a canadian API client with the same token
-			client, err2 := NewClient(OvhCA, "none", "none", "none")
-			if err2 != nil {
-				return fmt.Errorf("failed to create canadian ovh API client for fallback: %w", err2)
+		return err
+	}
+	defer release()
+
+	attempts := 0
+	return c.withRetry(ctx, func() error {
+		attempts++
+		if attempts > 1 && c.metrics != nil {
+			c.metrics.IncrementRetry(method, path)
+		}
+
+		// Waited before asking the circuit breaker, for a call abandoned while waiting not to take a probe
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return err
 			}
-			client.openStackToken = c.openStackToken
+		}
+
+		// Spent before asking the circuit breaker too, for a request refused by the budget not to take a probe
+		if err := c.callBudget.Spend(); err != nil {
+			return err
+		}
+
+		// No network call is made while the API is considered down
+		if err := c.CircuitBreaker.Allow(); err != nil {
+			return err
+		}
+
+		c.RequestCounter.Increment(method, path)
+
+		attemptCtx := ctx
+		if timeout := c.operationTimeout(ctx, method); timeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
 
-			// Execute the same call on ca.api.ovh.com and ignore the potential error, we will return the original one
-			err2 = client.CallAPIWithContext(ctx, method, path, reqBody, result, queryParams, headers, needAuth)
-			if err2 == nil {
-				// OK on the canadian API, our job is done
-				return nil
+		// The request is built again on every attempt, to be signed with the current time
+		req, err := c.NewRequestWithContext(attemptCtx, method, path, reqBody, queryParams, headers, needAuth)
+		if err == nil {
+			req = req.WithContext(attemptCtx)
+
+			var response *http.Response
+			start := c.now()
+			response, err = c.Do(req)
+			if c.metrics != nil {
+				statusCode := 0
+				if err == nil {
+					statusCode = response.StatusCode
+				}
+				c.metrics.ObserveAPICall(method, path, needAuth, statusCode, c.now().Sub(start))
+			}
+			if err == nil {
+				captureResponseHeader(ctx, response)
+				err = c.UnmarshalResponse(response, result)
 			}
 		}
-	}
 
-	return err
+		c.CircuitBreaker.Record(err)
+		return err
+	})
 }
 
 // UnmarshalResponse checks the response and unmarshals it into the response
 // type if needed Helper function, called from CallAPI
 func (c *Client) UnmarshalResponse(response *http.Response, result interface{}) error {
-	// Read all the response body
-	defer response.Body.Close()
-	body, err := ioutil.ReadAll(response.Body)
+	maxBytes := c.MaxResponseBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxResponseBodyBytes
+	}
+
+	reader, err := responseBody(response)
 	if err != nil {
 		return err
 	}
 
+	// Read all the response body, one extra byte allowing to detect oversized bodies
+	defer reader.Close()
+	body, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return err
+	}
+
+	if int64(len(body)) > maxBytes {
+		return ErrResponseTooLarge
+	}
+
 	// < 200 && >= 300 : API error
 	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
 		apiError := &APIError{Code: response.StatusCode}
 		if err = json.Unmarshal(body, apiError); err != nil {
 			apiError.Message = string(body)
 		}
-		apiError.QueryID = response.Header.Get("X-Ovh-QueryID")
+		apiError.QueryID = queryID(response)
+		apiError.RequestID = requestID(response)
+		apiError.RetryAfter = parseRetryAfter(response.Header.Get("Retry-After"), c.now())
 
 		return apiError
 	}
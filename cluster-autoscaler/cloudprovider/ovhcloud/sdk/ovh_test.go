@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient creates a client calling a local test server served by the given handler.
+// An OpenStack token is set so requests are not signed and do not need the server time.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "key", "secret", "consumer_key")
+	if err != nil {
+		assert.FailNow(t, "failed to create client", err)
+	}
+	client.openStackToken = "token"
+
+	return client
+}
+
+func TestClient_UnmarshalResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/small", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "small"}`))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"`))
+		_, _ = w.Write(bytes.Repeat([]byte("a"), 15<<20))
+		_, _ = w.Write([]byte(`"`))
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check response below limit is unmarshalled", func(t *testing.T) {
+		result := struct {
+			ID string `json:"id"`
+		}{}
+
+		err := client.CallAPIWithContext(context.Background(), "GET", "/small", nil, &result, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "small", result.ID)
+	})
+
+	t.Run("check response above default limit is rejected", func(t *testing.T) {
+		result := ""
+
+		err := client.CallAPIWithContext(context.Background(), "GET", "/large", nil, &result, nil, nil, true)
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+
+	t.Run("check limit can be raised", func(t *testing.T) {
+		client.MaxResponseBodyBytes = 20 << 20
+		defer func() { client.MaxResponseBodyBytes = 0 }()
+
+		result := ""
+
+		err := client.CallAPIWithContext(context.Background(), "GET", "/large", nil, &result, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Len(t, result, 15<<20)
+	})
+}
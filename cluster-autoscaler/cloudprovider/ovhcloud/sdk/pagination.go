@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// PageTokenParameter is the query parameter requesting the next page of a list
+const PageTokenParameter = "page_token"
+
+// PagedResponse defines a page of a list returned by the API
+type PagedResponse struct {
+	Items     []json.RawMessage `json:"items"`
+	NextToken string            `json:"nextToken"`
+}
+
+// fetchAll lists every item of a paginated endpoint, requesting its pages until the last one, and unmarshals them into result.
+// The query parameters, if any, are sent with every page request.
+// Endpoints answering with a bare list are not paginated, their list is complete.
+func (c *Client) fetchAll(ctx context.Context, path string, params url.Values, result interface{}) error {
+	ctx = withListOperation(ctx)
+	items := make([]json.RawMessage, 0)
+
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = append([]string(nil), values...)
+	}
+
+	for {
+		var body json.RawMessage
+		err := c.CallAPIWithContext(
+			ctx,
+			"GET",
+			path,
+			nil,
+			&body,
+			query,
+			nil,
+			true,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Nothing more to list
+		if len(body) == 0 {
+			break
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			list := make([]json.RawMessage, 0)
+			if err := json.Unmarshal(body, &list); err != nil {
+				return fmt.Errorf("failed to unmarshal list: %w", err)
+			}
+
+			items = append(items, list...)
+			break
+		}
+
+		page := PagedResponse{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to unmarshal page: %w", err)
+		}
+
+		items = append(items, page.Items...)
+		if page.NextToken == "" {
+			break
+		}
+
+		// Protects against endpoints returning again the same page
+		if page.NextToken == query.Get(PageTokenParameter) {
+			return fmt.Errorf("page token %s returned twice", page.NextToken)
+		}
+
+		query.Set(PageTokenParameter, page.NextToken)
+	}
+
+	all, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(all, result)
+}
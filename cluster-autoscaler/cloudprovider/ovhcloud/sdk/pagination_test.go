@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListPaginated(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"items": [{"id": "pool-1", "name": "workers"}, {"id": "pool-2"}], "nextToken": "page-2"}`,
+		"page-2": `{"items": [{"id": "pool-3"}], "nextToken": "page-3"}`,
+		"page-3": `{"items": [], "nextToken": ""}`,
+	}
+	requested := make([]string, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get(PageTokenParameter)
+		requested = append(requested, token)
+		_, _ = w.Write([]byte(pages[token]))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get(PageTokenParameter) {
+		case "":
+			_, _ = w.Write([]byte(`{"items": [{"id": "node-1"}], "nextToken": "next"}`))
+		case "next":
+			_, _ = w.Write([]byte(`{"items": [{"id": "node-2"}]}`))
+		}
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-2/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "node-3"}]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/pool-3/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [{"id": "node-4"}], "nextToken": "same"}`))
+	})
+
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	t.Run("check every page of node pools is listed", func(t *testing.T) {
+		pools, err := client.ListNodePools(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"", "page-2", "page-3"}, requested)
+
+		ids := make([]string, 0)
+		for _, pool := range pools {
+			ids = append(ids, pool.ID)
+		}
+		assert.Equal(t, []string{"pool-1", "pool-2", "pool-3"}, ids)
+	})
+
+	t.Run("check every page of nodes is listed", func(t *testing.T) {
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "pool-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []Node{{ID: "node-1", NodePoolName: "workers"}, {ID: "node-2", NodePoolName: "workers"}}, nodes)
+	})
+
+	t.Run("check lists without pagination are supported", func(t *testing.T) {
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "pool-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []Node{{ID: "node-3"}}, nodes)
+	})
+
+	t.Run("check a repeated page token fails", func(t *testing.T) {
+		_, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "pool-3")
+		assert.ErrorContains(t, err, "page token same returned twice")
+	})
+
+	t.Run("check cancelled contexts stop the listing", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		// The nodes of the node pool are cached by the previous listing
+		client.InvalidateNodeCache("clusterID", "pool-1")
+
+		_, err := client.ListNodePoolNodes(cancelled, "projectID", "clusterID", "pool-1")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestClient_ListNodePoolNodesWithOpts(t *testing.T) {
+	queries := make([]string, 0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "poolID", "name": "pool"}`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		switch r.URL.Query().Get(PageTokenParameter) {
+		case "":
+			_, _ = w.Write([]byte(`{"items": [{"id": "node-1", "status": "READY"}], "nextToken": "next"}`))
+		case "next":
+			_, _ = w.Write([]byte(`{"items": [{"id": "node-2", "status": "INSTALLING"}]}`))
+		}
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	t.Run("check nil options send no parameter", func(t *testing.T) {
+		queries = queries[:0]
+
+		nodes, err := client.ListNodePoolNodesWithOpts(ctx, "projectID", "clusterID", "poolID", nil)
+		assert.NoError(t, err)
+		assert.Len(t, nodes, 2)
+		assert.Equal(t, "pool", nodes[0].NodePoolName, "the name of a node pool not cached is read")
+		assert.Equal(t, []string{"", "page_token=next"}, queries)
+	})
+
+	t.Run("check fields are sent on every page", func(t *testing.T) {
+		queries = queries[:0]
+
+		_, err := client.ListNodePoolNodesWithOpts(ctx, "projectID", "clusterID", "poolID", &NodeListOpts{Fields: []string{"id", "status"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"fields=id%2Cstatus", "fields=id%2Cstatus&page_token=next"}, queries)
+	})
+
+	t.Run("check status filter is sent and applied", func(t *testing.T) {
+		queries = queries[:0]
+
+		nodes, err := client.ListNodePoolNodesWithOpts(ctx, "projectID", "clusterID", "poolID", &NodeListOpts{Fields: []string{"id"}, StatusFilter: "READY"})
+		assert.NoError(t, err)
+		assert.Equal(t, []Node{{ID: "node-1", Status: "READY", NodePoolName: "pool"}}, nodes)
+		assert.Equal(t, "fields=id%2Cstatus&status=READY", queries[0])
+	})
+
+	t.Run("check nodes are listed without name when the node pool cannot be read", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/nodes", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"id": "node-1"}]`))
+		})
+		client := newTestClient(t, mux)
+
+		nodes, err := client.ListNodePoolNodes(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+		assert.Equal(t, []Node{{ID: "node-1"}}, nodes)
+	})
+}
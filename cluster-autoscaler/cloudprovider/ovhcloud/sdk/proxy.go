@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// ProxyDialer routes the client HTTP requests through a proxy, except for the hosts matching a no-proxy pattern
+type ProxyDialer struct {
+	proxyURL *url.URL
+
+	// noProxy holds host patterns: an exact host, a domain suffix such as ".example.com"
+	// or "*.example.com", or "*" to never use the proxy
+	noProxy []string
+}
+
+// Proxy returns the proxy URL to use for the given request, nil to connect directly.
+// It is meant to be used as an http.Transport Proxy function.
+func (d *ProxyDialer) Proxy(req *http.Request) (*url.URL, error) {
+	if d.proxyURL == nil || d.bypassed(req.URL.Hostname()) {
+		return nil, nil
+	}
+
+	return d.proxyURL, nil
+}
+
+// String describes the proxy with its credentials redacted, so it can be logged
+func (d *ProxyDialer) String() string {
+	if d.proxyURL == nil {
+		return "no proxy"
+	}
+
+	if len(d.noProxy) == 0 {
+		return d.proxyURL.Redacted()
+	}
+
+	return fmt.Sprintf("%s (except %s)", d.proxyURL.Redacted(), strings.Join(d.noProxy, ", "))
+}
+
+// bypassed tells whether the host matches a no-proxy pattern
+func (d *ProxyDialer) bypassed(host string) bool {
+	host = strings.ToLower(host)
+
+	for _, pattern := range d.noProxy {
+		pattern = strings.ToLower(strings.TrimPrefix(pattern, "*"))
+
+		switch {
+		case pattern == "":
+			// "*" matches every host
+			return true
+		case strings.HasPrefix(pattern, "."):
+			if strings.HasSuffix(host, pattern) || host == pattern[1:] {
+				return true
+			}
+		case host == pattern:
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithHTTPProxy routes the client HTTP requests through the given proxy.
+// Credentials of the proxy URL are redacted from the logs.
+func WithHTTPProxy(proxyURL string) ClientOption {
+	return func(client *Client) error {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("proxy URL %s must include a scheme and a host", parsed.Redacted())
+		}
+
+		client.proxyDialer().proxyURL = parsed
+		client.installProxy()
+
+		return nil
+	}
+}
+
+// WithNoProxy connects directly to the hosts matching one of the given patterns, without going through the proxy
+func WithNoProxy(patterns []string) ClientOption {
+	return func(client *Client) error {
+		client.proxyDialer().noProxy = append([]string(nil), patterns...)
+		client.installProxy()
+
+		return nil
+	}
+}
+
+// proxyDialer returns the proxy dialer of the client, creating it if needed
+func (c *Client) proxyDialer() *ProxyDialer {
+	if c.proxy == nil {
+		c.proxy = &ProxyDialer{}
+	}
+
+	return c.proxy
+}
+
+// installProxy sets the proxy dialer on the client HTTP transport
+func (c *Client) installProxy() {
+	if c.Client == nil {
+		c.Client = &http.Client{}
+	}
+
+	transport, ok := c.Client.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		c.Client.Transport = transport
+	}
+	transport.Proxy = c.proxy.Proxy
+
+	klog.V(2).Infof("Routing API requests through proxy %s", c.proxy)
+}
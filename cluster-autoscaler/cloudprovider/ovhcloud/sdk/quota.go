@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// Quota defines the resources limits of a project in a region
+type Quota struct {
+	Region   string         `json:"region"`
+	Instance *InstanceQuota `json:"instance"`
+}
+
+// InstanceQuota defines the compute limits and usage of a project in a region.
+// RAM values are expressed in MB.
+type InstanceQuota struct {
+	MaxCores     int `json:"maxCores"`
+	MaxInstances int `json:"maxInstances"`
+	MaxRAM       int `json:"maxRam"`
+
+	UsedCores     int `json:"usedCores"`
+	UsedInstances int `json:"usedInstances"`
+	UsedRAM       int `json:"usedRAM"`
+}
+
+// ListQuotas allows to list the quotas of a project in every region
+func (c *Client) ListQuotas(ctx context.Context, projectID string) ([]Quota, error) {
+	quotas := make([]Quota, 0)
+
+	return quotas, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/quota", projectID),
+		nil,
+		&quotas,
+		nil,
+		nil,
+		true,
+	)
+}
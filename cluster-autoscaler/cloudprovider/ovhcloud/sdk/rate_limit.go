@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter delays the requests of a client
+type RateLimiter interface {
+	// Wait blocks until a request may be sent, or the context is done
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests, allowing bursts of burstSize requests
+func WithRateLimit(requestsPerSecond float64, burstSize int) ClientOption {
+	return func(client *Client) error {
+		if requestsPerSecond <= 0 || burstSize < 1 {
+			return fmt.Errorf("rate limit should allow at least 1 request, got %v per second with bursts of %d", requestsPerSecond, burstSize)
+		}
+
+		client.rateLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burstSize)
+		return nil
+	}
+}
+
+// WithRateLimiter delays the requests of the client with a custom rate limiter
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(client *Client) error {
+		client.rateLimiter = limiter
+		return nil
+	}
+}
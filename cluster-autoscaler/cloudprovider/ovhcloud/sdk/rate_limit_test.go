@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CallAPIWithRateLimit(t *testing.T) {
+	const (
+		calls             = 8
+		requestsPerSecond = 20.0
+		burstSize         = 3
+	)
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	assert.NoError(t, WithRateLimit(requestsPerSecond, burstSize)(client))
+
+	start := time.Now()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true))
+		}()
+	}
+	wg.Wait()
+
+	minimum := time.Duration(float64(calls-burstSize) / requestsPerSecond * float64(time.Second))
+	assert.GreaterOrEqual(t, time.Since(start), minimum)
+
+	t.Run("check waiting stops with the context", func(t *testing.T) {
+		assert.NoError(t, WithRateLimit(0.001, 1)(client))
+		assert.NoError(t, client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("check invalid limits are rejected", func(t *testing.T) {
+		assert.Error(t, WithRateLimit(0, 1)(client))
+		assert.Error(t, WithRateLimit(1, 0)(client))
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+
+	err := client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+
+	var apiError *APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, 2*time.Second, apiError.RetryAfter)
+
+	// The retry waits for the delay requested by the API
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+	assert.Equal(t, 2*time.Second, policy.delayAfter(2, err))
+	assert.LessOrEqual(t, policy.delayAfter(2, &APIError{Code: http.StatusServiceUnavailable}), time.Millisecond)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader is the header holding the ID of every request, generated unless given by the caller
+	RequestIDHeader = "X-Request-ID"
+
+	// VKEQueryIDHeader is the header holding the ID given by the API to a query, when X-Ovh-QueryID is not set
+	VKEQueryIDHeader = "X-VKE-QueryID"
+)
+
+// newRequestID generates a random UUID (version 4) identifying a request
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// queryID returns the ID given by the API to the query of a response
+func queryID(response *http.Response) string {
+	if id := response.Header.Get("X-Ovh-QueryID"); id != "" {
+		return id
+	}
+
+	return response.Header.Get(VKEQueryIDHeader)
+}
+
+// requestID returns the ID sent with the request of a response
+func requestID(response *http.Response) string {
+	if response.Request == nil {
+		return ""
+	}
+
+	return response.Request.Header.Get(RequestIDHeader)
+}
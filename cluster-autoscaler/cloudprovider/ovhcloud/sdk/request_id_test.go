@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RequestID(t *testing.T) {
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	received := []string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+		w.Header().Set(VKEQueryIDHeader, "EU.ext-1.query")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message": "internal error"}`))
+	})
+	client := newTestClient(t, mux)
+
+	t.Run("check every request is sent a new ID", func(t *testing.T) {
+		received = received[:0]
+
+		assert.NoError(t, client.CallAPI("GET", "/ok", nil, nil, nil, true))
+		assert.NoError(t, client.CallAPI("GET", "/ok", nil, nil, nil, true))
+
+		assert.Len(t, received, 2)
+		assert.Regexp(t, uuidV4, received[0])
+		assert.Regexp(t, uuidV4, received[1])
+		assert.NotEqual(t, received[0], received[1])
+	})
+
+	t.Run("check given request ID is propagated", func(t *testing.T) {
+		received = received[:0]
+
+		err := client.CallAPIWithContext(context.Background(), "GET", "/ok", nil, nil, nil, map[string]interface{}{RequestIDHeader: "upstream-id"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"upstream-id"}, received)
+	})
+
+	t.Run("check API errors carry the request and query IDs", func(t *testing.T) {
+		received = received[:0]
+
+		err := client.CallAPI("GET", "/fail", nil, nil, nil, true)
+
+		var apiError *APIError
+		assert.True(t, errors.As(err, &apiError))
+		assert.Equal(t, received[0], apiError.RequestID)
+		assert.Equal(t, "EU.ext-1.query", apiError.QueryID)
+	})
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryPolicy defines how API calls failing with a transient error are attempted again.
+// Delays grow exponentially from BaseDelay up to MaxDelay, with a random jitter.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// WithRetry makes the client attempt again the calls failing with a 429, 500, 502, 503 or 504 status,
+// following the given policy. Calls are attempted once by default.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(client *Client) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("retry policy should allow at least 1 attempt, got %d", policy.MaxAttempts)
+		}
+
+		client.retryPolicy = &policy
+		return nil
+	}
+}
+
+// RetryError is returned when all the attempts of an API call failed.
+type RetryError struct {
+	// Errors holds the error of every attempt, in order
+	Errors []error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	attempts := make([]string, 0, len(e.Errors))
+	for i, err := range e.Errors {
+		attempts = append(attempts, fmt.Sprintf("attempt %d: %v", i+1, err))
+	}
+
+	return fmt.Sprintf("failed after %d attempt(s): %s", len(e.Errors), strings.Join(attempts, "; "))
+}
+
+// Unwrap returns the errors of the attempts, the last one first.
+func (e *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for i := len(e.Errors) - 1; i >= 0; i-- {
+		errs = append(errs, e.Errors[i])
+	}
+
+	return errs
+}
+
+// isRetryableError tells whether an API call failed with a transient error
+func isRetryableError(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+
+	switch apiError.Code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay returns the jittered time to wait before the given attempt, the first retry being attempt 2
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 2)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait at least half of the delay
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// delayAfter returns the time to wait before the given attempt, following the failure of the previous one.
+// The delay requested by the API with a Retry-After header is respected.
+func (p *RetryPolicy) delayAfter(attempt int, err error) time.Duration {
+	delay := p.delay(attempt)
+
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.RetryAfter > delay {
+		return apiError.RetryAfter
+	}
+
+	return delay
+}
+
+// withRetry runs call until it succeeds, fails with a non transient error, or the attempts of the policy are exhausted.
+// The wait between two attempts is interrupted when the context is done.
+func (c *Client) withRetry(ctx context.Context, call func() error) error {
+	err := call()
+	if c.retryPolicy == nil || err == nil || !isRetryableError(err) {
+		return err
+	}
+
+	errs := []error{err}
+	for attempt := 2; attempt <= c.retryPolicy.MaxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return &RetryError{Errors: append(errs, ctx.Err())}
+		case <-c.clock.After(c.retryPolicy.delayAfter(attempt, err)):
+		}
+
+		err = call()
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+		if !isRetryableError(err) {
+			break
+		}
+	}
+
+	return &RetryError{Errors: errs}
+}
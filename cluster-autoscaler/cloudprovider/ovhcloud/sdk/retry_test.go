@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// statusSequence answers with the given status codes in order, then with 200
+type statusSequence struct {
+	codes    []int
+	attempts int
+	mutex    sync.Mutex
+}
+
+func (s *statusSequence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.attempts++
+	if s.attempts <= len(s.codes) {
+		w.WriteHeader(s.codes[s.attempts-1])
+		_, _ = w.Write([]byte(`{"message": "unavailable"}`))
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"id": "poolID"}`))
+}
+
+func newRetryTestClient(t *testing.T, codes ...int) (*Client, *statusSequence) {
+	sequence := &statusSequence{codes: codes}
+	client := newTestClient(t, sequence)
+	client.retryPolicy = &RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+	return client, sequence
+}
+
+func TestClient_CallAPIWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check transient errors are retried", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusTooManyRequests)
+
+		pool := &NodePool{}
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, pool, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "poolID", pool.ID)
+		assert.Equal(t, 4, sequence.attempts)
+		assert.Equal(t, int64(4), client.RequestCounter.Total())
+	})
+
+	t.Run("check errors of every attempt are returned", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusInternalServerError, http.StatusGatewayTimeout)
+
+		err := client.CallAPIWithContext(ctx, "PUT", "/pool", nil, nil, nil, nil, true)
+		assert.Equal(t, 4, sequence.attempts)
+
+		var retryError *RetryError
+		assert.ErrorAs(t, err, &retryError)
+		assert.Len(t, retryError.Errors, 4)
+		assert.ErrorContains(t, err, `attempt 1: Error 503: "unavailable"`)
+		assert.ErrorContains(t, err, `attempt 4: Error 504: "unavailable"`)
+
+		// The last error is reported first
+		var apiError *APIError
+		assert.ErrorAs(t, err, &apiError)
+		assert.Equal(t, http.StatusGatewayTimeout, apiError.Code)
+	})
+
+	t.Run("check other errors are not retried", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusNotFound)
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.Equal(t, 1, sequence.attempts)
+
+		var apiError *APIError
+		assert.ErrorAs(t, err, &apiError)
+		assert.Equal(t, http.StatusNotFound, apiError.Code)
+
+		client, sequence = newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusBadRequest)
+
+		err = client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.Equal(t, 2, sequence.attempts)
+		assert.ErrorContains(t, err, "attempt 2: Error 400")
+	})
+
+	t.Run("check calls are attempted once without policy", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusServiceUnavailable)
+		client.retryPolicy = nil
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.Error(t, err)
+		assert.Equal(t, 1, sequence.attempts)
+	})
+
+	t.Run("check context deadline is respected", func(t *testing.T) {
+		client, sequence := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		client.retryPolicy.BaseDelay = time.Hour
+		client.retryPolicy.MaxDelay = time.Hour
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		err := client.CallAPIWithContext(ctx, "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, sequence.attempts)
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	client, err := NewClient(OvhEU, "key", "secret", "consumer_key", WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}))
+	assert.NoError(t, err)
+	assert.Equal(t, &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}, client.retryPolicy)
+
+	_, err = NewClient(OvhEU, "key", "secret", "consumer_key", WithRetry(RetryPolicy{}))
+	assert.Error(t, err)
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	for attempt, max := range map[int]time.Duration{2: time.Second, 3: 2 * time.Second, 4: 4 * time.Second, 5: 5 * time.Second, 9: 5 * time.Second} {
+		delay := policy.delay(attempt)
+		assert.GreaterOrEqual(t, delay, max/2, attempt)
+		assert.LessOrEqual(t, delay, max, attempt)
+	}
+
+	assert.False(t, isRetryableError(errors.New("connection reset")))
+}
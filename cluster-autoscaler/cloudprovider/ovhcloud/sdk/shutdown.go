@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrClientShutdown is returned by the calls made once the client is shut down
+var ErrClientShutdown = errors.New("client is shut down")
+
+// AbandonedCallsError is returned when the client is shut down before its in-flight calls complete
+type AbandonedCallsError struct {
+	// Count is the number of calls still in flight, whose outcome is unknown
+	Count int64
+
+	// Err is the error of the context given to Shutdown
+	Err error
+}
+
+func (e *AbandonedCallsError) Error() string {
+	return fmt.Sprintf("abandoned %d in-flight API call(s): %v", e.Count, e.Err)
+}
+
+func (e *AbandonedCallsError) Unwrap() error {
+	return e.Err
+}
+
+// Shutdown stops the client from making new calls and stops the background renewal of its token,
+// then waits for the in-flight calls to complete. When the context is done first, an AbandonedCallsError
+// gives the number of calls left in flight, so that their changes can be reconciled manually.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.inFlightMutex.Lock()
+	c.shutdown = true
+	c.inFlightMutex.Unlock()
+
+	c.StopTokenRenewal()
+
+	done := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return &AbandonedCallsError{Count: atomic.LoadInt64(&c.inFlightCount), Err: ctx.Err()}
+	}
+}
+
+// startCall tracks a new in-flight call, unless the client is shut down
+func (c *Client) startCall() error {
+	c.inFlightMutex.Lock()
+	defer c.inFlightMutex.Unlock()
+
+	if c.shutdown {
+		return ErrClientShutdown
+	}
+
+	c.inFlight.Add(1)
+	atomic.AddInt64(&c.inFlightCount, 1)
+
+	return nil
+}
+
+// endCall stops tracking an in-flight call once it completed
+func (c *Client) endCall() {
+	atomic.AddInt64(&c.inFlightCount, -1)
+	c.inFlight.Done()
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Shutdown(t *testing.T) {
+	t.Run("check shutdown stops the token renewal", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		provider := newFakeTokenProvider(clock)
+
+		client, err := NewClient("http://localhost", "key", "secret", "consumer_key", WithClock(clock), WithTokenProvider(provider, 30*time.Minute))
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+
+		assert.NoError(t, client.Shutdown(context.Background()))
+
+		clock.Advance(2 * time.Hour)
+		assert.Never(t, func() bool { return len(provider.renewed) > 0 }, 50*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("check shutdown waits for in-flight calls", func(t *testing.T) {
+		started := make(chan struct{}, 3)
+		release := make(chan struct{})
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-release
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, client.CallAPIWithContext(context.Background(), "GET", "/slow", nil, nil, nil, nil, true))
+			}()
+		}
+		for i := 0; i < 3; i++ {
+			<-started
+		}
+
+		shutdown := make(chan error)
+		go func() {
+			shutdown <- client.Shutdown(context.Background())
+		}()
+
+		select {
+		case <-shutdown:
+			assert.FailNow(t, "shutdown should wait for the in-flight calls")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		assert.NoError(t, <-shutdown)
+		wg.Wait()
+
+		err := client.CallAPIWithContext(context.Background(), "GET", "/slow", nil, nil, nil, nil, true)
+		assert.ErrorIs(t, err, ErrClientShutdown)
+	})
+
+	t.Run("check abandoned calls are counted when the context is done", func(t *testing.T) {
+		started := make(chan struct{}, 2)
+		release := make(chan struct{})
+		defer close(release)
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-release
+		}))
+
+		for i := 0; i < 2; i++ {
+			go func() {
+				_ = client.CallAPIWithContext(context.Background(), "GET", "/slow", nil, nil, nil, nil, true)
+			}()
+		}
+		for i := 0; i < 2; i++ {
+			<-started
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := client.Shutdown(ctx)
+		var abandoned *AbandonedCallsError
+		assert.ErrorAs(t, err, &abandoned)
+		assert.Equal(t, int64(2), abandoned.Count)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// SigningVersion selects the algorithm used to sign the authenticated requests
+type SigningVersion int
+
+const (
+	// SigningVersionSHA1 sends the SHA1 hash of the signing string in the X-Ovh-Signature header
+	SigningVersionSHA1 SigningVersion = iota
+
+	// SigningVersionHMACSHA256 sends the HMAC-SHA256 of the signing string, keyed on the
+	// application secret, in the X-VKE-Signature header
+	SigningVersionHMACSHA256
+)
+
+// OvhSignatureHeader holds the SHA1 signature of the requests
+const OvhSignatureHeader = "X-Ovh-Signature"
+
+// VKESignatureHeader holds the HMAC-SHA256 signature of the requests
+const VKESignatureHeader = "X-VKE-Signature"
+
+// signingString returns the string signed for a request, its fields being joined by '+'
+func (c *Client) signingString(method, path string, body []byte, timestamp int64) string {
+	return fmt.Sprintf("%s+%s+%s+%s%s+%s+%d",
+		c.AppSecret,
+		c.ConsumerKey,
+		method,
+		getEndpointForSignature(c),
+		path,
+		body,
+		timestamp,
+	)
+}
+
+// sign returns the header and the value of the signature of a request
+func (c *Client) sign(method, path string, body []byte, timestamp int64) (string, string) {
+	signingString := c.signingString(method, path, body, timestamp)
+
+	if c.SigningVersion == SigningVersionHMACSHA256 {
+		return VKESignatureHeader, SignHMACSHA256(c.AppSecret, signingString)
+	}
+
+	h := sha1.New()
+	h.Write([]byte(signingString))
+	return OvhSignatureHeader, fmt.Sprintf("$1$%x", h.Sum(nil))
+}
+
+// SignHMACSHA256 returns the HMAC-SHA256 signature of a signing string, as sent in VKESignatureHeader
+func SignHMACSHA256(secret, signingString string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingString))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
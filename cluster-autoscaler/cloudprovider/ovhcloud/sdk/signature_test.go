@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_sign(t *testing.T) {
+	client, err := NewClient(OvhEU, "key", "secret", "consumer_key")
+	assert.NoError(t, err)
+
+	t.Run("check requests are signed with SHA1 by default", func(t *testing.T) {
+		header, value := client.sign("POST", "/cloud/project", []byte(`{"name":"pool"}`), 1677657600)
+		assert.Equal(t, OvhSignatureHeader, header)
+		assert.Equal(t, "$1$b415c698d9456aafbb4d56e9e12baae0720d00ca", value)
+	})
+
+	t.Run("check requests are signed with HMAC-SHA256 when opted in", func(t *testing.T) {
+		client.SigningVersion = SigningVersionHMACSHA256
+
+		header, value := client.sign("POST", "/cloud/project", []byte(`{"name":"pool"}`), 1677657600)
+		assert.Equal(t, VKESignatureHeader, header)
+		assert.Equal(t, "sha256=f4b5d9f07225a9eb19d3104b04b8a79c2d77a59f3ebfa0912f7498125a4a5de9", value)
+	})
+}
+
+func TestClient_HMACSignatureRoundTrip(t *testing.T) {
+	serverTime := time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+	headers := make(chan http.Header, 1)
+	bodies := make(chan string, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/time", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%d", serverTime.Unix())
+	})
+	mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		headers <- r.Header
+		bodies <- string(body)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "key", "secret", "consumer_key", WithClock(NewFakeClock(serverTime)))
+	assert.NoError(t, err)
+	client.SigningVersion = SigningVersionHMACSHA256
+
+	err = client.CallAPIWithContext(context.Background(), "PUT", "/signed", map[string]string{"name": "pool"}, nil, url.Values{"dryRun": []string{"true"}}, nil, true)
+	assert.NoError(t, err)
+
+	header := <-headers
+	assert.Empty(t, header.Get(OvhSignatureHeader))
+
+	// The server rebuilds the signing string from the received request
+	signingString := fmt.Sprintf("secret+consumer_key+PUT+%s/signed?dryRun=true+%s+%s", server.URL, <-bodies, header.Get("X-Ovh-Timestamp"))
+	assert.Equal(t, SignHMACSHA256("secret", signingString), header.Get(VKESignatureHeader))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SpotInterruption defines the notice sent before a spot node of a node pool is reclaimed
+type SpotInterruption struct {
+	NodeID     string `json:"nodeId"`
+	NodeName   string `json:"nodeName"`
+	InstanceID string `json:"instanceId"`
+
+	NoticeTime      time.Time `json:"noticeTime"`
+	TerminationTime time.Time `json:"terminationTime"`
+}
+
+// ListSpotInterruptions allows to list the pending interruption notices of the spot nodes of a node pool
+func (c *Client) ListSpotInterruptions(ctx context.Context, projectID string, clusterID string, poolID string) ([]SpotInterruption, error) {
+	interruptions := make([]SpotInterruption, 0)
+
+	return interruptions, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/spot-interruptions", projectID, clusterID, poolID),
+		nil,
+		&interruptions,
+		nil,
+		nil,
+		true,
+	)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshTunnelDialTimeout limits the time spent connecting to the bastion
+const sshTunnelDialTimeout = 30 * time.Second
+
+// tunnelDialer opens connections from the bastion, implemented by *ssh.Client
+type tunnelDialer interface {
+	Dial(network, addr string) (net.Conn, error)
+	Close() error
+}
+
+// sshTunnel forwards connections through an SSH bastion, reconnecting to it when needed
+type sshTunnel struct {
+	connect    func() (tunnelDialer, error)
+	targetAddr string
+
+	dialer tunnelDialer
+	mutex  sync.Mutex
+}
+
+// newSSHTunnel creates a tunnel forwarding connections to targetAddr.
+// The bastion connection is only opened on the first dial.
+func newSSHTunnel(connect func() (tunnelDialer, error), targetAddr string) *sshTunnel {
+	return &sshTunnel{
+		connect:    connect,
+		targetAddr: targetAddr,
+	}
+}
+
+// WithSSHTunnel forwards the API connections to targetAddr through the given bastion,
+// for environments where the API is not directly reachable. The bastion host key is
+// verified against the known_hosts file at knownHostsPath.
+func WithSSHTunnel(bastionAddr, bastionUser, privateKeyPEM, knownHostsPath string, targetAddr string) ClientOption {
+	return func(client *Client) error {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKeyPEM))
+		if err != nil {
+			return fmt.Errorf("failed to parse bastion private key: %w", err)
+		}
+
+		if knownHostsPath == "" {
+			return fmt.Errorf("a known_hosts file is needed to verify the bastion host key")
+		}
+
+		hostKeyCallback, err := knownhosts.New(knownHostsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read bastion known hosts: %w", err)
+		}
+
+		config := &ssh.ClientConfig{
+			User:            bastionUser,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         sshTunnelDialTimeout,
+		}
+
+		tunnel := newSSHTunnel(func() (tunnelDialer, error) {
+			return ssh.Dial("tcp", bastionAddr, config)
+		}, targetAddr)
+
+		client.installTunnel(tunnel)
+
+		return nil
+	}
+}
+
+// installTunnel routes the client HTTP connections through the tunnel
+func (c *Client) installTunnel(tunnel *sshTunnel) {
+	if c.Client == nil {
+		c.Client = &http.Client{}
+	}
+
+	// Keep the connection pool settings of the current transport
+	base, ok := c.Client.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+
+	transport := base.Clone()
+	transport.Proxy = nil
+	transport.DialContext = tunnel.DialContext
+
+	c.Client.Transport = transport
+	c.tunnel = tunnel
+}
+
+// CloseTunnel closes the SSH connection to the bastion, if any
+func (c *Client) CloseTunnel() error {
+	if c.tunnel == nil {
+		return nil
+	}
+
+	return c.tunnel.Close()
+}
+
+// DialContext opens a connection to the target through the bastion.
+// The bastion connection is opened again once if it has been lost.
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	target := t.targetAddr
+	if target == "" {
+		target = addr
+	}
+
+	dialer, err := t.getDialer()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialer.Dial(network, target)
+	if err == nil {
+		return conn, nil
+	}
+
+	// The SSH connection may have been closed by the bastion, reconnect and retry
+	t.resetDialer(dialer)
+
+	dialer, err = t.getDialer()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err = dialer.Dial(network, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s through bastion: %w", target, err)
+	}
+
+	return conn, nil
+}
+
+// Close closes the SSH connection to the bastion
+func (t *sshTunnel) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.dialer == nil {
+		return nil
+	}
+
+	err := t.dialer.Close()
+	t.dialer = nil
+
+	return err
+}
+
+// getDialer returns the current bastion connection, opening it if needed
+func (t *sshTunnel) getDialer() (tunnelDialer, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.dialer != nil {
+		return t.dialer, nil
+	}
+
+	dialer, err := t.connect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bastion: %w", err)
+	}
+	t.dialer = dialer
+
+	return dialer, nil
+}
+
+// resetDialer drops the given bastion connection unless it has already been replaced
+func (t *sshTunnel) resetDialer(dialer tunnelDialer) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.dialer != dialer {
+		return
+	}
+
+	_ = t.dialer.Close()
+	t.dialer = nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// pipeListener serves the server ends of net.Pipe connections
+type pipeListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return &net.TCPAddr{}
+}
+
+// fakeBastion simulates an SSH connection, forwarding connections to the listener through pipes
+type fakeBastion struct {
+	listener *pipeListener
+	targets  []string
+	closed   bool
+	broken   bool
+	mutex    sync.Mutex
+}
+
+func (b *fakeBastion) Dial(network, addr string) (net.Conn, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed || b.broken {
+		return nil, errors.New("ssh: disconnected")
+	}
+	b.targets = append(b.targets, addr)
+
+	client, server := net.Pipe()
+	b.listener.conns <- server
+
+	return client, nil
+}
+
+func (b *fakeBastion) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+	return nil
+}
+
+func newTunnelTestClient(t *testing.T) (*Client, *[]*fakeBastion) {
+	listener := &pipeListener{conns: make(chan net.Conn), done: make(chan struct{})}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "clusterID"}`))
+	})
+
+	server := &http.Server{Handler: mux}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	client, err := NewClient("http://vke.internal", "key", "secret", "consumer_key")
+	if err != nil {
+		assert.FailNow(t, "failed to create client", err)
+	}
+	client.openStackToken = "token"
+
+	bastions := make([]*fakeBastion, 0)
+	client.installTunnel(newSSHTunnel(func() (tunnelDialer, error) {
+		bastion := &fakeBastion{listener: listener}
+		bastions = append(bastions, bastion)
+		return bastion, nil
+	}, "10.0.0.1:443"))
+
+	return client, &bastions
+}
+
+func TestClient_SSHTunnel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check requests go through the tunnel", func(t *testing.T) {
+		client, bastions := newTunnelTestClient(t)
+
+		cluster, err := client.GetCluster(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+		assert.Equal(t, "clusterID", cluster.ID)
+
+		assert.Len(t, *bastions, 1)
+		assert.Equal(t, []string{"10.0.0.1:443"}, (*bastions)[0].targets)
+	})
+
+	t.Run("check tunnel reconnects after disconnection", func(t *testing.T) {
+		client, bastions := newTunnelTestClient(t)
+
+		_, err := client.GetCluster(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+
+		client.Client.CloseIdleConnections()
+		(*bastions)[0].broken = true
+
+		_, err = client.GetCluster(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+
+		assert.Len(t, *bastions, 2)
+		assert.True(t, (*bastions)[0].closed)
+	})
+
+	t.Run("check tunnel is closed", func(t *testing.T) {
+		client, bastions := newTunnelTestClient(t)
+
+		_, err := client.GetCluster(ctx, "projectID", "clusterID")
+		assert.NoError(t, err)
+
+		assert.NoError(t, client.CloseTunnel())
+		assert.True(t, (*bastions)[0].closed)
+	})
+
+	t.Run("check closing without tunnel", func(t *testing.T) {
+		client := newTestClient(t, http.NewServeMux())
+
+		assert.NoError(t, client.CloseTunnel())
+	})
+}
+
+func TestWithSSHTunnel(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	assert.NoError(t, err)
+	privateKeyPEM := string(pem.EncodeToMemory(block))
+
+	hostKey, err := ssh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+
+	knownHostsPath := filepath.Join(t.TempDir(), "known_hosts")
+	assert.NoError(t, os.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{"bastion:22"}, hostKey.PublicKey())+"\n"), 0600))
+
+	t.Run("check invalid private key is rejected", func(t *testing.T) {
+		_, err := NewClient("http://vke.internal", "key", "secret", "consumer_key", WithSSHTunnel("bastion:22", "user", "invalid", knownHostsPath, "10.0.0.1:443"))
+		assert.Error(t, err)
+	})
+
+	t.Run("check missing known hosts are rejected", func(t *testing.T) {
+		_, err := NewClient("http://vke.internal", "key", "secret", "consumer_key", WithSSHTunnel("bastion:22", "user", privateKeyPEM, "", "10.0.0.1:443"))
+		assert.Error(t, err)
+
+		_, err = NewClient("http://vke.internal", "key", "secret", "consumer_key", WithSSHTunnel("bastion:22", "user", privateKeyPEM, filepath.Join(t.TempDir(), "missing"), "10.0.0.1:443"))
+		assert.Error(t, err)
+	})
+
+	t.Run("check tunnel is installed", func(t *testing.T) {
+		client, err := NewClient("http://vke.internal", "key", "secret", "consumer_key", WithSSHTunnel("bastion:22", "user", privateKeyPEM, knownHostsPath, "10.0.0.1:443"))
+		assert.NoError(t, err)
+		assert.NotNil(t, client.tunnel)
+		assert.NoError(t, client.CloseTunnel())
+	})
+}
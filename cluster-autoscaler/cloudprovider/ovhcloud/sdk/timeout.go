@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// OperationTimeouts defines the timeout of the API requests by kind of operation.
+// The client Timeout is used for the ones left to zero.
+type OperationTimeouts struct {
+	// List is the timeout of the requests listing resources
+	List time.Duration
+	// Get is the timeout of the other GET requests
+	Get time.Duration
+	// Mutate is the timeout of the POST, PUT and PATCH requests
+	Mutate time.Duration
+	// Delete is the timeout of the DELETE requests
+	Delete time.Duration
+}
+
+// WithOperationTimeouts sets the timeout of the API requests by kind of operation,
+// e.g. to let the scale ups provisioning instances take longer than the listings
+func WithOperationTimeouts(timeouts OperationTimeouts) ClientOption {
+	return func(client *Client) error {
+		client.operationTimeouts = timeouts
+		return nil
+	}
+}
+
+// listOperationKey marks the contexts of the requests listing resources
+type listOperationKey struct{}
+
+// withListOperation marks the requests made with the returned context as listing resources
+func withListOperation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, listOperationKey{}, true)
+}
+
+// operationTimeout returns the timeout of a request given its method and context
+func (c *Client) operationTimeout(ctx context.Context, method string) time.Duration {
+	var timeout time.Duration
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		timeout = c.operationTimeouts.Get
+		if list, _ := ctx.Value(listOperationKey{}).(bool); list {
+			timeout = c.operationTimeouts.List
+		}
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		timeout = c.operationTimeouts.Mutate
+	case http.MethodDelete:
+		timeout = c.operationTimeouts.Delete
+	}
+
+	if timeout <= 0 {
+		return c.Timeout
+	}
+
+	return timeout
+}
+
+// httpClientTimeout returns the timeout of the HTTP client, long enough for every operation
+// as the timeout of each request is enforced by its context
+func (c *Client) httpClientTimeout() time.Duration {
+	timeout := c.Timeout
+	for _, operation := range []time.Duration{c.operationTimeouts.List, c.operationTimeouts.Get, c.operationTimeouts.Mutate, c.operationTimeouts.Delete} {
+		if timeout > 0 && operation > timeout {
+			timeout = operation
+		}
+	}
+
+	return timeout
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_OperationTimeouts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id": "poolID"}`))
+	})
+	client := newTestClient(t, mux)
+	client.operationTimeouts = OperationTimeouts{
+		List:   20 * time.Millisecond,
+		Mutate: 5 * time.Minute,
+	}
+
+	ctx := context.Background()
+
+	t.Run("check timeouts are chosen by operation", func(t *testing.T) {
+		assert.Equal(t, 20*time.Millisecond, client.operationTimeout(withListOperation(ctx), "GET"))
+		assert.Equal(t, DefaultTimeout, client.operationTimeout(ctx, "GET"))
+		assert.Equal(t, 5*time.Minute, client.operationTimeout(ctx, "PATCH"))
+		assert.Equal(t, DefaultTimeout, client.operationTimeout(ctx, "DELETE"))
+		assert.Equal(t, 5*time.Minute, client.httpClientTimeout())
+	})
+
+	t.Run("check the HTTP client timeout is set once created", func(t *testing.T) {
+		configured, err := NewClient("http://localhost", "key", "secret", "consumer_key", WithOperationTimeouts(OperationTimeouts{Mutate: 5 * time.Minute}))
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, configured.Client.Timeout)
+	})
+
+	t.Run("check listings fail fast", func(t *testing.T) {
+		_, err := client.ListNodePools(ctx, "projectID", "clusterID")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("check other operations use their own timeout", func(t *testing.T) {
+		_, err := client.GetNodePool(ctx, "projectID", "clusterID", "poolID")
+		assert.NoError(t, err)
+
+		_, err = client.UpdateNodePool(ctx, "projectID", "clusterID", "poolID", &UpdateNodePoolOpts{})
+		assert.NoError(t, err)
+	})
+}
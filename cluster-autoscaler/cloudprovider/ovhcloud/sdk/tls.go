@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// WithTLSClientCert authenticates the client with the certificate and key of the given PEM files, for the endpoints
+// requiring mutual TLS. When caFile is not empty, the endpoint certificates signed by its CA are trusted as well.
+// The files are loaded when the option is applied, so that invalid ones make the client creation fail.
+func WithTLSClientCert(certFile, keyFile, caFile string) ClientOption {
+	return func(client *Client) error {
+		if certFile == "" || keyFile == "" {
+			return errors.New("TLS client certificate and key files should not be empty")
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+
+		config := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+
+		if caFile != "" {
+			pool, err := loadCertPool(caFile)
+			if err != nil {
+				return err
+			}
+			config.RootCAs = pool
+		}
+
+		client.installTLSConfig(config)
+
+		return nil
+	}
+}
+
+// loadCertPool returns the system certificate pool with the certificates of the given PEM file added
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificate found in CA file %s", caFile)
+	}
+
+	return pool, nil
+}
+
+// installTLSConfig sets the TLS configuration on the client HTTP transport
+func (c *Client) installTLSConfig(config *tls.Config) {
+	if c.Client == nil {
+		c.Client = &http.Client{}
+	}
+
+	// Keep the connection pool settings of the current transport
+	base, ok := c.Client.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+
+	transport := base.Clone()
+	transport.TLSClientConfig = config
+
+	c.Client.Transport = transport
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeClientCert writes a self-signed client certificate and its key as PEM files, and returns their paths
+func writeClientCert(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "autoscaler"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return cert, certFile, keyFile
+}
+
+func TestWithTLSClientCert(t *testing.T) {
+	clientCert, certFile, keyFile := writeClientCert(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "cluster-id"}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	t.Run("check requests are authenticated with the client certificate", func(t *testing.T) {
+		client, err := NewClient(server.URL, "key", "secret", "consumer_key", WithTLSClientCert(certFile, keyFile, caFile))
+		assert.NoError(t, err)
+		client.openStackToken = "token"
+
+		cluster := Cluster{}
+		err = client.CallAPI("GET", "/cluster", nil, &cluster, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "cluster-id", cluster.ID)
+	})
+
+	t.Run("check requests fail without the client certificate", func(t *testing.T) {
+		client, err := NewClient(server.URL, "key", "secret", "consumer_key")
+		assert.NoError(t, err)
+		client.openStackToken = "token"
+
+		err = client.CallAPI("GET", "/cluster", nil, &Cluster{}, nil, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("check tuned transport is kept", func(t *testing.T) {
+		client, err := NewClient("ovh-eu", "key", "secret", "consumer_key",
+			WithHTTPClient(NewHTTPClient(20, 5, time.Minute)),
+			WithTLSClientCert(certFile, keyFile, ""),
+		)
+		assert.NoError(t, err)
+
+		transport := client.Client.Transport.(*http.Transport)
+		assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+		assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+		assert.Nil(t, transport.TLSClientConfig.RootCAs)
+	})
+
+	t.Run("check invalid files fail the client creation", func(t *testing.T) {
+		_, err := NewClient("ovh-eu", "key", "secret", "consumer_key", WithTLSClientCert("missing.crt", keyFile, ""))
+		assert.Error(t, err)
+
+		_, err = NewClient("ovh-eu", "key", "secret", "consumer_key", WithTLSClientCert(certFile, certFile, ""))
+		assert.Error(t, err)
+
+		_, err = NewClient("ovh-eu", "key", "secret", "consumer_key", WithTLSClientCert(certFile, keyFile, keyFile))
+		assert.Error(t, err)
+
+		_, err = NewClient("ovh-eu", "key", "secret", "consumer_key", WithTLSClientCert("", "", ""))
+		assert.Error(t, err)
+	})
+}
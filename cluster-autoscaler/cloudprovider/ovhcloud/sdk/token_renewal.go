@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultTokenRenewBefore is how long before its expiration an OpenStack keystone token is renewed
+	DefaultTokenRenewBefore = time.Hour
+
+	// tokenRenewalRetryInterval is the back-off applied after a failed token renewal
+	tokenRenewalRetryInterval = 30 * time.Second
+)
+
+// TokenProvider gives an OpenStack keystone token which can be renewed, such as OpenStackProvider
+type TokenProvider interface {
+	// GetToken returns the current token
+	GetToken() string
+
+	// TokenExpirationTime returns when the current token expires
+	TokenExpirationTime() time.Time
+
+	// ReauthenticateToken replaces the current token by a new one
+	ReauthenticateToken() error
+}
+
+// StartTokenRenewal renews the token of the provider in the background, renewBefore its expiration.
+// The returned function stops the renewal.
+func StartTokenRenewal(ctx context.Context, provider TokenProvider, renewBefore time.Duration) func() {
+	return startTokenRenewal(ctx, SystemClock{}, provider, renewBefore)
+}
+
+func startTokenRenewal(ctx context.Context, clock Clock, provider TokenProvider, renewBefore time.Duration) func() {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		for {
+			renewAt := provider.TokenExpirationTime().Add(-renewBefore)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(renewAt.Sub(clock.Now())):
+			}
+
+			err := provider.ReauthenticateToken()
+			if err == nil {
+				klog.V(2).Infof("OpenStack token renewed, expires at %s", provider.TokenExpirationTime())
+				continue
+			}
+
+			klog.Warningf("Failed to renew OpenStack token, retrying in %s: %v", tokenRenewalRetryInterval, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(tokenRenewalRetryInterval):
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// WithTokenProvider authenticates the client with the token of the provider,
+// renewed in the background renewBefore its expiration
+func WithTokenProvider(provider TokenProvider, renewBefore time.Duration) ClientOption {
+	return func(client *Client) error {
+		client.tokenProvider = provider
+		client.tokenRenewBefore = renewBefore
+		return nil
+	}
+}
+
+// StopTokenRenewal stops the background renewal of the token, if any
+func (c *Client) StopTokenRenewal() {
+	if c.stopTokenRenewal != nil {
+		c.stopTokenRenewal()
+	}
+}
+
+// token returns the OpenStack keystone token authenticating the requests, if any
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.tokenSource != nil {
+		return c.tokenSource.Token(ctx)
+	}
+
+	if c.tokenProvider != nil {
+		return c.tokenProvider.GetToken(), nil
+	}
+
+	return c.openStackToken, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTokenProvider issues tokens valid for two hours on the fake clock
+type fakeTokenProvider struct {
+	clock   *FakeClock
+	token   string
+	expiry  time.Time
+	fail    int
+	renewal int
+	renewed chan string
+	mutex   sync.Mutex
+}
+
+func newFakeTokenProvider(clock *FakeClock) *fakeTokenProvider {
+	return &fakeTokenProvider{
+		clock:   clock,
+		token:   "token-0",
+		expiry:  clock.Now().Add(2 * time.Hour),
+		renewed: make(chan string, 10),
+	}
+}
+
+func (p *fakeTokenProvider) GetToken() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.token
+}
+
+func (p *fakeTokenProvider) TokenExpirationTime() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.expiry
+}
+
+func (p *fakeTokenProvider) ReauthenticateToken() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.fail > 0 {
+		p.fail--
+		return errors.New("keystone unavailable")
+	}
+
+	p.renewal++
+	p.token = fmt.Sprintf("token-%d", p.renewal)
+	p.expiry = p.clock.Now().Add(2 * time.Hour)
+	p.renewed <- p.token
+
+	return nil
+}
+
+// advanceWhenWaiting advances the clock once the renewal goroutine waits on it
+func advanceWhenWaiting(t *testing.T, clock *FakeClock, d time.Duration) {
+	assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+	clock.Advance(d)
+}
+
+func TestStartTokenRenewal(t *testing.T) {
+	t.Run("check token is renewed before its expiration", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		provider := newFakeTokenProvider(clock)
+
+		stop := startTokenRenewal(context.Background(), clock, provider, 30*time.Minute)
+		defer stop()
+
+		advanceWhenWaiting(t, clock, time.Hour)
+		assert.Empty(t, provider.renewed)
+
+		advanceWhenWaiting(t, clock, 30*time.Minute)
+		assert.Equal(t, "token-1", <-provider.renewed)
+		assert.Equal(t, clock.Now().Add(2*time.Hour), provider.TokenExpirationTime())
+
+		// The renewed token is renewed again
+		advanceWhenWaiting(t, clock, 90*time.Minute)
+		assert.Equal(t, "token-2", <-provider.renewed)
+	})
+
+	t.Run("check failed renewal is retried after a back-off", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		provider := newFakeTokenProvider(clock)
+		provider.fail = 1
+
+		stop := startTokenRenewal(context.Background(), clock, provider, 30*time.Minute)
+		defer stop()
+
+		advanceWhenWaiting(t, clock, 90*time.Minute)
+		advanceWhenWaiting(t, clock, tokenRenewalRetryInterval)
+		assert.Equal(t, "token-1", <-provider.renewed)
+	})
+
+	t.Run("check stopped renewal does not renew the token", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		provider := newFakeTokenProvider(clock)
+
+		stop := startTokenRenewal(context.Background(), clock, provider, 30*time.Minute)
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		stop()
+
+		clock.Advance(2 * time.Hour)
+		assert.Never(t, func() bool { return len(provider.renewed) > 0 }, 50*time.Millisecond, time.Millisecond)
+	})
+}
+
+func TestClient_WithTokenProvider(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	provider := newFakeTokenProvider(clock)
+
+	var authorization string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool", func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	})
+	client := newTestClient(t, mux)
+
+	err := WithClock(clock)(client)
+	assert.NoError(t, err)
+	err = WithTokenProvider(provider, 30*time.Minute)(client)
+	assert.NoError(t, err)
+
+	t.Run("check requests use the provider token", func(t *testing.T) {
+		_, err := client.ListNodePools(context.Background(), "projectID", "clusterID")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer OpenStack/token-0", authorization)
+	})
+
+	t.Run("check requests use the renewed token", func(t *testing.T) {
+		assert.NoError(t, provider.ReauthenticateToken())
+
+		_, err := client.ListNodePools(context.Background(), "projectID", "clusterID")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer OpenStack/token-1", authorization)
+	})
+}
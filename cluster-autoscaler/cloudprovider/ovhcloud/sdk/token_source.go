@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/magnum/gophercloud"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/magnum/gophercloud/openstack"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/magnum/gophercloud/openstack/identity/v3/tokens"
+)
+
+// DefaultKeystoneRefreshBefore is how long before its expiration a keystone token is refreshed
+const DefaultKeystoneRefreshBefore = 5 * time.Minute
+
+// TokenSource gives the OpenStack keystone token authenticating every request.
+// Unlike a TokenProvider, renewed in the background, it is asked for a token before each request.
+type TokenSource interface {
+	// Token returns a valid token
+	Token(ctx context.Context) (string, error)
+}
+
+// WithTokenSource authenticates the requests of the client with the tokens of the source
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(client *Client) error {
+		client.tokenSource = source
+		return nil
+	}
+}
+
+// TokenProviderSource gives the current token of the provider, for a client to share the token renewed by another one
+func TokenProviderSource(provider TokenProvider) TokenSource {
+	return providerTokenSource{provider: provider}
+}
+
+type providerTokenSource struct {
+	provider TokenProvider
+}
+
+// Token returns the current token of the provider
+func (s providerTokenSource) Token(_ context.Context) (string, error) {
+	return s.provider.GetToken(), nil
+}
+
+// StaticTokenProvider always gives the same token
+type StaticTokenProvider string
+
+// Token returns the static token
+func (p StaticTokenProvider) Token(_ context.Context) (string, error) {
+	return string(p), nil
+}
+
+// KeystoneTokenProvider authenticates against the identity v3 endpoint with username/password
+// credentials, and authenticates again once its token is about to expire
+type KeystoneTokenProvider struct {
+	identity *gophercloud.ServiceClient
+	options  tokens.AuthOptions
+
+	// RefreshBefore is how long before its expiration the token is refreshed
+	RefreshBefore time.Duration
+
+	clock     Clock
+	token     string
+	expiresAt time.Time
+	mutex     sync.Mutex
+}
+
+// NewKeystoneTokenProvider creates a token provider authenticating against the identity endpoint of authUrl.
+// The first token is only created on the first call to Token.
+func NewKeystoneTokenProvider(authUrl string, username string, password string, domain string, tenant string) (*KeystoneTokenProvider, error) {
+	provider, err := openstack.NewClient(authUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenStack client: %w", err)
+	}
+
+	identity, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenStack identity v3 client: %w", err)
+	}
+
+	return &KeystoneTokenProvider{
+		identity: identity,
+		options: tokens.AuthOptions{
+			Username:   username,
+			Password:   password,
+			DomainName: domain,
+			Scope:      tokens.Scope{ProjectID: tenant},
+		},
+		RefreshBefore: DefaultKeystoneRefreshBefore,
+		clock:         SystemClock{},
+	}, nil
+}
+
+// Token returns the current token, creating a new one when it expires in less than RefreshBefore
+func (p *KeystoneTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != "" && p.clock.Now().Add(p.RefreshBefore).Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	// Requests of the identity client are bound to the context of the caller
+	p.identity.ProviderClient.Context = ctx
+	defer func() { p.identity.ProviderClient.Context = nil }()
+
+	// The token itself is given by the X-Subject-Token header, its expiration by the body
+	token, err := tokens.Create(p.identity, &p.options).ExtractToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to create keystone token: %w", err)
+	}
+
+	if token.ID == "" {
+		return "", fmt.Errorf("failed to create keystone token: X-Subject-Token header is missing")
+	}
+
+	p.token = token.ID
+	p.expiresAt = token.ExpiresAt
+
+	return p.token, nil
+}
+
+// ExpiresAt returns when the current token expires
+func (p *KeystoneTokenProvider) ExpiresAt() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.expiresAt
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestKeystone issues tokens valid for an hour, answering with an error once failing is set
+func newTestKeystone(t *testing.T, clock *FakeClock, failing *bool) (*httptest.Server, *int) {
+	issued := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		if *failing {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, fmt.Sprint(body), "password:secret")
+
+		issued++
+		w.Header().Set("X-Subject-Token", fmt.Sprintf("token-%d", issued))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token": {"expires_at": %q}}`, clock.Now().Add(time.Hour).Format(time.RFC3339))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func TestKeystoneTokenProvider_Token(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC))
+	failing := false
+	server, issued := newTestKeystone(t, clock, &failing)
+
+	provider, err := NewKeystoneTokenProvider(server.URL+"/v3", "user", "secret", "Default", "tenant")
+	assert.NoError(t, err)
+	provider.clock = clock
+
+	t.Run("check a token is created on first call", func(t *testing.T) {
+		token, err := provider.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		assert.Equal(t, clock.Now().Add(time.Hour), provider.ExpiresAt())
+	})
+
+	t.Run("check the token is kept until it nearly expires", func(t *testing.T) {
+		clock.Advance(54 * time.Minute)
+
+		token, err := provider.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		assert.Equal(t, 1, *issued)
+	})
+
+	t.Run("check the token is refreshed within 5 minutes of its expiration", func(t *testing.T) {
+		clock.Advance(2 * time.Minute)
+
+		token, err := provider.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-2", token)
+		assert.Equal(t, clock.Now().Add(time.Hour), provider.ExpiresAt())
+	})
+
+	t.Run("check authentication errors are returned", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		failing = true
+
+		_, err := provider.Token(ctx)
+		assert.ErrorContains(t, err, "failed to create keystone token")
+	})
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	authorizations := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations <- r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("check the token of the source authenticates the requests", func(t *testing.T) {
+		client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(StaticTokenProvider("static")))
+		assert.NoError(t, err)
+
+		err = client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer OpenStack/static", <-authorizations)
+	})
+
+	t.Run("check the current token of a provider authenticates the requests", func(t *testing.T) {
+		provider := newFakeTokenProvider(NewFakeClock(time.Now()))
+
+		client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(TokenProviderSource(provider)))
+		assert.NoError(t, err)
+
+		err = client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer OpenStack/token-0", <-authorizations)
+	})
+
+	t.Run("check requests are not sent without token", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		failing := true
+		keystone, _ := newTestKeystone(t, clock, &failing)
+
+		provider, err := NewKeystoneTokenProvider(keystone.URL+"/v3", "user", "secret", "Default", "tenant")
+		assert.NoError(t, err)
+
+		client, err := NewClient(server.URL, "none", "none", "none", WithTokenSource(provider))
+		assert.NoError(t, err)
+
+		err = client.CallAPIWithContext(context.Background(), "GET", "/pool", nil, nil, nil, nil, true)
+		assert.ErrorContains(t, err, "failed to get OpenStack token")
+		assert.Len(t, authorizations, 0)
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// UsageGranularityHour aggregates usage data points per hour
+	UsageGranularityHour = "hour"
+
+	// UsageGranularityDay aggregates usage data points per day
+	UsageGranularityDay = "day"
+)
+
+// UsageDataPoint is the usage of a node pool over one period of a usage report
+type UsageDataPoint struct {
+	Timestamp     time.Time `json:"timestamp"`
+	NodeCount     int       `json:"nodeCount"`
+	CPUHours      float64   `json:"cpuHours"`
+	MemoryGBHours float64   `json:"memoryGBHours"`
+}
+
+// UsageReport describes the usage of a node pool over a time range
+type UsageReport struct {
+	DataPoints []UsageDataPoint `json:"dataPoints"`
+}
+
+// GetNodePoolUsageReport allows to get the usage of a node pool between from and to,
+// aggregated by hour or by day
+func (c *Client) GetNodePoolUsageReport(ctx context.Context, projectID string, clusterID string, poolID string, from time.Time, to time.Time, granularity string) (*UsageReport, error) {
+	if granularity != UsageGranularityHour && granularity != UsageGranularityDay {
+		return nil, fmt.Errorf("unknown usage granularity %q, expected %q or %q", granularity, UsageGranularityHour, UsageGranularityDay)
+	}
+
+	if !from.Before(to) {
+		return nil, fmt.Errorf("usage report start %s must be before its end %s", from, to)
+	}
+
+	report := &UsageReport{}
+
+	return report, c.CallAPIWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("/cloud/project/%s/kube/%s/nodepool/%s/usage", projectID, clusterID, poolID),
+		nil,
+		&report,
+		url.Values{
+			"from":        []string{strconv.FormatInt(from.Unix(), 10)},
+			"to":          []string{strconv.FormatInt(to.Unix(), 10)},
+			"granularity": []string{granularity},
+		},
+		nil,
+		true,
+	)
+}
+
+// TotalCPUHours sums the CPU hours of the data points of a usage report
+func TotalCPUHours(r *UsageReport) float64 {
+	total := 0.0
+	for _, point := range r.DataPoints {
+		total += point.CPUHours
+	}
+
+	return total
+}
+
+// TotalMemoryGBHours sums the memory GB hours of the data points of a usage report
+func TotalMemoryGBHours(r *UsageReport) float64 {
+	total := 0.0
+	for _, point := range r.DataPoints {
+		total += point.MemoryGBHours
+	}
+
+	return total
+}
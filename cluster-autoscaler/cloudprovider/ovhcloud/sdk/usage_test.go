@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const usageReportFixture = `{"dataPoints": [
+	{"timestamp": "2024-03-04T00:00:00Z", "nodeCount": 3, "cpuHours": 144, "memoryGBHours": 504},
+	{"timestamp": "2024-03-05T00:00:00Z", "nodeCount": 4, "cpuHours": 192.5, "memoryGBHours": 672.25},
+	{"timestamp": "2024-03-06T00:00:00Z", "nodeCount": 2, "cpuHours": 96, "memoryGBHours": 336}
+]}`
+
+func TestClient_GetNodePoolUsageReport(t *testing.T) {
+	var query url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cloud/project/projectID/kube/clusterID/nodepool/poolID/usage", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(usageReportFixture))
+	})
+	client := newTestClient(t, mux)
+
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * 24 * time.Hour)
+
+	t.Run("check report is fetched and aggregated", func(t *testing.T) {
+		report, err := client.GetNodePoolUsageReport(context.Background(), "projectID", "clusterID", "poolID", from, to, UsageGranularityDay)
+		assert.NoError(t, err)
+
+		assert.Equal(t, url.Values{"from": {"1709510400"}, "to": {"1709769600"}, "granularity": {"day"}}, query)
+
+		assert.Len(t, report.DataPoints, 3)
+		assert.Equal(t, UsageDataPoint{Timestamp: from, NodeCount: 3, CPUHours: 144, MemoryGBHours: 504}, report.DataPoints[0])
+
+		assert.Equal(t, 432.5, TotalCPUHours(report))
+		assert.Equal(t, 1512.25, TotalMemoryGBHours(report))
+	})
+
+	t.Run("check invalid granularity", func(t *testing.T) {
+		_, err := client.GetNodePoolUsageReport(context.Background(), "projectID", "clusterID", "poolID", from, to, "week")
+		assert.Error(t, err)
+	})
+
+	t.Run("check invalid time range", func(t *testing.T) {
+		_, err := client.GetNodePoolUsageReport(context.Background(), "projectID", "clusterID", "poolID", to, from, UsageGranularityHour)
+		assert.Error(t, err)
+	})
+
+	t.Run("check empty report totals", func(t *testing.T) {
+		assert.Zero(t, TotalCPUHours(&UsageReport{}))
+		assert.Zero(t, TotalMemoryGBHours(&UsageReport{}))
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// WarmupCronAnnotation is the name of the annotation holding the cron expression at which warm nodes are added
+	WarmupCronAnnotation = "warmup-cron"
+
+	// WarmupNodesAnnotation is the name of the annotation holding the number of warm nodes added at warmup
+	WarmupNodesAnnotation = "warmup-nodes"
+
+	// CooldownCronAnnotation is the name of the annotation holding the cron expression at which warm nodes are removed
+	CooldownCronAnnotation = "cooldown-cron"
+)
+
+// WarmupSchedule defines when standby nodes are pre-provisioned in a node pool ahead of peak hours
+type WarmupSchedule struct {
+	CronExpression         string `json:"cronExpression"`
+	WarmNodes              int    `json:"warmNodes"`
+	CooldownCronExpression string `json:"cooldownCronExpression,omitempty"`
+}
+
+// Validate checks the cron expressions and the number of warm nodes of the schedule
+func (s *WarmupSchedule) Validate() error {
+	if s.WarmNodes <= 0 {
+		return fmt.Errorf("warm nodes should be positive, got %d", s.WarmNodes)
+	}
+
+	if _, err := cron.ParseStandard(s.CronExpression); err != nil {
+		return fmt.Errorf("invalid warmup cron expression %q: %w", s.CronExpression, err)
+	}
+
+	if s.CooldownCronExpression != "" {
+		if _, err := cron.ParseStandard(s.CooldownCronExpression); err != nil {
+			return fmt.Errorf("invalid cooldown cron expression %q: %w", s.CooldownCronExpression, err)
+		}
+	}
+
+	return nil
+}
+
+// SetWarmupSchedule stores the warmup schedule in the node pool annotations
+func (c *Client) SetWarmupSchedule(ctx context.Context, projectID string, clusterID string, poolID string, sched *WarmupSchedule) error {
+	if err := sched.Validate(); err != nil {
+		return err
+	}
+
+	return c.updateNodePoolTemplate(ctx, projectID, clusterID, poolID, func(template *NodePoolTemplate) {
+		annotations := template.Metadata.Annotations
+
+		annotations[c.AnnotationKey(WarmupCronAnnotation)] = sched.CronExpression
+		annotations[c.AnnotationKey(WarmupNodesAnnotation)] = strconv.Itoa(sched.WarmNodes)
+
+		delete(annotations, c.AnnotationKey(CooldownCronAnnotation))
+		if sched.CooldownCronExpression != "" {
+			annotations[c.AnnotationKey(CooldownCronAnnotation)] = sched.CooldownCronExpression
+		}
+	})
+}
+
+// GetWarmupSchedule reads the warmup schedule of a node pool, nil if none is set
+func (c *Client) GetWarmupSchedule(ctx context.Context, projectID string, clusterID string, poolID string) (*WarmupSchedule, error) {
+	pool, err := c.GetNodePool(ctx, projectID, clusterID, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %s: %w", poolID, err)
+	}
+
+	annotations := pool.Template.Metadata.Annotations
+	expression, ok := annotations[c.AnnotationKey(WarmupCronAnnotation)]
+	if !ok {
+		return nil, nil
+	}
+
+	warmNodes, err := strconv.Atoi(annotations[c.AnnotationKey(WarmupNodesAnnotation)])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", c.AnnotationKey(WarmupNodesAnnotation), err)
+	}
+
+	return &WarmupSchedule{
+		CronExpression:         expression,
+		WarmNodes:              warmNodes,
+		CooldownCronExpression: annotations[c.AnnotationKey(CooldownCronAnnotation)],
+	}, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmupSchedule_Validate(t *testing.T) {
+	t.Run("check valid schedule", func(t *testing.T) {
+		assert.NoError(t, (&WarmupSchedule{CronExpression: "0 8 * * 1-5", WarmNodes: 2, CooldownCronExpression: "0 20 * * 1-5"}).Validate())
+	})
+
+	t.Run("check invalid cron expressions are rejected", func(t *testing.T) {
+		assert.Error(t, (&WarmupSchedule{CronExpression: "every morning", WarmNodes: 2}).Validate())
+		assert.Error(t, (&WarmupSchedule{CronExpression: "0 8 * * *", WarmNodes: 2, CooldownCronExpression: "61 * * * *"}).Validate())
+	})
+
+	t.Run("check warm nodes should be positive", func(t *testing.T) {
+		assert.Error(t, (&WarmupSchedule{CronExpression: "0 8 * * *"}).Validate())
+	})
+}
+
+func TestClient_SetWarmupSchedule(t *testing.T) {
+	client, pool := newTemplateTestClient(t, nil)
+
+	sched, err := client.GetWarmupSchedule(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Nil(t, sched)
+
+	expected := &WarmupSchedule{CronExpression: "0 8 * * 1-5", WarmNodes: 3, CooldownCronExpression: "0 20 * * 1-5"}
+	err = client.SetWarmupSchedule(context.Background(), "projectID", "clusterID", "poolID", expected)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", pool.Template.Metadata.Annotations["team"])
+
+	sched, err = client.GetWarmupSchedule(context.Background(), "projectID", "clusterID", "poolID")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sched)
+
+	err = client.SetWarmupSchedule(context.Background(), "projectID", "clusterID", "poolID", &WarmupSchedule{CronExpression: "invalid", WarmNodes: 1})
+	assert.Error(t, err)
+}
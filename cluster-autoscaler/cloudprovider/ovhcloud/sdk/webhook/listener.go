@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// SignatureHeader is the header holding the HMAC-SHA256 signature of the request body
+const SignatureHeader = "X-VKE-Signature"
+
+// signaturePrefix is prepended to the hex encoded signature in SignatureHeader
+const signaturePrefix = "sha256="
+
+// DefaultBufferSize is the default number of events which can be queued before being consumed
+const DefaultBufferSize = 64
+
+// maxBodySize limits the size of the webhook payloads read by the listener
+const maxBodySize = 1 << 20
+
+const (
+	// readHeaderTimeout and readTimeout bound the time given to the clients to send their requests
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = 30 * time.Second
+)
+
+// ErrEmptySecret is returned when the webhook requests would not be authenticated, the secret being empty
+var ErrEmptySecret = errors.New("webhook secret should not be empty")
+
+// WebhookEvent is a scale trigger pushed by the platform
+type WebhookEvent struct {
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	ReceivedAt time.Time       `json:"-"`
+}
+
+// WebhookListener receives inbound scale triggers pushed by the platform
+type WebhookListener struct {
+	// BufferSize is the number of events which can be queued before being consumed.
+	// DefaultBufferSize is used when zero.
+	BufferSize int
+}
+
+// ListenAndServe listens on addr and sends every valid event on the returned channel.
+// Requests are authenticated using the HMAC-SHA256 signature of their body keyed with secret.
+// The server is stopped and the channel closed once ctx is done.
+func (l *WebhookListener) ListenAndServe(ctx context.Context, addr string, secret string) (<-chan WebhookEvent, error) {
+	bufferSize := l.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	events := make(chan WebhookEvent, bufferSize)
+	handler, err := NewHandler(secret, events)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Errorf("webhook listener on %s stopped: %v", addr, err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		// Shutdown waits for the running handlers, so nothing is sent on the channel once closed
+		if err := server.Shutdown(context.Background()); err != nil {
+			klog.Warningf("failed to shutdown webhook listener on %s: %v", addr, err)
+		}
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// NewHandler returns the HTTP handler validating webhook requests and sending their events on the channel.
+// ErrEmptySecret is returned when the secret is empty.
+func NewHandler(secret string, events chan<- WebhookEvent) (http.Handler, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if !ValidSignature(secret, body, r.Header.Get(SignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+
+		event, err := ParseWebhookEvent(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case events <- *event:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "too many pending events", http.StatusServiceUnavailable)
+		}
+	}), nil
+}
+
+// ParseWebhookEvent decodes a webhook request body into an event
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook event: %w", err)
+	}
+
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook event type is missing")
+	}
+
+	event.ReceivedAt = time.Now()
+
+	return event, nil
+}
+
+// Sign computes the signature header value of a body given the shared secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature checks in constant time that the signature matches the body and the shared secret
+func ValidSignature(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, body)))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func postEvent(t *testing.T, url string, body []byte, signature string) *http.Response {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	assert.NoError(t, err)
+
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	return resp
+}
+
+func TestNewHandler(t *testing.T) {
+	events := make(chan WebhookEvent, 1)
+	handler, err := NewHandler("secret", events)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := []byte(`{"type": "scale-up", "payload": {"poolId": "pool-1", "delta": 2}}`)
+
+	t.Run("check signed event is accepted", func(t *testing.T) {
+		resp := postEvent(t, server.URL, body, Sign("secret", body))
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		event := <-events
+		assert.Equal(t, "scale-up", event.Type)
+		assert.JSONEq(t, `{"poolId": "pool-1", "delta": 2}`, string(event.Payload))
+		assert.False(t, event.ReceivedAt.IsZero())
+	})
+
+	t.Run("check unsigned event is rejected", func(t *testing.T) {
+		resp := postEvent(t, server.URL, body, "")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Empty(t, events)
+	})
+
+	t.Run("check event signed with another secret is rejected", func(t *testing.T) {
+		resp := postEvent(t, server.URL, body, Sign("other", body))
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Empty(t, events)
+	})
+
+	t.Run("check non POST request is rejected", func(t *testing.T) {
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("check valid event", func(t *testing.T) {
+		event, err := ParseWebhookEvent([]byte(`{"type": "scale-down", "payload": null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "scale-down", event.Type)
+	})
+
+	t.Run("check event without type", func(t *testing.T) {
+		_, err := ParseWebhookEvent([]byte(`{"payload": {}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("check malformed event", func(t *testing.T) {
+		_, err := ParseWebhookEvent([]byte(`not json`))
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookListener_ListenAndServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	listener := &WebhookListener{}
+	events, err := listener.ListenAndServe(ctx, "127.0.0.1:0", "secret")
+	assert.NoError(t, err)
+
+	// The events channel is closed once the context is done
+	cancel()
+	for range events {
+	}
+}
+
+func TestEmptySecretIsRejected(t *testing.T) {
+	_, err := NewHandler("", make(chan WebhookEvent))
+	assert.ErrorIs(t, err, ErrEmptySecret)
+
+	listener := &WebhookListener{}
+	_, err = listener.ListenAndServe(context.Background(), "127.0.0.1:0", "")
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
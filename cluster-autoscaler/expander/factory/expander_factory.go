@@ -77,6 +77,12 @@ func (f *Factory) Build(names []string) (expander.Strategy, errors.AutoscalerErr
 	return newChainStrategy(filters, random.NewStrategy()), nil
 }
 
+// FilterProvider is implemented by cloud providers offering their own expanders.
+type FilterProvider interface {
+	// ExpanderFilters returns the functions creating the expanders, by expander name.
+	ExpanderFilters() map[string]func() expander.Filter
+}
+
 // RegisterDefaultExpanders is a convenience function, registering all known expanders in the Factory.
 func (f *Factory) RegisterDefaultExpanders(cloudProvider cloudprovider.CloudProvider, autoscalingKubeClients *context.AutoscalingKubeClients, kubeClient kube_client.Interface, configNamespace string, GRPCExpanderCert string, GRPCExpanderURL string) {
 	f.RegisterFilter(expander.RandomExpanderName, random.NewFilter)
@@ -96,4 +102,9 @@ func (f *Factory) RegisterDefaultExpanders(cloudProvider cloudprovider.CloudProv
 		return priority.NewFilter(lister.ConfigMaps(configNamespace), autoscalingKubeClients.Recorder)
 	})
 	f.RegisterFilter(expander.GRPCExpanderName, func() expander.Filter { return grpcplugin.NewFilter(GRPCExpanderCert, GRPCExpanderURL) })
+	if provider, ok := cloudProvider.(FilterProvider); ok {
+		for name, createFunc := range provider.ExpanderFilters() {
+			f.RegisterFilter(name, createFunc)
+		}
+	}
 }
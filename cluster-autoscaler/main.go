@@ -441,7 +441,12 @@ func registerSignalHandlers(autoscaler core.Autoscaler) {
 	}()
 }
 
-func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) (core.Autoscaler, error) {
+// debugHandlersRegisterer is implemented by cloud providers exposing their own debugging endpoints.
+type debugHandlersRegisterer interface {
+	RegisterDebugHandlers(mux *mux.PathRecorderMux)
+}
+
+func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, debugMux *mux.PathRecorderMux) (core.Autoscaler, error) {
 	// Create basic config from flags.
 	autoscalingOptions := createAutoscalingOptions()
 
@@ -476,6 +481,12 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 		DrainabilityRules:    drainabilityRules,
 	}
 
+	// Built here rather than by the autoscaler, for its debugging endpoints to be registered.
+	opts.CloudProvider = cloudBuilder.NewCloudProvider(autoscalingOptions, informerFactory)
+	if registerer, ok := opts.CloudProvider.(debugHandlersRegisterer); ok {
+		registerer.RegisterDebugHandlers(debugMux)
+	}
+
 	opts.Processors = ca_processors.DefaultProcessors(autoscalingOptions)
 	opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nodeInfoCacheExpireTime, *forceDaemonSets)
 	podListProcessor := podlistprocessor.NewDefaultPodListProcessor(opts.PredicateChecker)
@@ -535,10 +546,10 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 	return autoscaler, nil
 }
 
-func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) {
+func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, debugMux *mux.PathRecorderMux) {
 	metrics.RegisterAll(*emitPerNodeGroupMetrics)
 
-	autoscaler, err := buildAutoscaler(debuggingSnapshotter)
+	autoscaler, err := buildAutoscaler(debuggingSnapshotter, debugMux)
 	if err != nil {
 		klog.Fatalf("Failed to create autoscaler: %v", err)
 	}
@@ -605,8 +616,8 @@ func main() {
 
 	debuggingSnapshotter := debuggingsnapshot.NewDebuggingSnapshotter(*debuggingSnapshotEnabled)
 
+	pathRecorderMux := mux.NewPathRecorderMux("cluster-autoscaler")
 	go func() {
-		pathRecorderMux := mux.NewPathRecorderMux("cluster-autoscaler")
 		defaultMetricsHandler := legacyregistry.Handler().ServeHTTP
 		pathRecorderMux.HandleFunc("/metrics", func(w http.ResponseWriter, req *http.Request) {
 			defaultMetricsHandler(w, req)
@@ -623,7 +634,7 @@ func main() {
 	}()
 
 	if !leaderElection.LeaderElect {
-		run(healthCheck, debuggingSnapshotter)
+		run(healthCheck, debuggingSnapshotter, pathRecorderMux)
 	} else {
 		id, err := os.Hostname()
 		if err != nil {
@@ -663,7 +674,7 @@ func main() {
 				OnStartedLeading: func(_ ctx.Context) {
 					// Since we are committing a suicide after losing
 					// mastership, we can safely ignore the argument.
-					run(healthCheck, debuggingSnapshotter)
+					run(healthCheck, debuggingSnapshotter, pathRecorderMux)
 				},
 				OnStoppedLeading: func() {
 					klog.Fatalf("lost master")